@@ -7,12 +7,15 @@ import (
 	"os/signal"
 	"syscall"
 
+	"github.com/Di-nis/shortener-url/internal/healthcheck"
 	"github.com/Di-nis/shortener-url/internal/logger"
+	"github.com/Di-nis/shortener-url/internal/netguard"
 	grpcServer "github.com/Di-nis/shortener-url/internal/server/grpc"
 	httpServer "github.com/Di-nis/shortener-url/internal/server/http"
-	"github.com/Di-nis/shortener-url/internal/service"
+	"github.com/Di-nis/shortener-url/internal/webhook"
 
 	"github.com/joho/godotenv"
+	"golang.org/x/sync/errgroup"
 )
 
 // Run - запуск приложения.
@@ -29,17 +32,92 @@ func Run() error {
 		return err
 	}
 
+	if err = checkWebhookStorage(cfg); err != nil {
+		return err
+	}
+
 	repo, err := initStorage(cfg)
 	if err != nil {
 		return err
 	}
 
-	svc := service.NewService()
+	svc, err := initService(cfg, repo)
+	if err != nil {
+		_ = repo.Close()
+		return err
+	}
+	urlUseCase := initUseCase(cfg, repo, svc)
+
+	// ошибка любого из серверов отменяет groupCtx и останавливает остальные компоненты
+	group, groupCtx := errgroup.WithContext(ctx)
+
+	// фоновая проверка доступности оригинальных URL
+	if cfg.HealthCheckEnabled {
+		checker := healthcheck.NewChecker(repo, netguard.NewPublicClient(), healthcheck.Options{
+			Interval:     cfg.HealthCheckInterval,
+			Timeout:      cfg.HealthCheckTimeout,
+			Concurrency:  cfg.HealthCheckConcurrency,
+			HostInterval: cfg.HealthCheckHostInterval,
+			MarkBroken:   cfg.HealthCheckMarkBroken,
+		})
+		group.Go(func() error {
+			checker.Run(groupCtx)
+			return nil
+		})
+	}
+
+	// фоновая доставка событий на адреса подписок
+	if cfg.EnableWebhooks {
+		dispatcher := webhook.NewDispatcher(repo, netguard.NewPublicClient(), webhook.Options{
+			PollInterval: cfg.WebhookPollInterval,
+			Timeout:      cfg.WebhookTimeout,
+			MaxAttempts:  cfg.WebhookMaxAttempts,
+			BackoffBase:  cfg.WebhookBackoffBase,
+			BackoffMax:   cfg.WebhookBackoffMax,
+		})
+		group.Go(func() error {
+			dispatcher.Run(groupCtx)
+			return nil
+		})
+	}
+
+	// фоновое получение заголовков страниц для новых URL
+	if cfg.FetchTitles {
+		group.Go(func() error {
+			urlUseCase.RunTitleWorkers(groupCtx, cfg.TitleFetchConcurrency)
+			return nil
+		})
+	}
+
+	switch {
+	case cfg.GRPCAddress != "":
+		// HTTP- и gRPC-серверы работают одновременно на разных адресах
+		group.Go(func() error {
+			return httpServer.Run(groupCtx, cfg, repo, urlUseCase)
+		})
+		group.Go(func() error {
+			return grpcServer.Run(groupCtx, cfg, cfg.GRPCAddress, urlUseCase)
+		})
+	case cfg.EnableGRPC:
+		// только gRPC-сервер
+		group.Go(func() error {
+			return grpcServer.Run(groupCtx, cfg, cfg.ServerAddress, urlUseCase)
+		})
+	default:
+		// только HTTP-сервер
+		group.Go(func() error {
+			return httpServer.Run(groupCtx, cfg, repo, urlUseCase)
+		})
+	}
+
+	err = group.Wait()
 
-	// gRPC-сервер
-	if cfg.EnableGRPC {
-		return grpcServer.Run(ctx, cfg, repo, svc)
+	// хранилище закрывается один раз после остановки всех серверов
+	if closeErr := repo.Close(); closeErr != nil {
+		logger.Sugar.Errorf("failed closing database: %v", closeErr)
+		if err == nil {
+			err = closeErr
+		}
 	}
-	// HTTP-сервер
-	return httpServer.Run(ctx, cfg, repo, svc)
+	return err
 }
@@ -4,9 +4,14 @@ import (
 	"fmt"
 
 	"github.com/Di-nis/shortener-url/internal/config"
+	"github.com/Di-nis/shortener-url/internal/constants"
 	"github.com/Di-nis/shortener-url/internal/logger"
 	"github.com/Di-nis/shortener-url/internal/models"
+	"github.com/Di-nis/shortener-url/internal/netguard"
+	"github.com/Di-nis/shortener-url/internal/pagetitle"
+	"github.com/Di-nis/shortener-url/internal/redirect"
 	"github.com/Di-nis/shortener-url/internal/repository"
+	"github.com/Di-nis/shortener-url/internal/service"
 	"github.com/Di-nis/shortener-url/internal/storage"
 	"github.com/Di-nis/shortener-url/internal/usecase"
 
@@ -32,10 +37,12 @@ func initRepoPostgres(cfg *config.Config) (*repository.RepoPostgres, error) {
 	if err != nil {
 		return nil, err
 	}
+	repo.PerUserOriginals = cfg.PerUserOriginals
 
 	// выполнение миграций
 	err = repo.Migrations()
 	if err != nil {
+		_ = repo.Close()
 		return nil, err
 	}
 
@@ -54,9 +61,21 @@ func InitRepoFile(fileStoragePath string) (*repository.RepoFileMemory, error) {
 		return nil, fmt.Errorf("ошибка инициализации producer: %w", err)
 	}
 
+	sequence, err := storage.NewSequenceFile(fileStoragePath + ".seq")
+	if err != nil {
+		return nil, fmt.Errorf("ошибка инициализации счетчика коротких URL: %w", err)
+	}
+
+	blocked, err := storage.NewBlockedUsersFile(fileStoragePath + ".blocked")
+	if err != nil {
+		return nil, fmt.Errorf("ошибка загрузки списка заблокированных пользователей: %w", err)
+	}
+
 	storage := &repository.Storage{
 		Consumer: consumer,
 		Producer: producer,
+		Sequence: sequence,
+		Blocked:  blocked,
 	}
 
 	repo := repository.NewRepoFileMemory(storage)
@@ -81,6 +100,7 @@ func InitRepoMemory(cfg *config.Config) (*repository.RepoFileMemory, error) {
 
 	var err error
 	repo := repository.NewRepoFileMemory(storage)
+	repo.PerUserOriginals = cfg.PerUserOriginals
 	repo.URLs, err = storage.Consumer.Load()
 	if err != nil {
 		return nil, fmt.Errorf("ошибка загрузки данных из файла-хранилища: %w", err)
@@ -89,13 +109,56 @@ func InitRepoMemory(cfg *config.Config) (*repository.RepoFileMemory, error) {
 	return repo, nil
 }
 
+// initService - инициализация стратегии создания коротких URL.
+// Стратегии на основе счетчика получают номера из хранилища данных.
+func initService(cfg *config.Config, repo usecase.URLRepository) (service.Service, error) {
+	return service.New(service.Options{
+		Strategy: cfg.ShortStrategy,
+		Length:   cfg.ShortLength,
+		Alphabet: cfg.ShortAlphabet,
+		Salt:     cfg.ShortSalt,
+	}, repo)
+}
+
+// initUseCase - инициализация бизнес-логики, общей для HTTP- и gRPC-серверов.
+func initUseCase(cfg *config.Config, repo usecase.URLRepository, svc service.Service) *usecase.URLUseCase {
+	urlUseCase := usecase.NewURLUseCase(repo, svc)
+	urlUseCase.PerUserOriginals = cfg.PerUserOriginals
+	urlUseCase.RedirectPolicy = redirect.Policy{
+		PassQuery:     cfg.RedirectPassQuery,
+		PassPath:      cfg.RedirectPassPath,
+		QueryConflict: cfg.RedirectQueryConflict,
+	}
+	if cfg.FetchTitles {
+		urlUseCase.TitleFetcher = pagetitle.NewFetcher(netguard.NewPublicClient(), cfg.TitleFetchTimeout, cfg.TitleFetchMaxBytes, cfg.TitleFetchConcurrency)
+	}
+	if cfg.EnableWebhooks {
+		urlUseCase.Events = usecase.NewWebhookUseCase(repo)
+	}
+	return urlUseCase
+}
+
+// checkWebhookStorage - проверка, что хранилище сохраняет подписки и очередь доставок.
+// Файл-хранилище содержит только записи URL, поэтому события терялись бы при перезапуске.
+func checkWebhookStorage(cfg *config.Config) error {
+	if cfg.EnableWebhooks && cfg.DataBaseDSN == "" && cfg.FileStoragePath != "" {
+		return fmt.Errorf("path: internal/app/dependencies.go, func checkWebhookStorage(): %w", constants.ErrorWebhooksNotPersistent)
+	}
+	return nil
+}
+
 // initStorage - инициализация хранилища данных.
 func initStorage(cfg *config.Config) (usecase.URLRepository, error) {
 	if cfg.DataBaseDSN != "" {
 		return initRepoPostgres(cfg)
 	}
 	if cfg.FileStoragePath != "" {
-		return InitRepoFile(cfg.FileStoragePath)
+		repo, err := InitRepoFile(cfg.FileStoragePath)
+		if err != nil {
+			return nil, err
+		}
+		repo.PerUserOriginals = cfg.PerUserOriginals
+		return repo, nil
 	}
 	return InitRepoMemory(cfg)
 }
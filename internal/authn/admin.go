@@ -0,0 +1,29 @@
+package authn
+
+import (
+	"crypto/subtle"
+	"net/http"
+)
+
+// AdminTokenHeader - заголовок с токеном администратора.
+const AdminTokenHeader = "X-Admin-Token"
+
+// WithAdminToken - middleware для проверки токена администратора.
+// Если токен не задан в конфигурации, доступ запрещен.
+func WithAdminToken(token string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if token == "" {
+				w.WriteHeader(http.StatusForbidden)
+				return
+			}
+
+			got := r.Header.Get(AdminTokenHeader)
+			if subtle.ConstantTimeCompare([]byte(got), []byte(token)) != 1 {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
@@ -0,0 +1,54 @@
+package authn
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWithAdminToken(t *testing.T) {
+	tests := []struct {
+		name       string
+		token      string
+		header     string
+		statusCode int
+	}{
+		{
+			name:       "токен совпадает",
+			token:      "admin-secret",
+			header:     "admin-secret",
+			statusCode: http.StatusOK,
+		},
+		{
+			name:       "токен не совпадает",
+			token:      "admin-secret",
+			header:     "admin",
+			statusCode: http.StatusUnauthorized,
+		},
+		{
+			name:       "токен не передан",
+			token:      "admin-secret",
+			statusCode: http.StatusUnauthorized,
+		},
+		{
+			name:       "токен не задан в конфигурации",
+			statusCode: http.StatusForbidden,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := WithAdminToken(tt.token)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+
+			req := httptest.NewRequest(http.MethodGet, "/api/admin/urls", nil)
+			if tt.header != "" {
+				req.Header.Set(AdminTokenHeader, tt.header)
+			}
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+
+			assert.Equal(t, tt.statusCode, rec.Code)
+		})
+	}
+}
@@ -13,6 +13,39 @@ import (
 
 const headerAuthorization = "authorization"
 
+// authenticate - аутентификация вызова по токену из метаданных запроса.
+// Токен возвращается клиенту в заголовках ответа через sendHeader.
+func authenticate(ctx context.Context, JWTSecret string, sendHeader func(metadata.MD) error) (context.Context, error) {
+	var token string
+
+	mdReq, ok := metadata.FromIncomingContext(ctx)
+	if ok {
+		values := mdReq.Get(headerAuthorization)
+		if len(values) > 0 {
+			token = values[0]
+		}
+	}
+
+	session, err := Authenticate(token, JWTSecret)
+	if err != nil {
+		if IsAuthError(err) {
+			return nil, status.Error(codes.Unauthenticated, err.Error())
+		}
+		return nil, status.Error(codes.Internal, "internal error")
+	}
+
+	mdOut := metadata.Pairs(
+		headerAuthorization, session.Token,
+	)
+
+	// отправка headers клиенту
+	if err = sendHeader(mdOut); err != nil {
+		return nil, status.Error(codes.Internal, "internal error")
+	}
+
+	return context.WithValue(ctx, constants.UserIDKey, session.UserID), nil
+}
+
 // Interceptor - аутентификация пользователя.
 func Interceptor(JWTSecret string) grpc.UnaryServerInterceptor {
 	return func(
@@ -21,49 +54,39 @@ func Interceptor(JWTSecret string) grpc.UnaryServerInterceptor {
 		info *grpc.UnaryServerInfo,
 		handler grpc.UnaryHandler,
 	) (interface{}, error) {
-		var (
-			token, userID, sessionID string
-			err                      error
-		)
-
-		mdReq, ok := metadata.FromIncomingContext(ctx)
-		if ok {
-			values := mdReq.Get(headerAuthorization)
-			if len(values) > 0 {
-				token = values[0]
-			}
+		ctx, err := authenticate(ctx, JWTSecret, func(md metadata.MD) error {
+			return grpc.SendHeader(ctx, md)
+		})
+		if err != nil {
+			return nil, err
 		}
+		return handler(ctx, req)
+	}
+}
 
-		if token == "" {
-			userID = GenerateUserID()
-			sessionID = GenerateSessionID()
-			token, err = BuildJWTString(JWTSecret, userID, sessionID)
-			if err != nil {
-				return nil, status.Error(codes.Internal, "internal error")
-			}
-		} else {
-			claims, isTokenValid := GetClaims(token, JWTSecret)
-			if !isTokenValid {
-				return nil, status.Error(codes.Unauthenticated, "token not valid")
-			}
-			userID = claims.UserID
-			sessionID = claims.SID
-			if sessionID == "" {
-				return nil, status.Error(codes.Unauthenticated, "sessionID not valid")
-			}
-		}
+// authStream - поток с контекстом, содержащим идентификатор пользователя.
+type authStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
 
-		mdOut := metadata.Pairs(
-			headerAuthorization, token,
-		)
+// Context - контекст аутентифицированного потока.
+func (s *authStream) Context() context.Context {
+	return s.ctx
+}
 
-		// отправка headers клиенту
-		err = grpc.SendHeader(ctx, mdOut)
+// StreamInterceptor - аутентификация пользователя для потоковых вызовов, аналогичная Interceptor.
+func StreamInterceptor(JWTSecret string) grpc.StreamServerInterceptor {
+	return func(
+		srv interface{},
+		ss grpc.ServerStream,
+		info *grpc.StreamServerInfo,
+		handler grpc.StreamHandler,
+	) error {
+		ctx, err := authenticate(ss.Context(), JWTSecret, ss.SendHeader)
 		if err != nil {
-			return nil, status.Error(codes.Internal, "internal error")
+			return err
 		}
-
-		ctx = context.WithValue(ctx, constants.UserIDKey, userID)
-		return handler(ctx, req)
+		return handler(srv, &authStream{ServerStream: ss, ctx: ctx})
 	}
 }
@@ -0,0 +1,92 @@
+package authn
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Di-nis/shortener-url/internal/constants"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// serverStream - поток вызова с метаданными клиента, сохраняющий заголовки ответа.
+type serverStream struct {
+	grpc.ServerStream
+	ctx    context.Context
+	header metadata.MD
+}
+
+func (s *serverStream) Context() context.Context {
+	return s.ctx
+}
+
+func (s *serverStream) SendHeader(md metadata.MD) error {
+	s.header = md
+	return nil
+}
+
+func TestStreamInterceptor(t *testing.T) {
+	const secretKey = "secret"
+
+	validToken, err := BuildJWTString(secretKey, "01KA3YRQCWTNAJEGR5Z30PH6VT", "session")
+	require.NoError(t, err)
+
+	tests := []struct {
+		name       string
+		token      string
+		wantUserID string
+		wantCode   codes.Code
+	}{
+		{
+			name:       "действительный токен",
+			token:      validToken,
+			wantUserID: "01KA3YRQCWTNAJEGR5Z30PH6VT",
+			wantCode:   codes.OK,
+		},
+		{
+			name:     "недействительный токен",
+			token:    "fsdffvdfrgfxvbxdf",
+			wantCode: codes.Unauthenticated,
+		},
+		{
+			name:     "выпуск токена для нового пользователя",
+			token:    "",
+			wantCode: codes.OK,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			if tt.token != "" {
+				ctx = metadata.NewIncomingContext(ctx, metadata.Pairs(headerAuthorization, tt.token))
+			}
+			stream := &serverStream{ctx: ctx}
+
+			var gotUserID string
+			handler := func(srv interface{}, ss grpc.ServerStream) error {
+				gotUserID = ss.Context().Value(constants.UserIDKey).(string)
+				return nil
+			}
+
+			err := StreamInterceptor(secretKey)(nil, stream, &grpc.StreamServerInfo{}, handler)
+			assert.Equal(t, tt.wantCode, status.Code(err))
+			if tt.wantCode != codes.OK {
+				assert.Empty(t, gotUserID)
+				return
+			}
+
+			tokens := stream.header.Get(headerAuthorization)
+			require.Len(t, tokens, 1)
+			claims, ok := GetClaims(tokens[0], secretKey)
+			require.True(t, ok)
+			assert.Equal(t, claims.UserID, gotUserID)
+			if tt.wantUserID != "" {
+				assert.Equal(t, tt.wantUserID, gotUserID)
+			}
+		})
+	}
+}
@@ -16,27 +16,28 @@ import (
 // Middleware - аутентификация пользователя.
 func Middleware(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
-		var userID, tokenString, sessionID string
+		var tokenString string
 
 		JWTSecret := os.Getenv("JWT_SECRET")
 		cookie, err := req.Cookie("auth_token")
-		if err != nil {
-			tokenString = ""
-		} else {
+		if err == nil {
 			tokenString = cookie.Value
 		}
 
-		if tokenString == "" {
-			userID = GenerateUserID()
-			sessionID = GenerateSessionID()
-			newToken, err := BuildJWTString(JWTSecret, userID, sessionID)
-			if err != nil {
-				http.Error(res, "Ошибка создания токена", http.StatusInternalServerError)
+		session, err := Authenticate(tokenString, JWTSecret)
+		if err != nil {
+			if IsAuthError(err) {
+				http.Error(res, err.Error(), http.StatusUnauthorized)
 				return
 			}
+			http.Error(res, "Ошибка создания токена", http.StatusInternalServerError)
+			return
+		}
+
+		if session.Issued {
 			newCookie := &http.Cookie{
 				Name:     "auth_token",
-				Value:    newToken,
+				Value:    session.Token,
 				Expires:  time.Now().Add(24 * time.Hour),
 				Path:     "/",
 				Domain:   "localhost",
@@ -45,25 +46,12 @@ func Middleware(next http.Handler) http.Handler {
 				Secure:   true,
 			}
 			http.SetCookie(res, newCookie)
-			res.Header().Set("Authorization", newToken)
 		} else {
-			claims, isTokenValid := GetClaims(tokenString, JWTSecret)
-			if !isTokenValid {
-				http.Error(res, "token not valid", http.StatusUnauthorized)
-				return
-			}
-			userID = claims.UserID
-			sessionID = claims.SID
-			if sessionID == "" {
-				http.Error(res, "session ID not valid", http.StatusUnauthorized)
-				return
-			}
-
 			http.SetCookie(res, cookie)
-			res.Header().Set("Authorization", tokenString)
 		}
+		res.Header().Set("Authorization", session.Token)
 
-		ctx := context.WithValue(req.Context(), constants.UserIDKey, userID)
+		ctx := context.WithValue(req.Context(), constants.UserIDKey, session.UserID)
 		next.ServeHTTP(res, req.WithContext(ctx))
 	})
 }
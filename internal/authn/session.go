@@ -0,0 +1,54 @@
+package authn
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/Di-nis/shortener-url/internal/constants"
+)
+
+// Session - аутентифицированный пользователь и токен, который возвращается клиенту.
+type Session struct {
+	UserID    string
+	SessionID string
+	Token     string
+	// Issued - токен выпущен для нового пользователя
+	Issued bool
+}
+
+// Authenticate - проверка токена клиента или выпуск токена для нового пользователя, если токен не передан.
+// Общая логика для HTTP middleware и gRPC-перехватчиков.
+func Authenticate(token, secretKey string) (Session, error) {
+	if token == "" {
+		session := Session{
+			UserID:    GenerateUserID(),
+			SessionID: GenerateSessionID(),
+			Issued:    true,
+		}
+
+		var err error
+		session.Token, err = BuildJWTString(secretKey, session.UserID, session.SessionID)
+		if err != nil {
+			return Session{}, fmt.Errorf("path: internal/authn/session.go, func Authenticate(), failed to build token: %w", err)
+		}
+		return session, nil
+	}
+
+	claims, isTokenValid := GetClaims(token, secretKey)
+	if !isTokenValid {
+		return Session{}, constants.ErrorTokenNotValid
+	}
+	if claims.SID == "" {
+		return Session{}, constants.ErrorSessionNotValid
+	}
+	return Session{
+		UserID:    claims.UserID,
+		SessionID: claims.SID,
+		Token:     token,
+	}, nil
+}
+
+// IsAuthError - ошибка проверки токена клиента, в отличие от внутренней ошибки выпуска токена.
+func IsAuthError(err error) bool {
+	return errors.Is(err, constants.ErrorTokenNotValid) || errors.Is(err, constants.ErrorSessionNotValid)
+}
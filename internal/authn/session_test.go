@@ -0,0 +1,68 @@
+package authn
+
+import (
+	"testing"
+
+	"github.com/Di-nis/shortener-url/internal/constants"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAuthenticate(t *testing.T) {
+	const secretKey = "secret"
+
+	validToken, err := BuildJWTString(secretKey, "01KA3YRQCWTNAJEGR5Z30PH6VT", "session")
+	require.NoError(t, err)
+	noSessionToken, err := BuildJWTString(secretKey, "01KA3YRQCWTNAJEGR5Z30PH6VT", "")
+	require.NoError(t, err)
+
+	tests := []struct {
+		name       string
+		token      string
+		wantUserID string
+		wantIssued bool
+		wantErr    error
+	}{
+		{
+			name:       "выпуск токена для нового пользователя",
+			token:      "",
+			wantIssued: true,
+		},
+		{
+			name:       "действительный токен",
+			token:      validToken,
+			wantUserID: "01KA3YRQCWTNAJEGR5Z30PH6VT",
+		},
+		{
+			name:    "недействительный токен",
+			token:   "fsdffvdfrgfxvbxdf",
+			wantErr: constants.ErrorTokenNotValid,
+		},
+		{
+			name:    "токен без идентификатора сессии",
+			token:   noSessionToken,
+			wantErr: constants.ErrorSessionNotValid,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			session, err := Authenticate(tt.token, secretKey)
+			assert.ErrorIs(t, err, tt.wantErr)
+			if tt.wantErr != nil {
+				assert.True(t, IsAuthError(err))
+				return
+			}
+
+			assert.Equal(t, tt.wantIssued, session.Issued)
+			if tt.wantIssued {
+				claims, ok := GetClaims(session.Token, secretKey)
+				require.True(t, ok)
+				assert.Equal(t, session.UserID, claims.UserID)
+				assert.Equal(t, session.SessionID, claims.SID)
+			} else {
+				assert.Equal(t, tt.wantUserID, session.UserID)
+				assert.Equal(t, tt.token, session.Token)
+			}
+		})
+	}
+}
@@ -6,51 +6,47 @@ import (
 	"strings"
 )
 
+// Contains - проверка вхождения IP-адреса в доверенную подсеть.
+// Пустая или некорректная подсеть не содержит ни одного адреса.
+func Contains(trustedSubnet string, ip net.IP) bool {
+	if trustedSubnet == "" || ip == nil {
+		return false
+	}
+
+	_, network, err := net.ParseCIDR(trustedSubnet)
+	if err != nil {
+		return false
+	}
+	return network.Contains(ip)
+}
+
+// ContainsAddr - проверка вхождения адреса вида host:port в доверенную подсеть.
+func ContainsAddr(trustedSubnet string, addr string) bool {
+	ipStr, _, err := net.SplitHostPort(addr)
+	if err != nil {
+		return false
+	}
+	return Contains(trustedSubnet, net.ParseIP(ipStr))
+}
+
 // WithCheckCIDR - middleware для проверки CIDR.
 func WithCheckCIDR(trustedSubnet string, useHeader bool) func(http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-			if trustedSubnet == "" {
-				w.WriteHeader(http.StatusForbidden)
-				return
-			}
-
-			_, network, err := net.ParseCIDR(trustedSubnet)
-			if err != nil {
-				w.WriteHeader(http.StatusForbidden)
-				return
-			}
-
-			var ip net.IP
+			var trusted bool
 			if !useHeader {
-				addr := r.RemoteAddr
-				ipStr, _, err := net.SplitHostPort(addr)
-				if err != nil {
-					w.WriteHeader(http.StatusForbidden)
-					return
-				}
-
-				ip = net.ParseIP(ipStr)
-				if ip == nil {
-					w.WriteHeader(http.StatusForbidden)
-					return
-				}
+				trusted = ContainsAddr(trustedSubnet, r.RemoteAddr)
 			} else {
-				ipStr := r.Header.Get("X-Real-IP")
-				ip = net.ParseIP(ipStr)
+				ip := net.ParseIP(r.Header.Get("X-Real-IP"))
 				if ip == nil {
 					ips := r.Header.Get("X-Forwarded-For")
 					ipStrs := strings.Split(ips, ",")
-					ipStr = ipStrs[0]
-					ip = net.ParseIP(ipStr)
-				}
-
-				if ip == nil {
-					w.WriteHeader(http.StatusForbidden)
-					return
+					ip = net.ParseIP(ipStrs[0])
 				}
+				trusted = Contains(trustedSubnet, ip)
 			}
-			if network.Contains(ip) {
+
+			if trusted {
 				next.ServeHTTP(w, r)
 			} else {
 				w.WriteHeader(http.StatusForbidden)
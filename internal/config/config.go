@@ -8,30 +8,146 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"strings"
+	"time"
 
 	"github.com/caarlos0/env/v6"
 )
 
 // Config - структура конфигурации приложения.
 type Config struct {
-	ServerAddress   string `env:"SERVER_ADDRESS"`
-	BaseURL         string `env:"BASE_URL"`
-	LogLevel        string `env:"LOG_LEVEL"`
-	FileStoragePath string `env:"FILE_STORAGE_PATH"`
-	DataBaseDSN     string `env:"DATABASE_DSN"`
-	JWTSecret       string `env:"JWT_SECRET"`
-	AuditFile       string `env:"AUDIT_FILE"`
-	AuditURL        string `env:"AUDIT_URL"`
+	ServerAddress string `env:"SERVER_ADDRESS"`
+	BaseURL       string `env:"BASE_URL"`
+	// Domains - базовые URL дополнительных доменов, с которых обслуживаются короткие ссылки.
+	Domains         []string `env:"DOMAINS" envSeparator:","`
+	LogLevel        string   `env:"LOG_LEVEL"`
+	FileStoragePath string   `env:"FILE_STORAGE_PATH"`
+	DataBaseDSN     string   `env:"DATABASE_DSN"`
+	JWTSecret       string   `env:"JWT_SECRET"`
+	AuditFile       string   `env:"AUDIT_FILE"`
+	AuditURL        string   `env:"AUDIT_URL"`
 	UseMockAuth     bool
 	EnableHTTPS     bool   `env:"ENABLE_HTTPS"`
 	CertFilePath    string `env:"CERT_FILE_PATH"`
 	KeyFilePath     string `env:"KEY_FILE_PATH"`
-	Config          string `env:"CONFIG"`
-	TrustedSubnet   string `env:"TRUSTED_SUBNET"`
-	UseHeader       bool   `env:"USE_HEADER"`
-	EnableGRPC      bool   `env:"ENABLE_GRPC"`
+	// ClientCAFilePath - корневые сертификаты клиентов в формате PEM.
+	// Если задан при EnableHTTPS, gRPC-сервер требует и проверяет сертификат клиента (mTLS).
+	ClientCAFilePath string `env:"CLIENT_CA_FILE_PATH"`
+	Config           string `env:"CONFIG"`
+	TrustedSubnet    string `env:"TRUSTED_SUBNET"`
+	UseHeader        bool   `env:"USE_HEADER"`
+	EnableGRPC       bool   `env:"ENABLE_GRPC"`
+	// GRPCAddress - адрес gRPC-сервера, запускаемого вместе с HTTP-сервером.
+	// Если не задан, при EnableGRPC на ServerAddress запускается только gRPC-сервер.
+	GRPCAddress string `env:"GRPC_ADDRESS"`
+	// GRPCReflection - регистрация сервиса reflection для отладки gRPC-сервера (grpcurl).
+	GRPCReflection bool `env:"GRPC_REFLECTION"`
+	// PerUserOriginals - собственный короткий URL у каждого пользователя для одного оригинального URL.
+	PerUserOriginals bool `env:"PER_USER_ORIGINALS"`
+
+	CORSAllowedOrigins   []string `env:"CORS_ALLOWED_ORIGINS" envSeparator:","`
+	CORSAllowedMethods   []string `env:"CORS_ALLOWED_METHODS" envSeparator:","`
+	CORSAllowedHeaders   []string `env:"CORS_ALLOWED_HEADERS" envSeparator:","`
+	CORSAllowCredentials bool     `env:"CORS_ALLOW_CREDENTIALS"`
+	CORSMaxAge           int      `env:"CORS_MAX_AGE"`
+	HSTSMaxAge           int      `env:"HSTS_MAX_AGE"`
+	ReferrerPolicy       string   `env:"REFERRER_POLICY"`
+
+	MaxBodySize       int64         `env:"MAX_BODY_SIZE"`
+	MaxBatchBodySize  int64         `env:"MAX_BATCH_BODY_SIZE"`
+	HandlerTimeout    time.Duration `env:"HANDLER_TIMEOUT"`
+	BatchTimeout      time.Duration `env:"BATCH_TIMEOUT"`
+	DeleteTimeout     time.Duration `env:"DELETE_TIMEOUT"`
+	StatsTimeout      time.Duration `env:"STATS_TIMEOUT"`
+	ReadTimeout       time.Duration `env:"READ_TIMEOUT"`
+	ReadHeaderTimeout time.Duration `env:"READ_HEADER_TIMEOUT"`
+	WriteTimeout      time.Duration `env:"WRITE_TIMEOUT"`
+	IdleTimeout       time.Duration `env:"IDLE_TIMEOUT"`
+
+	FetchTitles           bool          `env:"FETCH_TITLES"`
+	TitleFetchTimeout     time.Duration `env:"TITLE_FETCH_TIMEOUT"`
+	TitleFetchMaxBytes    int64         `env:"TITLE_FETCH_MAX_BYTES"`
+	TitleFetchConcurrency int           `env:"TITLE_FETCH_CONCURRENCY"`
+
+	RedirectPassQuery     bool   `env:"REDIRECT_PASS_QUERY"`
+	RedirectPassPath      bool   `env:"REDIRECT_PASS_PATH"`
+	RedirectQueryConflict string `env:"REDIRECT_QUERY_CONFLICT"`
+
+	// HoldingPageURL - страница, на которую перенаправляются переходы по еще не активным ссылкам.
+	// Если не задана, возвращается 404.
+	HoldingPageURL string `env:"HOLDING_PAGE_URL"`
+
+	HealthCheckEnabled      bool          `env:"HEALTH_CHECK_ENABLED"`
+	HealthCheckInterval     time.Duration `env:"HEALTH_CHECK_INTERVAL"`
+	HealthCheckTimeout      time.Duration `env:"HEALTH_CHECK_TIMEOUT"`
+	HealthCheckConcurrency  int           `env:"HEALTH_CHECK_CONCURRENCY"`
+	HealthCheckHostInterval time.Duration `env:"HEALTH_CHECK_HOST_INTERVAL"`
+	HealthCheckMarkBroken   bool          `env:"HEALTH_CHECK_MARK_BROKEN"`
+
+	// AdminToken - токен доступа к административному API, если не задан - доступ запрещен.
+	AdminToken string `env:"ADMIN_TOKEN"`
+
+	// EnableWebhooks - доставка событий по подпискам. При файле-хранилище требует DataBaseDSN:
+	// подписки и очередь доставок в файле не сохраняются.
+	EnableWebhooks      bool          `env:"ENABLE_WEBHOOKS"`
+	WebhookPollInterval time.Duration `env:"WEBHOOK_POLL_INTERVAL"`
+	WebhookTimeout      time.Duration `env:"WEBHOOK_TIMEOUT"`
+	WebhookMaxAttempts  int           `env:"WEBHOOK_MAX_ATTEMPTS"`
+	WebhookBackoffBase  time.Duration `env:"WEBHOOK_BACKOFF_BASE"`
+	WebhookBackoffMax   time.Duration `env:"WEBHOOK_BACKOFF_MAX"`
+
+	// ShortStrategy - стратегия создания коротких URL: hash, random, counter или hashids.
+	ShortStrategy string `env:"SHORT_STRATEGY"`
+	ShortLength   int    `env:"SHORT_LENGTH"`
+	ShortAlphabet string `env:"SHORT_ALPHABET"`
+	// ShortSalt - соль стратегии hashids.
+	ShortSalt string `env:"SHORT_SALT"`
 }
 
+// Значения по умолчанию.
+var (
+	defaultCORSAllowedMethods = []string{"GET", "POST", "DELETE", "OPTIONS"}
+	defaultCORSAllowedHeaders = []string{"Content-Type", "Content-Encoding", "Accept-Encoding", "Authorization"}
+)
+
+const (
+	defaultCORSMaxAge     = 600
+	defaultHSTSMaxAge     = 31536000
+	defaultReferrerPolicy = "no-referrer"
+
+	defaultMaxBodySize       = 1 << 20
+	defaultMaxBatchBodySize  = 10 << 20
+	defaultHandlerTimeout    = 3 * time.Second
+	defaultBatchTimeout      = 10 * time.Second
+	defaultDeleteTimeout     = 10 * time.Second
+	defaultStatsTimeout      = 5 * time.Second
+	defaultReadTimeout       = 10 * time.Second
+	defaultReadHeaderTimeout = 5 * time.Second
+	defaultWriteTimeout      = 30 * time.Second
+	defaultIdleTimeout       = 120 * time.Second
+
+	defaultTitleFetchTimeout     = 5 * time.Second
+	defaultTitleFetchMaxBytes    = 256 << 10
+	defaultTitleFetchConcurrency = 4
+
+	defaultRedirectQueryConflict = "keep"
+
+	defaultHealthCheckInterval     = time.Hour
+	defaultHealthCheckTimeout      = 10 * time.Second
+	defaultHealthCheckConcurrency  = 4
+	defaultHealthCheckHostInterval = time.Second
+
+	defaultWebhookPollInterval = 5 * time.Second
+	defaultWebhookTimeout      = 10 * time.Second
+	defaultWebhookMaxAttempts  = 8
+	defaultWebhookBackoffBase  = 10 * time.Second
+	defaultWebhookBackoffMax   = time.Hour
+
+	defaultShortStrategy = "hash"
+	defaultShortLength   = 8
+	defaultShortAlphabet = "0123456789abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ"
+)
+
 // NewConfig - функция для создания конфигурации.
 func NewConfig() *Config {
 	return &Config{}
@@ -47,6 +163,129 @@ func (c *Config) Load() {
 
 	// третий приоритет - из файла
 	c.loanFromFile()
+
+	// значения по умолчанию для незаданных параметров
+	c.setDefaults()
+}
+
+// setDefaults - установка значений по умолчанию.
+func (c *Config) setDefaults() {
+	if len(c.CORSAllowedMethods) == 0 {
+		c.CORSAllowedMethods = defaultCORSAllowedMethods
+	}
+	if len(c.CORSAllowedHeaders) == 0 {
+		c.CORSAllowedHeaders = defaultCORSAllowedHeaders
+	}
+	if c.CORSMaxAge == 0 {
+		c.CORSMaxAge = defaultCORSMaxAge
+	}
+	if c.HSTSMaxAge == 0 {
+		c.HSTSMaxAge = defaultHSTSMaxAge
+	}
+	if c.ReferrerPolicy == "" {
+		c.ReferrerPolicy = defaultReferrerPolicy
+	}
+	if c.MaxBodySize == 0 {
+		c.MaxBodySize = defaultMaxBodySize
+	}
+	if c.MaxBatchBodySize == 0 {
+		c.MaxBatchBodySize = defaultMaxBatchBodySize
+	}
+	if c.HandlerTimeout == 0 {
+		c.HandlerTimeout = defaultHandlerTimeout
+	}
+	if c.BatchTimeout == 0 {
+		c.BatchTimeout = defaultBatchTimeout
+	}
+	if c.DeleteTimeout == 0 {
+		c.DeleteTimeout = defaultDeleteTimeout
+	}
+	if c.StatsTimeout == 0 {
+		c.StatsTimeout = defaultStatsTimeout
+	}
+	if c.ReadTimeout == 0 {
+		c.ReadTimeout = defaultReadTimeout
+	}
+	if c.ReadHeaderTimeout == 0 {
+		c.ReadHeaderTimeout = defaultReadHeaderTimeout
+	}
+	if c.WriteTimeout == 0 {
+		c.WriteTimeout = defaultWriteTimeout
+	}
+	if c.IdleTimeout == 0 {
+		c.IdleTimeout = defaultIdleTimeout
+	}
+	if c.TitleFetchTimeout == 0 {
+		c.TitleFetchTimeout = defaultTitleFetchTimeout
+	}
+	if c.TitleFetchMaxBytes == 0 {
+		c.TitleFetchMaxBytes = defaultTitleFetchMaxBytes
+	}
+	if c.TitleFetchConcurrency == 0 {
+		c.TitleFetchConcurrency = defaultTitleFetchConcurrency
+	}
+	if c.RedirectQueryConflict == "" {
+		c.RedirectQueryConflict = defaultRedirectQueryConflict
+	}
+	if c.HealthCheckInterval == 0 {
+		c.HealthCheckInterval = defaultHealthCheckInterval
+	}
+	if c.HealthCheckTimeout == 0 {
+		c.HealthCheckTimeout = defaultHealthCheckTimeout
+	}
+	if c.HealthCheckConcurrency == 0 {
+		c.HealthCheckConcurrency = defaultHealthCheckConcurrency
+	}
+	if c.HealthCheckHostInterval == 0 {
+		c.HealthCheckHostInterval = defaultHealthCheckHostInterval
+	}
+	if c.WebhookPollInterval == 0 {
+		c.WebhookPollInterval = defaultWebhookPollInterval
+	}
+	if c.WebhookTimeout == 0 {
+		c.WebhookTimeout = defaultWebhookTimeout
+	}
+	if c.WebhookMaxAttempts == 0 {
+		c.WebhookMaxAttempts = defaultWebhookMaxAttempts
+	}
+	if c.WebhookBackoffBase == 0 {
+		c.WebhookBackoffBase = defaultWebhookBackoffBase
+	}
+	if c.WebhookBackoffMax == 0 {
+		c.WebhookBackoffMax = defaultWebhookBackoffMax
+	}
+	if c.ShortStrategy == "" {
+		c.ShortStrategy = defaultShortStrategy
+	}
+	if c.ShortLength == 0 {
+		c.ShortLength = defaultShortLength
+	}
+	if c.ShortAlphabet == "" {
+		c.ShortAlphabet = defaultShortAlphabet
+	}
+}
+
+// parseDuration - разбор длительности, пустая строка соответствует нулевому значению.
+func parseDuration(value string) (time.Duration, error) {
+	if value == "" {
+		return 0, nil
+	}
+	return time.ParseDuration(value)
+}
+
+// splitList - разбиение строки со значениями, перечисленными через запятую.
+func splitList(value string) []string {
+	if value == "" {
+		return nil
+	}
+
+	var list []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			list = append(list, item)
+		}
+	}
+	return list
 }
 
 // loanFromEnv - загрузка конфигурации из переменных окружения.
@@ -60,12 +299,47 @@ func (c *Config) loanFromEnv() error {
 // loanFromFlags - загрузка конфигурации из аргументов командной строки.
 func (c *Config) loanFromFlags() {
 	var (
-		serverAddress, baseURL, fileStoragePath                 string
+		serverAddress, baseURL, fileStoragePath, domains        string
 		dataBaseDSN, auditFile, auditURL, config, trustedSubnet string
-		enableHTTPS, enableGRPC, useHeader                      bool
+		enableHTTPS, enableGRPC, useHeader, perUserOriginals    bool
+		grpcReflection                                          bool
+		grpcAddress                                             string
+
+		corsOrigins, corsMethods, corsHeaders, referrerPolicy string
+		corsCredentials                                       bool
+		corsMaxAge, hstsMaxAge                                int
+
+		maxBodySize, maxBatchBodySize                int64
+		handlerTimeout, batchTimeout, deleteTimeout  time.Duration
+		statsTimeout, readTimeout, readHeaderTimeout time.Duration
+		writeTimeout, idleTimeout                    time.Duration
+
+		fetchTitles           bool
+		titleFetchTimeout     time.Duration
+		titleFetchMaxBytes    int64
+		titleFetchConcurrency int
+
+		redirectPassQuery, redirectPassPath bool
+		redirectQueryConflict               string
+
+		holdingPageURL string
+
+		healthCheckEnabled, healthCheckMarkBroken                        bool
+		healthCheckInterval, healthCheckTimeout, healthCheckHostInterval time.Duration
+		healthCheckConcurrency                                           int
+
+		adminToken string
+
+		enableWebhooks                                                             bool
+		webhookPollInterval, webhookTimeout, webhookBackoffBase, webhookBackoffMax time.Duration
+		webhookMaxAttempts                                                         int
+
+		shortStrategy, shortAlphabet, shortSalt string
+		shortLength                             int
 	)
 	flag.StringVar(&serverAddress, "a", "", "URL")
 	flag.StringVar(&baseURL, "b", "", "base URL")
+	flag.StringVar(&domains, "domains", "", "comma-separated list of base URLs of additional domains")
 	flag.StringVar(&fileStoragePath, "f", "", "path to storage file")
 	flag.StringVar(&dataBaseDSN, "d", "", "database dource name")
 	flag.StringVar(&auditFile, "audit-file", "", "path to audit file")
@@ -75,6 +349,51 @@ func (c *Config) loanFromFlags() {
 	flag.BoolVar(&enableHTTPS, "s", false, "use HTTPS web-server")
 	flag.BoolVar(&useHeader, "use-header", false, "using a header when parsing an IP address")
 	flag.BoolVar(&enableGRPC, "grpc", false, "use gRPC server")
+	flag.BoolVar(&grpcReflection, "grpc-reflection", false, "register gRPC server reflection service")
+	flag.StringVar(&grpcAddress, "grpc-address", "", "gRPC server address to run alongside HTTP server")
+	flag.BoolVar(&perUserOriginals, "per-user-originals", false, "give each user own short URL for the same original URL")
+	flag.StringVar(&corsOrigins, "cors-origins", "", "comma-separated list of allowed CORS origins")
+	flag.StringVar(&corsMethods, "cors-methods", "", "comma-separated list of allowed CORS methods")
+	flag.StringVar(&corsHeaders, "cors-headers", "", "comma-separated list of allowed CORS headers")
+	flag.BoolVar(&corsCredentials, "cors-credentials", false, "allow CORS requests with credentials")
+	flag.IntVar(&corsMaxAge, "cors-max-age", 0, "CORS preflight cache duration in seconds")
+	flag.IntVar(&hstsMaxAge, "hsts-max-age", 0, "HSTS max-age in seconds")
+	flag.StringVar(&referrerPolicy, "referrer-policy", "", "Referrer-Policy header for redirects")
+	flag.Int64Var(&maxBodySize, "max-body-size", 0, "maximum request body size in bytes")
+	flag.Int64Var(&maxBatchBodySize, "max-batch-body-size", 0, "maximum batch request body size in bytes")
+	flag.DurationVar(&handlerTimeout, "handler-timeout", 0, "default handler timeout")
+	flag.DurationVar(&batchTimeout, "batch-timeout", 0, "batch shortening handler timeout")
+	flag.DurationVar(&deleteTimeout, "delete-timeout", 0, "URL deletion handler timeout")
+	flag.DurationVar(&statsTimeout, "stats-timeout", 0, "internal stats handler timeout")
+	flag.DurationVar(&readTimeout, "read-timeout", 0, "HTTP server read timeout")
+	flag.DurationVar(&readHeaderTimeout, "read-header-timeout", 0, "HTTP server read header timeout")
+	flag.DurationVar(&writeTimeout, "write-timeout", 0, "HTTP server write timeout")
+	flag.DurationVar(&idleTimeout, "idle-timeout", 0, "HTTP server idle timeout")
+	flag.BoolVar(&fetchTitles, "fetch-titles", false, "fetch page titles for URLs created without title")
+	flag.DurationVar(&titleFetchTimeout, "title-fetch-timeout", 0, "page title fetch timeout")
+	flag.Int64Var(&titleFetchMaxBytes, "title-fetch-max-bytes", 0, "maximum page size read when fetching title")
+	flag.IntVar(&titleFetchConcurrency, "title-fetch-concurrency", 0, "maximum number of concurrent title fetches")
+	flag.BoolVar(&redirectPassQuery, "redirect-pass-query", false, "merge incoming query string into redirect URL by default")
+	flag.BoolVar(&redirectPassPath, "redirect-pass-path", false, "forward extra path segments onto redirect URL by default")
+	flag.StringVar(&redirectQueryConflict, "redirect-query-conflict", "", "duplicate query parameter policy: keep, override or append")
+	flag.StringVar(&holdingPageURL, "holding-page-url", "", "page shown for links that are not active yet")
+	flag.BoolVar(&healthCheckEnabled, "health-check", false, "periodically check availability of original URLs")
+	flag.DurationVar(&healthCheckInterval, "health-check-interval", 0, "interval between URL availability checks")
+	flag.DurationVar(&healthCheckTimeout, "health-check-timeout", 0, "URL availability check timeout")
+	flag.IntVar(&healthCheckConcurrency, "health-check-concurrency", 0, "maximum number of concurrent URL availability checks")
+	flag.DurationVar(&healthCheckHostInterval, "health-check-host-interval", 0, "minimum interval between checks of the same host")
+	flag.BoolVar(&healthCheckMarkBroken, "health-check-mark-broken", false, "mark unavailable URLs as broken")
+	flag.StringVar(&adminToken, "admin-token", "", "admin API access token")
+	flag.BoolVar(&enableWebhooks, "webhooks", false, "deliver link lifecycle events to user webhooks")
+	flag.DurationVar(&webhookPollInterval, "webhook-poll-interval", 0, "interval between webhook delivery queue polls")
+	flag.DurationVar(&webhookTimeout, "webhook-timeout", 0, "webhook delivery request timeout")
+	flag.IntVar(&webhookMaxAttempts, "webhook-max-attempts", 0, "maximum number of webhook delivery attempts")
+	flag.DurationVar(&webhookBackoffBase, "webhook-backoff-base", 0, "delay before the first webhook delivery retry")
+	flag.DurationVar(&webhookBackoffMax, "webhook-backoff-max", 0, "maximum delay between webhook delivery retries")
+	flag.StringVar(&shortStrategy, "short-strategy", "", "short URL generation strategy: hash, random, counter or hashids")
+	flag.IntVar(&shortLength, "short-length", 0, "short URL length (minimum length for counter strategies)")
+	flag.StringVar(&shortAlphabet, "short-alphabet", "", "characters used in short URLs")
+	flag.StringVar(&shortSalt, "short-salt", "", "salt of the hashids short URL strategy")
 
 	flag.Parse()
 
@@ -84,6 +403,9 @@ func (c *Config) loanFromFlags() {
 	if c.BaseURL == "" {
 		c.BaseURL = baseURL
 	}
+	if len(c.Domains) == 0 {
+		c.Domains = splitList(domains)
+	}
 	if c.FileStoragePath == "" {
 		c.FileStoragePath = fileStoragePath
 	}
@@ -111,6 +433,141 @@ func (c *Config) loanFromFlags() {
 	if !c.EnableGRPC {
 		c.EnableGRPC = enableGRPC
 	}
+	if !c.GRPCReflection {
+		c.GRPCReflection = grpcReflection
+	}
+	if c.GRPCAddress == "" {
+		c.GRPCAddress = grpcAddress
+	}
+	if !c.PerUserOriginals {
+		c.PerUserOriginals = perUserOriginals
+	}
+	if len(c.CORSAllowedOrigins) == 0 {
+		c.CORSAllowedOrigins = splitList(corsOrigins)
+	}
+	if len(c.CORSAllowedMethods) == 0 {
+		c.CORSAllowedMethods = splitList(corsMethods)
+	}
+	if len(c.CORSAllowedHeaders) == 0 {
+		c.CORSAllowedHeaders = splitList(corsHeaders)
+	}
+	if !c.CORSAllowCredentials {
+		c.CORSAllowCredentials = corsCredentials
+	}
+	if c.CORSMaxAge == 0 {
+		c.CORSMaxAge = corsMaxAge
+	}
+	if c.HSTSMaxAge == 0 {
+		c.HSTSMaxAge = hstsMaxAge
+	}
+	if c.ReferrerPolicy == "" {
+		c.ReferrerPolicy = referrerPolicy
+	}
+	if c.MaxBodySize == 0 {
+		c.MaxBodySize = maxBodySize
+	}
+	if c.MaxBatchBodySize == 0 {
+		c.MaxBatchBodySize = maxBatchBodySize
+	}
+	if c.HandlerTimeout == 0 {
+		c.HandlerTimeout = handlerTimeout
+	}
+	if c.BatchTimeout == 0 {
+		c.BatchTimeout = batchTimeout
+	}
+	if c.DeleteTimeout == 0 {
+		c.DeleteTimeout = deleteTimeout
+	}
+	if c.StatsTimeout == 0 {
+		c.StatsTimeout = statsTimeout
+	}
+	if c.ReadTimeout == 0 {
+		c.ReadTimeout = readTimeout
+	}
+	if c.ReadHeaderTimeout == 0 {
+		c.ReadHeaderTimeout = readHeaderTimeout
+	}
+	if c.WriteTimeout == 0 {
+		c.WriteTimeout = writeTimeout
+	}
+	if c.IdleTimeout == 0 {
+		c.IdleTimeout = idleTimeout
+	}
+	if !c.FetchTitles {
+		c.FetchTitles = fetchTitles
+	}
+	if c.TitleFetchTimeout == 0 {
+		c.TitleFetchTimeout = titleFetchTimeout
+	}
+	if c.TitleFetchMaxBytes == 0 {
+		c.TitleFetchMaxBytes = titleFetchMaxBytes
+	}
+	if c.TitleFetchConcurrency == 0 {
+		c.TitleFetchConcurrency = titleFetchConcurrency
+	}
+	if !c.RedirectPassQuery {
+		c.RedirectPassQuery = redirectPassQuery
+	}
+	if !c.RedirectPassPath {
+		c.RedirectPassPath = redirectPassPath
+	}
+	if c.RedirectQueryConflict == "" {
+		c.RedirectQueryConflict = redirectQueryConflict
+	}
+	if c.HoldingPageURL == "" {
+		c.HoldingPageURL = holdingPageURL
+	}
+	if !c.HealthCheckEnabled {
+		c.HealthCheckEnabled = healthCheckEnabled
+	}
+	if c.HealthCheckInterval == 0 {
+		c.HealthCheckInterval = healthCheckInterval
+	}
+	if c.HealthCheckTimeout == 0 {
+		c.HealthCheckTimeout = healthCheckTimeout
+	}
+	if c.HealthCheckConcurrency == 0 {
+		c.HealthCheckConcurrency = healthCheckConcurrency
+	}
+	if c.HealthCheckHostInterval == 0 {
+		c.HealthCheckHostInterval = healthCheckHostInterval
+	}
+	if !c.HealthCheckMarkBroken {
+		c.HealthCheckMarkBroken = healthCheckMarkBroken
+	}
+	if c.AdminToken == "" {
+		c.AdminToken = adminToken
+	}
+	if !c.EnableWebhooks {
+		c.EnableWebhooks = enableWebhooks
+	}
+	if c.WebhookPollInterval == 0 {
+		c.WebhookPollInterval = webhookPollInterval
+	}
+	if c.WebhookTimeout == 0 {
+		c.WebhookTimeout = webhookTimeout
+	}
+	if c.WebhookMaxAttempts == 0 {
+		c.WebhookMaxAttempts = webhookMaxAttempts
+	}
+	if c.WebhookBackoffBase == 0 {
+		c.WebhookBackoffBase = webhookBackoffBase
+	}
+	if c.WebhookBackoffMax == 0 {
+		c.WebhookBackoffMax = webhookBackoffMax
+	}
+	if c.ShortStrategy == "" {
+		c.ShortStrategy = shortStrategy
+	}
+	if c.ShortLength == 0 {
+		c.ShortLength = shortLength
+	}
+	if c.ShortAlphabet == "" {
+		c.ShortAlphabet = shortAlphabet
+	}
+	if c.ShortSalt == "" {
+		c.ShortSalt = shortSalt
+	}
 }
 
 // loanFromFile - загрузка конфигурации из файла.
@@ -120,19 +577,76 @@ func (c *Config) loanFromFile() error {
 	}
 
 	type ConfigAlias struct {
-		ServerAddress   string `json:"server_address"`
-		BaseURL         string `json:"base_url"`
-		LogLevel        string `json:"log_level"`
-		FileStoragePath string `json:"file_storage_path"`
-		DataBaseDSN     string `json:"database_dsn"`
-		AuditFile       string `json:"audit_file"`
-		AuditURL        string `json:"audit_url"`
-		EnableHTTPS     bool   `json:"enable_https"`
-		CertFilePath    string `json:"cert_file_path"`
-		KeyFilePath     string `json:"key_file_path"`
-		TrustedSubnet   string `json:"trusted_subnet"`
-		UseHeader       bool   `json:"use_header"`
-		EnableGRPC      bool   `json:"enable_gRPC"`
+		ServerAddress    string   `json:"server_address"`
+		BaseURL          string   `json:"base_url"`
+		Domains          []string `json:"domains"`
+		LogLevel         string   `json:"log_level"`
+		FileStoragePath  string   `json:"file_storage_path"`
+		DataBaseDSN      string   `json:"database_dsn"`
+		AuditFile        string   `json:"audit_file"`
+		AuditURL         string   `json:"audit_url"`
+		EnableHTTPS      bool     `json:"enable_https"`
+		CertFilePath     string   `json:"cert_file_path"`
+		KeyFilePath      string   `json:"key_file_path"`
+		ClientCAFilePath string   `json:"client_ca_file_path"`
+		TrustedSubnet    string   `json:"trusted_subnet"`
+		UseHeader        bool     `json:"use_header"`
+		EnableGRPC       bool     `json:"enable_gRPC"`
+		GRPCReflection   bool     `json:"grpc_reflection"`
+		GRPCAddress      string   `json:"grpc_address"`
+
+		PerUserOriginals bool `json:"per_user_originals"`
+
+		CORSAllowedOrigins   []string `json:"cors_allowed_origins"`
+		CORSAllowedMethods   []string `json:"cors_allowed_methods"`
+		CORSAllowedHeaders   []string `json:"cors_allowed_headers"`
+		CORSAllowCredentials bool     `json:"cors_allow_credentials"`
+		CORSMaxAge           int      `json:"cors_max_age"`
+		HSTSMaxAge           int      `json:"hsts_max_age"`
+		ReferrerPolicy       string   `json:"referrer_policy"`
+
+		MaxBodySize       int64  `json:"max_body_size"`
+		MaxBatchBodySize  int64  `json:"max_batch_body_size"`
+		HandlerTimeout    string `json:"handler_timeout"`
+		BatchTimeout      string `json:"batch_timeout"`
+		DeleteTimeout     string `json:"delete_timeout"`
+		StatsTimeout      string `json:"stats_timeout"`
+		ReadTimeout       string `json:"read_timeout"`
+		ReadHeaderTimeout string `json:"read_header_timeout"`
+		WriteTimeout      string `json:"write_timeout"`
+		IdleTimeout       string `json:"idle_timeout"`
+
+		FetchTitles           bool   `json:"fetch_titles"`
+		TitleFetchTimeout     string `json:"title_fetch_timeout"`
+		TitleFetchMaxBytes    int64  `json:"title_fetch_max_bytes"`
+		TitleFetchConcurrency int    `json:"title_fetch_concurrency"`
+
+		RedirectPassQuery     bool   `json:"redirect_pass_query"`
+		RedirectPassPath      bool   `json:"redirect_pass_path"`
+		RedirectQueryConflict string `json:"redirect_query_conflict"`
+
+		HoldingPageURL string `json:"holding_page_url"`
+
+		HealthCheckEnabled      bool   `json:"health_check"`
+		HealthCheckInterval     string `json:"health_check_interval"`
+		HealthCheckTimeout      string `json:"health_check_timeout"`
+		HealthCheckConcurrency  int    `json:"health_check_concurrency"`
+		HealthCheckHostInterval string `json:"health_check_host_interval"`
+		HealthCheckMarkBroken   bool   `json:"health_check_mark_broken"`
+
+		AdminToken string `json:"admin_token"`
+
+		EnableWebhooks      bool   `json:"enable_webhooks"`
+		WebhookPollInterval string `json:"webhook_poll_interval"`
+		WebhookTimeout      string `json:"webhook_timeout"`
+		WebhookMaxAttempts  int    `json:"webhook_max_attempts"`
+		WebhookBackoffBase  string `json:"webhook_backoff_base"`
+		WebhookBackoffMax   string `json:"webhook_backoff_max"`
+
+		ShortStrategy string `json:"short_strategy"`
+		ShortLength   int    `json:"short_length"`
+		ShortAlphabet string `json:"short_alphabet"`
+		ShortSalt     string `json:"short_salt"`
 	}
 
 	var configAlias ConfigAlias
@@ -159,6 +673,9 @@ func (c *Config) loanFromFile() error {
 	if c.BaseURL == "" {
 		c.BaseURL = configAlias.BaseURL
 	}
+	if len(c.Domains) == 0 {
+		c.Domains = configAlias.Domains
+	}
 
 	if c.LogLevel == "" {
 		c.LogLevel = configAlias.LogLevel
@@ -192,6 +709,10 @@ func (c *Config) loanFromFile() error {
 		c.KeyFilePath = configAlias.KeyFilePath
 	}
 
+	if c.ClientCAFilePath == "" {
+		c.ClientCAFilePath = configAlias.ClientCAFilePath
+	}
+
 	if !c.UseHeader {
 		c.UseHeader = configAlias.UseHeader
 	}
@@ -200,5 +721,153 @@ func (c *Config) loanFromFile() error {
 		c.EnableGRPC = configAlias.EnableGRPC
 	}
 
+	if !c.GRPCReflection {
+		c.GRPCReflection = configAlias.GRPCReflection
+	}
+
+	if c.GRPCAddress == "" {
+		c.GRPCAddress = configAlias.GRPCAddress
+	}
+
+	if !c.PerUserOriginals {
+		c.PerUserOriginals = configAlias.PerUserOriginals
+	}
+
+	if len(c.CORSAllowedOrigins) == 0 {
+		c.CORSAllowedOrigins = configAlias.CORSAllowedOrigins
+	}
+
+	if len(c.CORSAllowedMethods) == 0 {
+		c.CORSAllowedMethods = configAlias.CORSAllowedMethods
+	}
+
+	if len(c.CORSAllowedHeaders) == 0 {
+		c.CORSAllowedHeaders = configAlias.CORSAllowedHeaders
+	}
+
+	if !c.CORSAllowCredentials {
+		c.CORSAllowCredentials = configAlias.CORSAllowCredentials
+	}
+
+	if c.CORSMaxAge == 0 {
+		c.CORSMaxAge = configAlias.CORSMaxAge
+	}
+
+	if c.HSTSMaxAge == 0 {
+		c.HSTSMaxAge = configAlias.HSTSMaxAge
+	}
+
+	if c.ReferrerPolicy == "" {
+		c.ReferrerPolicy = configAlias.ReferrerPolicy
+	}
+
+	if c.MaxBodySize == 0 {
+		c.MaxBodySize = configAlias.MaxBodySize
+	}
+
+	if c.MaxBatchBodySize == 0 {
+		c.MaxBatchBodySize = configAlias.MaxBatchBodySize
+	}
+
+	if !c.FetchTitles {
+		c.FetchTitles = configAlias.FetchTitles
+	}
+
+	if c.TitleFetchMaxBytes == 0 {
+		c.TitleFetchMaxBytes = configAlias.TitleFetchMaxBytes
+	}
+
+	if c.TitleFetchConcurrency == 0 {
+		c.TitleFetchConcurrency = configAlias.TitleFetchConcurrency
+	}
+
+	if !c.RedirectPassQuery {
+		c.RedirectPassQuery = configAlias.RedirectPassQuery
+	}
+
+	if !c.RedirectPassPath {
+		c.RedirectPassPath = configAlias.RedirectPassPath
+	}
+
+	if c.RedirectQueryConflict == "" {
+		c.RedirectQueryConflict = configAlias.RedirectQueryConflict
+	}
+
+	if c.HoldingPageURL == "" {
+		c.HoldingPageURL = configAlias.HoldingPageURL
+	}
+
+	if !c.HealthCheckEnabled {
+		c.HealthCheckEnabled = configAlias.HealthCheckEnabled
+	}
+
+	if c.HealthCheckConcurrency == 0 {
+		c.HealthCheckConcurrency = configAlias.HealthCheckConcurrency
+	}
+
+	if !c.HealthCheckMarkBroken {
+		c.HealthCheckMarkBroken = configAlias.HealthCheckMarkBroken
+	}
+
+	if c.AdminToken == "" {
+		c.AdminToken = configAlias.AdminToken
+	}
+
+	if !c.EnableWebhooks {
+		c.EnableWebhooks = configAlias.EnableWebhooks
+	}
+
+	if c.WebhookMaxAttempts == 0 {
+		c.WebhookMaxAttempts = configAlias.WebhookMaxAttempts
+	}
+
+	if c.ShortStrategy == "" {
+		c.ShortStrategy = configAlias.ShortStrategy
+	}
+
+	if c.ShortLength == 0 {
+		c.ShortLength = configAlias.ShortLength
+	}
+
+	if c.ShortAlphabet == "" {
+		c.ShortAlphabet = configAlias.ShortAlphabet
+	}
+
+	if c.ShortSalt == "" {
+		c.ShortSalt = configAlias.ShortSalt
+	}
+
+	durations := []struct {
+		target *time.Duration
+		value  string
+	}{
+		{&c.HandlerTimeout, configAlias.HandlerTimeout},
+		{&c.BatchTimeout, configAlias.BatchTimeout},
+		{&c.DeleteTimeout, configAlias.DeleteTimeout},
+		{&c.StatsTimeout, configAlias.StatsTimeout},
+		{&c.ReadTimeout, configAlias.ReadTimeout},
+		{&c.ReadHeaderTimeout, configAlias.ReadHeaderTimeout},
+		{&c.WriteTimeout, configAlias.WriteTimeout},
+		{&c.IdleTimeout, configAlias.IdleTimeout},
+		{&c.TitleFetchTimeout, configAlias.TitleFetchTimeout},
+		{&c.HealthCheckInterval, configAlias.HealthCheckInterval},
+		{&c.HealthCheckTimeout, configAlias.HealthCheckTimeout},
+		{&c.HealthCheckHostInterval, configAlias.HealthCheckHostInterval},
+		{&c.WebhookPollInterval, configAlias.WebhookPollInterval},
+		{&c.WebhookTimeout, configAlias.WebhookTimeout},
+		{&c.WebhookBackoffBase, configAlias.WebhookBackoffBase},
+		{&c.WebhookBackoffMax, configAlias.WebhookBackoffMax},
+	}
+	for _, d := range durations {
+		if *d.target != 0 {
+			continue
+		}
+		duration, err := parseDuration(d.value)
+		if err != nil {
+			return fmt.Errorf("path: internal/config/config.go, func loanFromJSON(), failed to parse duration: %w", err)
+		}
+		*d.target = duration
+	}
+
 	return nil
 }
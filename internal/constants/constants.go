@@ -10,9 +10,10 @@ type contextKey string
 
 // Для использования при создании хэша URL.
 const (
-	HashLength            = 8
-	TokenExp              = time.Hour * 3
-	UserIDKey  contextKey = "userID"
+	TokenExp             = time.Hour * 3
+	UserIDKey contextKey = "userID"
+	// ShortAttempts - предельное число попыток подобрать свободный короткий URL при коллизиях.
+	ShortAttempts = 5
 )
 
 // Роли пользователей.
@@ -24,24 +25,56 @@ const (
 var (
 	// URL уже существует
 	ErrorURLAlreadyExist = errors.New("URL already exists")
+	// короткий URL уже занят другим оригинальным URL
+	ErrorShortCollision = errors.New("short URL collision")
 	// URL не существует
 	ErrorURLNotExist = errors.New("URL doesn't exist")
 	// Метод не разрешен
 	ErrorMethodNotAllowed = errors.New("method not allowed")
 	// URL уже удален
 	ErrorURLAlreadyDeleted = errors.New("URL already deleted")
+	// URL еще не активен
+	ErrorURLNotYetActive = errors.New("URL is not active yet")
+	// лимит переходов по URL исчерпан
+	ErrorURLClicksExhausted = errors.New("URL click limit exhausted")
+	// URL отключен администратором
+	ErrorURLDisabled = errors.New("URL disabled")
+	// пользователю запрещено создавать URL
+	ErrorUserBlocked = errors.New("user is blocked")
 	// нет валидных данных
 	ErrorNoData = errors.New("URL already deleted")
 	// даныне не найдены
 	ErrorNotFound = errors.New("URL not found")
+	// некорректные параметры подписки на события
+	ErrorInvalidWebhook = errors.New("invalid webhook")
+	// некорректный период статистики
+	ErrorInvalidStatsRange = errors.New("invalid stats range")
+	// домен не входит в список обслуживаемых
+	ErrorUnknownDomain = errors.New("unknown domain")
+	// некорректные параметры поиска URL
+	ErrorInvalidLookup = errors.New("invalid lookup")
+	// некорректный токен продолжения списка URL
+	ErrorInvalidPageToken = errors.New("invalid page token")
+	// некорректные параметры создания короткого URL
+	ErrorInvalidShortOptions = errors.New("invalid short URL options")
+	// токен аутентификации не прошел проверку
+	ErrorTokenNotValid = errors.New("token not valid")
+	// в токене аутентификации отсутствует идентификатор сессии
+	ErrorSessionNotValid = errors.New("session ID not valid")
+	// файл корневых сертификатов клиентов не содержит ни одного сертификата
+	ErrorClientCANotValid = errors.New("client CA bundle contains no certificates")
+	// очередь доставки событий не сохраняется в файле-хранилище
+	ErrorWebhooksNotPersistent = errors.New("webhooks require database storage when file storage is used")
 )
 
 // Тексты ошибок.
 var (
-	ReadRequestError   = "unable to read request body"
-	EmptyBodyError     = "request body is empty"
-	InvalidJSONError   = "invalid JSON format"
-	InternalError      = "internal error"
-	WriteResponseError = "error writing response"
-	NoContentError     = "no content"
+	ReadRequestError     = "unable to read request body"
+	EmptyBodyError       = "request body is empty"
+	InvalidJSONError     = "invalid JSON format"
+	InternalError        = "internal error"
+	WriteResponseError   = "error writing response"
+	NoContentError       = "no content"
+	RequestTooLargeError = "request body too large"
+	TimeoutError         = "request timeout exceeded"
 )
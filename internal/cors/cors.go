@@ -0,0 +1,113 @@
+// Package cors предоставляет middleware для обработки кросс-доменных запросов (CORS).
+package cors
+
+import (
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// Options - параметры CORS.
+type Options struct {
+	AllowedOrigins   []string
+	AllowedMethods   []string
+	AllowedHeaders   []string
+	AllowCredentials bool
+	MaxAge           int
+}
+
+// isOriginAllowed - проверка, разрешен ли источник запроса.
+func (o *Options) isOriginAllowed(origin string) bool {
+	return slices.Contains(o.AllowedOrigins, "*") || slices.Contains(o.AllowedOrigins, origin)
+}
+
+// isMethodAllowed - проверка, разрешен ли метод запроса.
+func (o *Options) isMethodAllowed(method string) bool {
+	for _, allowed := range o.AllowedMethods {
+		if strings.EqualFold(allowed, method) {
+			return true
+		}
+	}
+	return false
+}
+
+// allowedHeaders - получение списка разрешенных заголовков для preflight-запроса.
+func (o *Options) allowedHeaders(requested string) string {
+	if slices.Contains(o.AllowedHeaders, "*") {
+		return requested
+	}
+	return strings.Join(o.AllowedHeaders, ", ")
+}
+
+// setOriginHeaders - установка заголовков, общих для всех CORS-ответов.
+func (o *Options) setOriginHeaders(header http.Header, origin string) {
+	header.Add("Vary", "Origin")
+
+	// Источник, разрешенный только через "*", не получает доступ к cookie:
+	// иначе любой сайт мог бы выполнять запросы от имени пользователя.
+	if !slices.Contains(o.AllowedOrigins, origin) {
+		header.Set("Access-Control-Allow-Origin", "*")
+		return
+	}
+
+	// При передаче cookie браузер не принимает "*", поэтому возвращается конкретный источник.
+	header.Set("Access-Control-Allow-Origin", origin)
+	if o.AllowCredentials {
+		header.Set("Access-Control-Allow-Credentials", "true")
+	}
+}
+
+// WithCORS - middleware для обработки CORS-запросов.
+func WithCORS(options Options) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if len(options.AllowedOrigins) == 0 {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			origin := r.Header.Get("Origin")
+			if origin == "" {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			requestMethod := r.Header.Get("Access-Control-Request-Method")
+			isPreflight := r.Method == http.MethodOptions && requestMethod != ""
+
+			if !options.isOriginAllowed(origin) {
+				if isPreflight {
+					w.WriteHeader(http.StatusForbidden)
+					return
+				}
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			options.setOriginHeaders(w.Header(), origin)
+
+			if !isPreflight {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			w.Header().Add("Vary", "Access-Control-Request-Method")
+			w.Header().Add("Vary", "Access-Control-Request-Headers")
+
+			if !options.isMethodAllowed(requestMethod) {
+				w.WriteHeader(http.StatusForbidden)
+				return
+			}
+
+			w.Header().Set("Access-Control-Allow-Methods", strings.Join(options.AllowedMethods, ", "))
+			if headers := options.allowedHeaders(r.Header.Get("Access-Control-Request-Headers")); headers != "" {
+				w.Header().Set("Access-Control-Allow-Headers", headers)
+			}
+			if options.MaxAge > 0 {
+				w.Header().Set("Access-Control-Max-Age", strconv.Itoa(options.MaxAge))
+			}
+			w.WriteHeader(http.StatusNoContent)
+		})
+	}
+}
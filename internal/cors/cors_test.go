@@ -0,0 +1,169 @@
+package cors
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWithCORS(t *testing.T) {
+	options := Options{
+		AllowedOrigins:   []string{"https://app.example.com"},
+		AllowedMethods:   []string{"GET", "POST", "OPTIONS"},
+		AllowedHeaders:   []string{"Content-Type"},
+		AllowCredentials: true,
+		MaxAge:           600,
+	}
+
+	type want struct {
+		statusCode       int
+		allowOrigin      string
+		allowCredentials string
+		allowMethods     string
+		allowHeaders     string
+		maxAge           string
+	}
+
+	tests := []struct {
+		name          string
+		options       Options
+		method        string
+		origin        string
+		requestMethod string
+		want          want
+	}{
+		{
+			name:   "запрос без Origin",
+			method: http.MethodPost,
+			want: want{
+				statusCode: http.StatusOK,
+			},
+		},
+		{
+			name:   "простой запрос с разрешенного источника",
+			method: http.MethodPost,
+			origin: "https://app.example.com",
+			want: want{
+				statusCode:       http.StatusOK,
+				allowOrigin:      "https://app.example.com",
+				allowCredentials: "true",
+			},
+		},
+		{
+			name:   "простой запрос с неразрешенного источника",
+			method: http.MethodPost,
+			origin: "https://evil.example.com",
+			want: want{
+				statusCode: http.StatusOK,
+			},
+		},
+		{
+			name:          "preflight-запрос с разрешенного источника",
+			method:        http.MethodOptions,
+			origin:        "https://app.example.com",
+			requestMethod: http.MethodPost,
+			want: want{
+				statusCode:       http.StatusNoContent,
+				allowOrigin:      "https://app.example.com",
+				allowCredentials: "true",
+				allowMethods:     "GET, POST, OPTIONS",
+				allowHeaders:     "Content-Type",
+				maxAge:           "600",
+			},
+		},
+		{
+			name:          "preflight-запрос с неразрешенным методом",
+			method:        http.MethodOptions,
+			origin:        "https://app.example.com",
+			requestMethod: http.MethodDelete,
+			want: want{
+				statusCode:       http.StatusForbidden,
+				allowOrigin:      "https://app.example.com",
+				allowCredentials: "true",
+			},
+		},
+		{
+			name:          "preflight-запрос с неразрешенного источника",
+			method:        http.MethodOptions,
+			origin:        "https://evil.example.com",
+			requestMethod: http.MethodPost,
+			want: want{
+				statusCode: http.StatusForbidden,
+			},
+		},
+		{
+			name: "любой источник без передачи cookie",
+			options: Options{
+				AllowedOrigins: []string{"*"},
+				AllowedMethods: []string{"GET"},
+			},
+			method: http.MethodGet,
+			origin: "https://any.example.com",
+			want: want{
+				statusCode:  http.StatusOK,
+				allowOrigin: "*",
+			},
+		},
+		{
+			name: "любой источник с передачей cookie",
+			options: Options{
+				AllowedOrigins:   []string{"*"},
+				AllowedMethods:   []string{"GET"},
+				AllowCredentials: true,
+			},
+			method: http.MethodGet,
+			origin: "https://evil.example.com",
+			want: want{
+				statusCode:  http.StatusOK,
+				allowOrigin: "*",
+			},
+		},
+		{
+			name: "явно указанный источник вместе с любым источником",
+			options: Options{
+				AllowedOrigins:   []string{"*", "https://app.example.com"},
+				AllowedMethods:   []string{"GET"},
+				AllowCredentials: true,
+			},
+			method: http.MethodGet,
+			origin: "https://app.example.com",
+			want: want{
+				statusCode:       http.StatusOK,
+				allowOrigin:      "https://app.example.com",
+				allowCredentials: "true",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts := options
+			if tt.options.AllowedOrigins != nil {
+				opts = tt.options
+			}
+
+			handler := WithCORS(opts)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusOK)
+			}))
+
+			req := httptest.NewRequest(tt.method, "/api/shorten", nil)
+			if tt.origin != "" {
+				req.Header.Set("Origin", tt.origin)
+			}
+			if tt.requestMethod != "" {
+				req.Header.Set("Access-Control-Request-Method", tt.requestMethod)
+			}
+			res := httptest.NewRecorder()
+
+			handler.ServeHTTP(res, req)
+
+			assert.Equal(t, tt.want.statusCode, res.Code)
+			assert.Equal(t, tt.want.allowOrigin, res.Header().Get("Access-Control-Allow-Origin"))
+			assert.Equal(t, tt.want.allowCredentials, res.Header().Get("Access-Control-Allow-Credentials"))
+			assert.Equal(t, tt.want.allowMethods, res.Header().Get("Access-Control-Allow-Methods"))
+			assert.Equal(t, tt.want.allowHeaders, res.Header().Get("Access-Control-Allow-Headers"))
+			assert.Equal(t, tt.want.maxAge, res.Header().Get("Access-Control-Max-Age"))
+		})
+	}
+}
@@ -0,0 +1,83 @@
+// Package domains реализует сопоставление доменов, с которых обслуживаются короткие ссылки,
+// с базовыми URL ответов и пространствами имен коротких URL.
+package domains
+
+import (
+	"net"
+	"net/url"
+	"strings"
+
+	"github.com/Di-nis/shortener-url/internal/constants"
+)
+
+// Registry - набор обслуживаемых доменов.
+// Домен по умолчанию определяется базовым URL и обозначается пустой строкой.
+type Registry struct {
+	defaultBaseURL string
+	defaultHost    string
+	// baseURLs - базовые URL дополнительных доменов по имени хоста
+	baseURLs map[string]string
+}
+
+// NewRegistry - создание набора доменов из базового URL и списка базовых URL дополнительных доменов.
+// Записи, из которых не удается получить имя хоста, пропускаются.
+func NewRegistry(baseURL string, domains []string) *Registry {
+	registry := &Registry{
+		defaultBaseURL: strings.TrimSuffix(baseURL, "/"),
+		defaultHost:    hostOf(baseURL),
+		baseURLs:       make(map[string]string, len(domains)),
+	}
+	for _, domain := range domains {
+		host := hostOf(domain)
+		if host == "" || host == registry.defaultHost {
+			continue
+		}
+		registry.baseURLs[host] = strings.TrimSuffix(domain, "/")
+	}
+	return registry
+}
+
+// hostOf - имя хоста базового URL в нижнем регистре.
+func hostOf(baseURL string) string {
+	u, err := url.Parse(baseURL)
+	if err != nil {
+		return ""
+	}
+	return strings.ToLower(u.Host)
+}
+
+// Resolve - проверка домена, выбранного создателем ссылки.
+// Возвращает пустую строку для домена по умолчанию и ErrorUnknownDomain для необслуживаемого домена.
+func (r *Registry) Resolve(domain string) (string, error) {
+	domain = strings.ToLower(strings.TrimSpace(domain))
+	if domain == "" || domain == r.defaultHost {
+		return "", nil
+	}
+	if _, ok := r.baseURLs[domain]; ok {
+		return domain, nil
+	}
+	return "", constants.ErrorUnknownDomain
+}
+
+// FromHost - домен, в пространстве имен которого обрабатывается запрос с заголовком Host.
+// Хост сравнивается сначала целиком, затем без порта; неизвестные хосты относятся к домену по умолчанию.
+func (r *Registry) FromHost(host string) string {
+	host = strings.ToLower(host)
+	if _, ok := r.baseURLs[host]; ok {
+		return host
+	}
+	if hostname, _, err := net.SplitHostPort(host); err == nil {
+		if _, ok := r.baseURLs[hostname]; ok {
+			return hostname
+		}
+	}
+	return ""
+}
+
+// BaseURL - базовый URL домена для построения коротких ссылок в ответах.
+func (r *Registry) BaseURL(domain string) string {
+	if baseURL, ok := r.baseURLs[domain]; ok {
+		return baseURL
+	}
+	return r.defaultBaseURL
+}
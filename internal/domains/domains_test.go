@@ -0,0 +1,105 @@
+package domains
+
+import (
+	"testing"
+
+	"github.com/Di-nis/shortener-url/internal/constants"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestRegistry() *Registry {
+	return NewRegistry("http://localhost:8080", []string{
+		"https://go.brand-a.com/",
+		"https://Brand-B.io",
+		"http://localhost:8080",
+		"::not a url",
+	})
+}
+
+func TestRegistry_Resolve(t *testing.T) {
+	tests := []struct {
+		name    string
+		domain  string
+		want    string
+		wantErr error
+	}{
+		{
+			name:   "домен не выбран",
+			domain: "",
+			want:   "",
+		},
+		{
+			name:   "домен по умолчанию",
+			domain: "localhost:8080",
+			want:   "",
+		},
+		{
+			name:   "дополнительный домен",
+			domain: "go.brand-a.com",
+			want:   "go.brand-a.com",
+		},
+		{
+			name:   "регистр имени домена не учитывается",
+			domain: " BRAND-B.IO ",
+			want:   "brand-b.io",
+		},
+		{
+			name:    "домен не обслуживается",
+			domain:  "evil.example",
+			want:    "",
+			wantErr: constants.ErrorUnknownDomain,
+		},
+	}
+	registry := newTestRegistry()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := registry.Resolve(tt.domain)
+			assert.ErrorIs(t, err, tt.wantErr)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestRegistry_FromHost(t *testing.T) {
+	tests := []struct {
+		name string
+		host string
+		want string
+	}{
+		{
+			name: "хост домена по умолчанию",
+			host: "localhost:8080",
+			want: "",
+		},
+		{
+			name: "хост дополнительного домена",
+			host: "go.brand-a.com",
+			want: "go.brand-a.com",
+		},
+		{
+			name: "хост дополнительного домена с портом",
+			host: "GO.BRAND-A.COM:443",
+			want: "go.brand-a.com",
+		},
+		{
+			name: "неизвестный хост",
+			host: "127.0.0.1:53412",
+			want: "",
+		},
+	}
+	registry := newTestRegistry()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, registry.FromHost(tt.host))
+		})
+	}
+}
+
+func TestRegistry_BaseURL(t *testing.T) {
+	registry := newTestRegistry()
+
+	assert.Equal(t, "http://localhost:8080", registry.BaseURL(""))
+	assert.Equal(t, "https://go.brand-a.com", registry.BaseURL("go.brand-a.com"))
+	assert.Equal(t, "https://Brand-B.io", registry.BaseURL("brand-b.io"))
+	assert.Equal(t, "http://localhost:8080", registry.BaseURL("evil.example"))
+}
@@ -0,0 +1,147 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/Di-nis/shortener-url/internal/authn"
+	"github.com/Di-nis/shortener-url/internal/cidr"
+	"github.com/Di-nis/shortener-url/internal/constants"
+	"github.com/Di-nis/shortener-url/internal/limits"
+	"github.com/Di-nis/shortener-url/internal/models"
+	"github.com/go-chi/chi/v5"
+)
+
+// AdminManager - интерфейс, включающий административные операции над URL и пользователями.
+type AdminManager interface {
+	SearchURLs(context.Context, models.AdminFilter) ([]models.URLBase, error)
+	DeleteURL(context.Context, string, string) error
+	SetURLDisabled(context.Context, string, string, bool) error
+	BlockUser(context.Context, string) error
+	UnblockUser(context.Context, string) error
+}
+
+// registerAdminRoutes - регистрация маршрутов административного API.
+// Доступ разрешен только из доверенной подсети и с токеном администратора.
+func (c *Controller) registerAdminRoutes(r chi.Router) {
+	r.Route("/admin", func(r chi.Router) {
+		r.Use(cidr.WithCheckCIDR(c.Config.TrustedSubnet, c.Config.UseHeader))
+		r.Use(authn.WithAdminToken(c.Config.AdminToken))
+		r.Use(limits.WithTimeout(c.Config.HandlerTimeout))
+
+		r.Get("/urls", c.adminSearchURLs)
+		r.Delete("/urls/{short_url}", c.adminDeleteURL)
+		r.Post("/urls/{short_url}/disable", c.adminSetURLDisabled(true))
+		r.Post("/urls/{short_url}/enable", c.adminSetURLDisabled(false))
+		r.Get("/users/{user_id}/urls", c.adminSearchURLs)
+		r.Put("/users/{user_id}/block", c.adminBlockUser)
+		r.Delete("/users/{user_id}/block", c.adminUnblockUser)
+	})
+}
+
+// writeStatusAdmin - запись статус-кода в ответ для административных операций.
+func writeStatusAdmin(res http.ResponseWriter, err error) {
+	if err == nil {
+		res.WriteHeader(http.StatusNoContent)
+	} else if errors.Is(err, constants.ErrorURLNotExist) || errors.Is(err, constants.ErrorNotFound) {
+		res.WriteHeader(http.StatusNotFound)
+	} else if limits.IsTimeout(err) {
+		http.Error(res, constants.TimeoutError, http.StatusServiceUnavailable)
+	} else {
+		http.Error(res, constants.InternalError, http.StatusInternalServerError)
+	}
+}
+
+// parseAdminFilter - разбор параметров отбора URL из запроса.
+func parseAdminFilter(req *http.Request) (models.AdminFilter, error) {
+	query := req.URL.Query()
+	filter := models.AdminFilter{
+		UserID: query.Get("user_id"),
+		Query:  query.Get("q"),
+	}
+	if userID := chi.URLParam(req, "user_id"); userID != "" {
+		filter.UserID = userID
+	}
+
+	var err error
+	if value := query.Get("limit"); value != "" {
+		if filter.Limit, err = strconv.Atoi(value); err != nil {
+			return models.AdminFilter{}, err
+		}
+	}
+	if value := query.Get("offset"); value != "" {
+		if filter.Offset, err = strconv.Atoi(value); err != nil {
+			return models.AdminFilter{}, err
+		}
+	}
+	return filter, nil
+}
+
+// adminSearchURLs - поиск URL всех пользователей или URL выбранного пользователя.
+func (c *Controller) adminSearchURLs(res http.ResponseWriter, req *http.Request) {
+	filter, err := parseAdminFilter(req)
+	if err != nil {
+		http.Error(res, "invalid limit or offset", http.StatusBadRequest)
+		return
+	}
+
+	urls, err := c.Admin.SearchURLs(req.Context(), filter)
+	if err != nil {
+		writeStatusAdmin(res, err)
+		return
+	}
+
+	urlsOut := make([]models.AdminURL, 0, len(urls))
+	for _, url := range urls {
+		urlsOut = append(urlsOut, models.AdminURL{
+			UUID:         url.UUID,
+			Short:        c.shortURL(url),
+			Original:     url.Original,
+			DeletedFlag:  url.DeletedFlag,
+			DisabledFlag: url.DisabledFlag,
+		})
+	}
+	writeJSON(res, http.StatusOK, urlsOut)
+}
+
+// adminDeleteURL - удаление URL независимо от владельца.
+// Домен ссылки выбирается параметром domain или заголовком Host запроса.
+func (c *Controller) adminDeleteURL(res http.ResponseWriter, req *http.Request) {
+	domain, err := c.requestDomain(req, req.URL.Query().Get("domain"))
+	if err != nil {
+		http.Error(res, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	err = c.Admin.DeleteURL(req.Context(), domain, chi.URLParam(req, "short_url"))
+	writeStatusAdmin(res, err)
+}
+
+// adminSetURLDisabled - отключение или включение URL независимо от владельца.
+// Домен ссылки выбирается параметром domain или заголовком Host запроса.
+func (c *Controller) adminSetURLDisabled(disabled bool) http.HandlerFunc {
+	return func(res http.ResponseWriter, req *http.Request) {
+		domain, err := c.requestDomain(req, req.URL.Query().Get("domain"))
+		if err != nil {
+			http.Error(res, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		err = c.Admin.SetURLDisabled(req.Context(), domain, chi.URLParam(req, "short_url"), disabled)
+		writeStatusAdmin(res, err)
+	}
+}
+
+// adminBlockUser - запрет пользователю создавать URL.
+func (c *Controller) adminBlockUser(res http.ResponseWriter, req *http.Request) {
+	err := c.Admin.BlockUser(req.Context(), chi.URLParam(req, "user_id"))
+	writeStatusAdmin(res, err)
+}
+
+// adminUnblockUser - снятие запрета на создание URL.
+func (c *Controller) adminUnblockUser(res http.ResponseWriter, req *http.Request) {
+	err := c.Admin.UnblockUser(req.Context(), chi.URLParam(req, "user_id"))
+	writeStatusAdmin(res, err)
+}
@@ -0,0 +1,209 @@
+package handler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Di-nis/shortener-url/internal/authn"
+	"github.com/Di-nis/shortener-url/internal/config"
+	"github.com/Di-nis/shortener-url/internal/models"
+	"github.com/Di-nis/shortener-url/internal/repository"
+	"github.com/Di-nis/shortener-url/internal/service"
+	"github.com/Di-nis/shortener-url/internal/storage"
+	"github.com/Di-nis/shortener-url/internal/usecase"
+	"github.com/go-resty/resty/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestController_admin(t *testing.T) {
+	const (
+		adminToken = "admin-secret"
+		// идентификатор пользователя, который устанавливает MockAuthMiddleware
+		userID = "01KA3YRQCWTNAJEGR5Z30PH6VT"
+	)
+
+	cfg := &config.Config{
+		BaseURL:        "http://localhost:8080",
+		Domains:        []string{"https://go.brand-a.com"},
+		MaxBodySize:    1 << 20,
+		HandlerTimeout: time.Second,
+		UseMockAuth:    true,
+		TrustedSubnet:  "127.0.0.0/8",
+		AdminToken:     adminToken,
+	}
+
+	repo := repository.NewRepoFileMemory(&repository.Storage{Producer: storage.NewProducerMemory(nil)})
+	controller := NewСontroller(usecase.NewURLUseCase(repo, service.NewService()), cfg)
+	controller.Admin = usecase.NewAdminUseCase(repo)
+
+	server := httptest.NewServer(controller.SetupRouter())
+	defer server.Close()
+
+	client := resty.New().SetRedirectPolicy(resty.NoRedirectPolicy())
+	var short string
+
+	t.Run("Предварительное создание данных", func(t *testing.T) {
+		resp, err := client.R().SetBody("https://www.hc-sochi.ru/").Post(server.URL + "/")
+		require.NoError(t, err, "error making HTTP request")
+		require.Equal(t, http.StatusCreated, resp.StatusCode())
+		short = strings.TrimPrefix(resp.String(), "http://localhost:8080/")
+	})
+
+	t.Run("поиск URL всех пользователей", func(t *testing.T) {
+		var urls []models.AdminURL
+		resp, err := client.R().
+			SetHeader(authn.AdminTokenHeader, adminToken).
+			SetResult(&urls).
+			Get(server.URL + "/api/admin/urls?q=HC-SOCHI")
+		require.NoError(t, err, "error making HTTP request")
+		require.Equal(t, http.StatusOK, resp.StatusCode())
+		require.Len(t, urls, 1)
+		assert.Equal(t, userID, urls[0].UUID)
+		assert.Equal(t, "http://localhost:8080/"+short, urls[0].Short)
+	})
+
+	tests := []struct {
+		name       string
+		method     string
+		path       string
+		token      string
+		body       string
+		statusCode int
+	}{
+		{
+			name:       "запрос без токена администратора",
+			method:     http.MethodGet,
+			path:       "/api/admin/urls",
+			statusCode: http.StatusUnauthorized,
+		},
+		{
+			name:       "некорректный параметр limit",
+			method:     http.MethodGet,
+			path:       "/api/admin/urls?limit=ten",
+			token:      adminToken,
+			statusCode: http.StatusBadRequest,
+		},
+		{
+			name:       "URL пользователя",
+			method:     http.MethodGet,
+			path:       "/api/admin/users/" + userID + "/urls",
+			token:      adminToken,
+			statusCode: http.StatusOK,
+		},
+		{
+			name:       "отключение URL",
+			method:     http.MethodPost,
+			path:       "/api/admin/urls/" + short + "/disable",
+			token:      adminToken,
+			statusCode: http.StatusNoContent,
+		},
+		{
+			name:       "отключение URL с тем же кодом в другом домене",
+			method:     http.MethodPost,
+			path:       "/api/admin/urls/" + short + "/disable?domain=go.brand-a.com",
+			token:      adminToken,
+			statusCode: http.StatusNotFound,
+		},
+		{
+			name:       "отключение URL в неизвестном домене",
+			method:     http.MethodPost,
+			path:       "/api/admin/urls/" + short + "/disable?domain=unknown.example.com",
+			token:      adminToken,
+			statusCode: http.StatusBadRequest,
+		},
+		{
+			name:       "переход по отключенному URL",
+			method:     http.MethodGet,
+			path:       "/" + short,
+			statusCode: http.StatusForbidden,
+		},
+		{
+			name:       "включение URL",
+			method:     http.MethodPost,
+			path:       "/api/admin/urls/" + short + "/enable",
+			token:      adminToken,
+			statusCode: http.StatusNoContent,
+		},
+		{
+			name:       "переход по включенному URL",
+			method:     http.MethodGet,
+			path:       "/" + short,
+			statusCode: http.StatusTemporaryRedirect,
+		},
+		{
+			name:       "блокировка пользователя",
+			method:     http.MethodPut,
+			path:       "/api/admin/users/" + userID + "/block",
+			token:      adminToken,
+			statusCode: http.StatusNoContent,
+		},
+		{
+			name:       "создание URL заблокированным пользователем",
+			method:     http.MethodPost,
+			path:       "/",
+			body:       "https://www.ska.ru/",
+			statusCode: http.StatusForbidden,
+		},
+		{
+			name:       "снятие блокировки пользователя",
+			method:     http.MethodDelete,
+			path:       "/api/admin/users/" + userID + "/block",
+			token:      adminToken,
+			statusCode: http.StatusNoContent,
+		},
+		{
+			name:       "создание URL после снятия блокировки",
+			method:     http.MethodPost,
+			path:       "/",
+			body:       "https://www.ska.ru/",
+			statusCode: http.StatusCreated,
+		},
+		{
+			name:       "удаление URL с тем же кодом в другом домене",
+			method:     http.MethodDelete,
+			path:       "/api/admin/urls/" + short + "?domain=go.brand-a.com",
+			token:      adminToken,
+			statusCode: http.StatusNotFound,
+		},
+		{
+			name:       "удаление URL администратором",
+			method:     http.MethodDelete,
+			path:       "/api/admin/urls/" + short,
+			token:      adminToken,
+			statusCode: http.StatusNoContent,
+		},
+		{
+			name:       "переход по удаленному URL",
+			method:     http.MethodGet,
+			path:       "/" + short,
+			statusCode: http.StatusGone,
+		},
+		{
+			name:       "удаление несуществующего URL",
+			method:     http.MethodDelete,
+			path:       "/api/admin/urls/missing",
+			token:      adminToken,
+			statusCode: http.StatusNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := client.R()
+			req.Method = tt.method
+			req.URL = server.URL + tt.path
+			if tt.token != "" {
+				req.SetHeader(authn.AdminTokenHeader, tt.token)
+			}
+			if tt.body != "" {
+				req.Body = tt.body
+			}
+
+			resp, _ := req.Send()
+			assert.Equal(t, tt.statusCode, resp.StatusCode())
+		})
+	}
+}
@@ -28,8 +28,8 @@ func getBenchmarkMocks(ctrl *gomock.Controller) *mocks.MockURLUseCase {
 	mock.EXPECT().Ping(gomock.Any()).Return(nil).AnyTimes()
 	mock.EXPECT().CreateURLOrdinary(gomock.Any(), gomock.Any()).Return(models.URLBase{}, nil).AnyTimes()
 	mock.EXPECT().CreateURLBatch(gomock.Any(), gomock.AssignableToTypeOf([]models.URLBase{})).Return([]models.URLBase{}, nil).AnyTimes()
-	mock.EXPECT().GetOriginalURL(gomock.Any(), urlShort1).Return(urlOriginal1, nil).AnyTimes()
-	mock.EXPECT().GetAllURLs(gomock.Any(), gomock.AssignableToTypeOf("")).Return([]models.URLBase{}, nil).AnyTimes()
+	mock.EXPECT().GetRedirectURL(gomock.Any(), gomock.Any()).Return(models.RedirectResult{URL: urlOriginal1}, nil).AnyTimes()
+	mock.EXPECT().GetAllURLs(gomock.Any(), gomock.AssignableToTypeOf(""), gomock.Any()).Return([]models.URLBase{}, nil).AnyTimes()
 	mock.EXPECT().DeleteURLs(gomock.Any(), gomock.AssignableToTypeOf([]models.URLBase{})).Return(nil).AnyTimes()
 	return mock
 }
@@ -12,6 +12,7 @@ import (
 	"github.com/Di-nis/shortener-url/internal/config"
 	"github.com/Di-nis/shortener-url/internal/constants"
 	"github.com/Di-nis/shortener-url/internal/mocks"
+	"github.com/Di-nis/shortener-url/internal/models"
 )
 
 func getExampleMocks(ctrl *gomock.Controller) *mocks.MockURLUseCase {
@@ -22,8 +23,8 @@ func getExampleMocks(ctrl *gomock.Controller) *mocks.MockURLUseCase {
 	mock.EXPECT().CreateURLOrdinary(gomock.Any(), urlIn3).Return(urlOut3, nil).AnyTimes()
 	mock.EXPECT().CreateURLOrdinary(gomock.Any(), urlIn4).Return(urlOut4, nil).AnyTimes()
 	mock.EXPECT().CreateURLBatch(gomock.Any(), urlsIn1).Return(urlsOut1, nil).AnyTimes()
-	mock.EXPECT().GetOriginalURL(gomock.Any(), urlShort1).Return(urlOriginal1, nil).AnyTimes()
-	mock.EXPECT().GetAllURLs(gomock.Any(), UUID).Return(urlsOut2, nil).AnyTimes()
+	mock.EXPECT().GetRedirectURL(gomock.Any(), gomock.Any()).Return(models.RedirectResult{URL: urlOriginal1}, nil).AnyTimes()
+	mock.EXPECT().GetAllURLs(gomock.Any(), UUID, models.URLFilter{}).Return(urlsOut2, nil).AnyTimes()
 	mock.EXPECT().DeleteURLs(gomock.Any(), urlsIn2).Return(nil).AnyTimes()
 	return mock
 }
@@ -9,6 +9,8 @@ import (
 	"net/http"
 	"net/http/pprof"
 	"reflect"
+	"strconv"
+	"time"
 
 	"github.com/Di-nis/shortener-url/internal/audit"
 	"github.com/Di-nis/shortener-url/internal/authn"
@@ -16,18 +18,28 @@ import (
 	"github.com/Di-nis/shortener-url/internal/compress"
 	"github.com/Di-nis/shortener-url/internal/config"
 	"github.com/Di-nis/shortener-url/internal/constants"
+	"github.com/Di-nis/shortener-url/internal/cors"
+	"github.com/Di-nis/shortener-url/internal/domains"
+	"github.com/Di-nis/shortener-url/internal/limits"
 	"github.com/Di-nis/shortener-url/internal/logger"
 	"github.com/Di-nis/shortener-url/internal/models"
+	"github.com/Di-nis/shortener-url/internal/secure"
 	"github.com/Di-nis/shortener-url/internal/toolkit"
 
 	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
 
 	"context"
-	"time"
 
 	_ "github.com/jackc/pgx/v5/stdlib"
 )
 
+// Параметры cookie с идентификатором посетителя для закрепления адреса назначения.
+const (
+	visitorCookieName   = "visitor_id"
+	visitorCookieMaxAge = 365 * 24 * time.Hour
+)
+
 // Pinger - интерфейс для проверки соединения с БД.
 type Pinger interface {
 	Ping(context.Context) error
@@ -41,8 +53,15 @@ type URLCreator interface {
 
 // URLReader - интерфейс, включащий методы по получению URL.
 type URLReader interface {
-	GetOriginalURL(context.Context, string) (string, error)
-	GetAllURLs(context.Context, string) ([]models.URLBase, error)
+	GetOriginalURL(context.Context, string, string) (string, error)
+	GetRedirectURL(context.Context, models.RedirectRequest) (models.RedirectResult, error)
+	GetAllURLs(context.Context, string, models.URLFilter) ([]models.URLBase, error)
+	LookupURLs(context.Context, string, models.URLLookup) ([]models.URLBase, error)
+}
+
+// URLUpdater - интерфейс, включащий методы по изменению URL.
+type URLUpdater interface {
+	UpdateURL(context.Context, models.URLUpdate) error
 }
 
 // URLDeleter - интерфейс, включащий методы по удалению URL.
@@ -52,7 +71,7 @@ type URLDeleter interface {
 
 // URLStats - интерфейс, включающий методы по получению статистики.
 type URLStats interface {
-	GetStats(context.Context) (int, int, error)
+	GetStats(context.Context, models.StatsFilter) (*models.Stats, error)
 }
 
 // URLUseCase - объединенный интерфейс.
@@ -60,6 +79,7 @@ type URLUseCase interface {
 	Pinger
 	URLCreator
 	URLReader
+	URLUpdater
 	URLDeleter
 	URLStats
 }
@@ -69,8 +89,15 @@ type Controller struct {
 	Pinger     Pinger
 	URLCreator URLCreator
 	URLReader  URLReader
+	URLUpdater URLUpdater
 	URLDeleter URLDeleter
 	URLStats   URLStats
+	// Webhooks - необязательное управление подписками на события, маршруты регистрируются, если задано.
+	Webhooks WebhookManager
+	// Admin - административные операции, маршруты регистрируются, если задано.
+	Admin AdminManager
+	// Domains - обслуживаемые домены и их базовые URL.
+	Domains *domains.Registry
 
 	Config *config.Config
 	Client *audit.Client
@@ -82,8 +109,10 @@ func NewСontroller(urlUseCase URLUseCase, config *config.Config) *Controller {
 		Pinger:     urlUseCase,
 		URLCreator: urlUseCase,
 		URLReader:  urlUseCase,
+		URLUpdater: urlUseCase,
 		URLDeleter: urlUseCase,
 		URLStats:   urlUseCase,
+		Domains:    domains.NewRegistry(config.BaseURL, config.Domains),
 		Config:     config,
 		Client:     audit.NewClient(&http.Client{}, config.AuditURL),
 	}
@@ -93,13 +122,33 @@ func NewСontroller(urlUseCase URLUseCase, config *config.Config) *Controller {
 func (c *Controller) SetupRouter() http.Handler {
 	router := chi.NewRouter()
 
-	router.Use(logger.WithLogging, compress.GzipMiddleware)
+	router.Use(logger.WithLogging)
+	c.UseHeadersMiddleware(router)
+	router.Use(compress.GzipMiddleware)
 	c.UseAuthMiddleware(router)
 
 	c.RegisterRoutes(router)
 	return router
 }
 
+// UseHeadersMiddleware - использование middleware для CORS и заголовков безопасности.
+func (c *Controller) UseHeadersMiddleware(router *chi.Mux) {
+	router.Use(
+		cors.WithCORS(cors.Options{
+			AllowedOrigins:   c.Config.CORSAllowedOrigins,
+			AllowedMethods:   c.Config.CORSAllowedMethods,
+			AllowedHeaders:   c.Config.CORSAllowedHeaders,
+			AllowCredentials: c.Config.CORSAllowCredentials,
+			MaxAge:           c.Config.CORSMaxAge,
+		}),
+		secure.WithSecurityHeaders(secure.Options{
+			EnableHTTPS:    c.Config.EnableHTTPS,
+			HSTSMaxAge:     c.Config.HSTSMaxAge,
+			ReferrerPolicy: c.Config.ReferrerPolicy,
+		}),
+	)
+}
+
 // UseAuthMiddleware - использование middleware для аутентификации.
 func (c *Controller) UseAuthMiddleware(router *chi.Mux) {
 	if c.Config.UseMockAuth {
@@ -111,23 +160,52 @@ func (c *Controller) UseAuthMiddleware(router *chi.Mux) {
 
 // RegisterRoutes - регистрация маршрутов.
 func (c *Controller) RegisterRoutes(router *chi.Mux) {
-	router.Post("/api/shorten/batch", c.CreateURLShortJSONBatch)
-	router.Get("/api/user/urls", c.getAllURLs)
-	router.Delete("/api/user/urls", c.deleteURLs)
-	router.Get("/ping", c.pingDB)
+	// префикс /api выделен в отдельный маршрутизатор, чтобы его пути
+	// не перехватывались перенаправлением с переносом сегментов пути
+	router.Route("/api", func(r chi.Router) {
+		r.With(
+			limits.WithBodyLimit(c.Config.MaxBatchBodySize),
+			limits.WithTimeout(c.Config.BatchTimeout),
+		).Post("/shorten/batch", c.CreateURLShortJSONBatch)
+		r.With(limits.WithTimeout(c.Config.HandlerTimeout)).Get("/user/urls", c.getAllURLs)
+		r.With(limits.WithTimeout(c.Config.HandlerTimeout)).Get("/user/urls/search", c.searchURLs)
+		r.With(
+			limits.WithBodyLimit(c.Config.MaxBodySize),
+			limits.WithTimeout(c.Config.DeleteTimeout),
+		).Delete("/user/urls", c.deleteURLs)
+		r.With(
+			limits.WithBodyLimit(c.Config.MaxBodySize),
+			limits.WithTimeout(c.Config.HandlerTimeout),
+		).Patch("/user/urls/{short_url}", c.updateURL)
+
+		r.Group(func(r chi.Router) {
+			r.Use(cidr.WithCheckCIDR(c.Config.TrustedSubnet, c.Config.UseHeader))
+			r.Use(limits.WithTimeout(c.Config.StatsTimeout))
+
+			r.Get("/internal/stats", c.stats)
+		})
 
-	router.Group(func(r chi.Router) {
-		r.Use(cidr.WithCheckCIDR(c.Config.TrustedSubnet, c.Config.UseHeader))
+		r.Group(func(r chi.Router) {
+			c.useAuditMiddlewares(r)
 
-		r.Get("/api/internal/stats", c.stats)
+			r.Post("/shorten", c.createURLShortJSON)
+		})
+
+		if c.Webhooks != nil {
+			c.registerWebhookRoutes(r)
+		}
+		if c.Admin != nil {
+			c.registerAdminRoutes(r)
+		}
 	})
+	router.With(limits.WithTimeout(c.Config.HandlerTimeout)).Get("/ping", c.pingDB)
 
 	router.Group(func(r chi.Router) {
-		r.Use(audit.WithAudit(c.Client, c.Config.AuditFile))
+		c.useAuditMiddlewares(r)
 
 		r.Post("/", c.createURLShortText)
-		r.Post("/api/shorten", c.createURLShortJSON)
 		r.Get("/{short_url}", c.getURLOriginal)
+		r.Get("/{short_url}/*", c.getURLOriginal)
 	})
 
 	// pprof
@@ -138,10 +216,17 @@ func (c *Controller) RegisterRoutes(router *chi.Mux) {
 	router.Get("/debug/pprof/trace", pprof.Trace)
 }
 
+// useAuditMiddlewares - использование middleware для аудита создания и перехода по URL.
+func (c *Controller) useAuditMiddlewares(r chi.Router) {
+	// ограничение тела запроса устанавливается до аудита, который читает тело запроса
+	r.Use(limits.WithBodyLimit(c.Config.MaxBodySize))
+	r.Use(audit.WithAudit(c.Client, c.Config.AuditFile))
+	r.Use(limits.WithTimeout(c.Config.HandlerTimeout))
+}
+
 // CreateURLShortJSONBatch - обрабатка HTTP-запроса: тип запроcа - POST, вовзвращает короткий URL.
 func (c *Controller) CreateURLShortJSONBatch(res http.ResponseWriter, req *http.Request) {
-	ctx, cancel := context.WithTimeout(req.Context(), 3*time.Second)
-	defer cancel()
+	ctx := req.Context()
 
 	if req.Method != http.MethodPost {
 		res.WriteHeader(http.StatusMethodNotAllowed)
@@ -150,7 +235,7 @@ func (c *Controller) CreateURLShortJSONBatch(res http.ResponseWriter, req *http.
 
 	bodyBytes, err := io.ReadAll(req.Body)
 	if err != nil {
-		http.Error(res, constants.ReadRequestError, http.StatusBadRequest)
+		writeStatusReadBody(res, err)
 		return
 	}
 	defer req.Body.Close()
@@ -172,13 +257,17 @@ func (c *Controller) CreateURLShortJSONBatch(res http.ResponseWriter, req *http.
 
 	for i := range urls {
 		urls[i].UUID = userID
+		if urls[i].Domain, err = c.requestDomain(req, urls[i].Domain); err != nil {
+			http.Error(res, err.Error(), http.StatusBadRequest)
+			return
+		}
 	}
 
 	createdURLs, err := c.URLCreator.CreateURLBatch(ctx, urls)
 
 	// Добавление базового URL
 	for i := range createdURLs {
-		createdURLs[i].Short = toolkit.AddBaseURLToResponse(c.Config.BaseURL, createdURLs[i].Short)
+		createdURLs[i].Short = c.shortURL(createdURLs[i])
 	}
 
 	bodyResult, marshalErr := json.Marshal(createdURLs)
@@ -198,15 +287,18 @@ func (c *Controller) CreateURLShortJSONBatch(res http.ResponseWriter, req *http.
 
 // createURLShortJSON - обрабатка HTTP-запроса: тип запроcа - POST, вовзвращает короткий URL.
 func (c *Controller) createURLShortJSON(res http.ResponseWriter, req *http.Request) {
-	ctx, cancel := context.WithTimeout(req.Context(), 3*time.Second)
-	defer cancel()
+	ctx := req.Context()
 
 	if req.Method != http.MethodPost {
 		res.WriteHeader(http.StatusMethodNotAllowed)
 		return
 	}
 
-	bodyBytes, _ := io.ReadAll(req.Body)
+	bodyBytes, err := io.ReadAll(req.Body)
+	if err != nil {
+		writeStatusReadBody(res, err)
+		return
+	}
 	if reflect.DeepEqual(bodyBytes, []byte{}) {
 		http.Error(res, constants.EmptyBodyError, http.StatusBadRequest)
 		return
@@ -228,10 +320,14 @@ func (c *Controller) createURLShortJSON(res http.ResponseWriter, req *http.Reque
 	}
 
 	urlInOut.UUID = userID
+	if urlInOut.Domain, err = c.requestDomain(req, urlInOut.Domain); err != nil {
+		http.Error(res, err.Error(), http.StatusBadRequest)
+		return
+	}
 
-	url, err := c.URLCreator.CreateURLOrdinary(ctx, urlInOut)
+	url, err = c.URLCreator.CreateURLOrdinary(ctx, urlInOut)
 
-	url.Short = toolkit.AddBaseURLToResponse(c.Config.BaseURL, url.Short)
+	url.Short = c.shortURL(url)
 	urlInOut = models.URLJSON(url)
 
 	bodyResult, marshalErr := json.Marshal(urlInOut)
@@ -251,15 +347,18 @@ func (c *Controller) createURLShortJSON(res http.ResponseWriter, req *http.Reque
 
 // createURLShortText - обрабатка HTTP-запроса: тип запроcа - POST, вовзвращает короткий URL.
 func (c *Controller) createURLShortText(res http.ResponseWriter, req *http.Request) {
-	ctx, cancel := context.WithTimeout(req.Context(), 3*time.Second)
-	defer cancel()
+	ctx := req.Context()
 
 	if req.Method != http.MethodPost {
 		res.WriteHeader(http.StatusMethodNotAllowed)
 		return
 	}
 
-	bodyBytes, _ := io.ReadAll(req.Body)
+	bodyBytes, err := io.ReadAll(req.Body)
+	if err != nil {
+		writeStatusReadBody(res, err)
+		return
+	}
 	if reflect.DeepEqual(bodyBytes, []byte{}) {
 		http.Error(res, constants.EmptyBodyError, http.StatusBadRequest)
 		return
@@ -274,10 +373,14 @@ func (c *Controller) createURLShortText(res http.ResponseWriter, req *http.Reque
 		Original: string(bodyBytes),
 		UUID:     userID,
 	}
+	if urlIn.Domain, err = c.requestDomain(req, req.URL.Query().Get("domain")); err != nil {
+		http.Error(res, err.Error(), http.StatusBadRequest)
+		return
+	}
 
 	urlOut, err := c.URLCreator.CreateURLOrdinary(ctx, urlIn)
 
-	urlOut.Short = toolkit.AddBaseURLToResponse(c.Config.BaseURL, urlOut.Short)
+	urlOut.Short = c.shortURL(urlOut)
 
 	res.Header().Set("Content-Type", "text/plain")
 	writeStatusCreate(res, err)
@@ -288,10 +391,23 @@ func (c *Controller) createURLShortText(res http.ResponseWriter, req *http.Reque
 	}
 }
 
+// requestDomain - домен создаваемой или изменяемой ссылки: выбранный явно
+// или, если не выбран, определенный по заголовку Host запроса.
+func (c *Controller) requestDomain(req *http.Request, domain string) (string, error) {
+	if domain != "" {
+		return c.Domains.Resolve(domain)
+	}
+	return c.Domains.FromHost(req.Host), nil
+}
+
+// shortURL - короткий URL с базовым URL домена ссылки.
+func (c *Controller) shortURL(url models.URLBase) string {
+	return toolkit.AddBaseURLToResponse(c.Domains.BaseURL(url.Domain), url.Short)
+}
+
 // getAllURLs - получение всех когда-либо сокращенных пользователем URL.
 func (c *Controller) getAllURLs(res http.ResponseWriter, req *http.Request) {
-	ctx, cancel := context.WithTimeout(req.Context(), 3*time.Second)
-	defer cancel()
+	ctx := req.Context()
 
 	if req.Method != http.MethodGet {
 		res.WriteHeader(http.StatusMethodNotAllowed)
@@ -303,12 +419,61 @@ func (c *Controller) getAllURLs(res http.ResponseWriter, req *http.Request) {
 	var err error
 	userID := req.Context().Value(constants.UserIDKey).(string)
 
-	urls, err := c.URLReader.GetAllURLs(ctx, userID)
+	filter := models.URLFilter{
+		Tag:    req.URL.Query().Get("tag"),
+		Folder: req.URL.Query().Get("folder"),
+	}
+
+	urls, err := c.URLReader.GetAllURLs(ctx, userID, filter)
+	if err != nil {
+		if limits.IsTimeout(err) {
+			http.Error(res, constants.TimeoutError, http.StatusServiceUnavailable)
+			return
+		}
+		http.Error(res, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	c.writeUserURLs(res, urls)
+}
+
+// searchURLs - поиск URL пользователя по оригинальному URL, хосту, префиксу или подстроке.
+func (c *Controller) searchURLs(res http.ResponseWriter, req *http.Request) {
+	ctx := req.Context()
+	userID := ctx.Value(constants.UserIDKey).(string)
+
+	lookup := models.URLLookup{
+		Mode:  req.URL.Query().Get("mode"),
+		Query: req.URL.Query().Get("q"),
+	}
+	if value := req.URL.Query().Get("limit"); value != "" {
+		limit, err := strconv.Atoi(value)
+		if err != nil || limit <= 0 {
+			http.Error(res, "invalid limit", http.StatusBadRequest)
+			return
+		}
+		lookup.Limit = limit
+	}
+
+	urls, err := c.URLReader.LookupURLs(ctx, userID, lookup)
 	if err != nil {
+		if errors.Is(err, constants.ErrorInvalidLookup) {
+			http.Error(res, err.Error(), http.StatusBadRequest)
+			return
+		}
+		if limits.IsTimeout(err) {
+			http.Error(res, constants.TimeoutError, http.StatusServiceUnavailable)
+			return
+		}
 		http.Error(res, err.Error(), http.StatusInternalServerError)
 		return
 	}
 
+	c.writeUserURLs(res, urls)
+}
+
+// writeUserURLs - запись списка URL пользователя в ответ, для пустого списка - 204.
+func (c *Controller) writeUserURLs(res http.ResponseWriter, urls []models.URLBase) {
 	var (
 		urlsOut []models.URLGetAll
 		urlOut  models.URLGetAll
@@ -316,7 +481,7 @@ func (c *Controller) getAllURLs(res http.ResponseWriter, req *http.Request) {
 
 	for _, url := range urls {
 		urlOut = models.URLGetAll(url)
-		urlOut.Short = toolkit.AddBaseURLToResponse(c.Config.BaseURL, urlOut.Short)
+		urlOut.Short = c.shortURL(url)
 		urlsOut = append(urlsOut, urlOut)
 	}
 
@@ -340,44 +505,134 @@ func (c *Controller) getAllURLs(res http.ResponseWriter, req *http.Request) {
 	}
 }
 
+// updateURL - изменение тегов, папки, заголовка и описания сокращенного URL.
+func (c *Controller) updateURL(res http.ResponseWriter, req *http.Request) {
+	ctx := req.Context()
+
+	if req.Method != http.MethodPatch {
+		res.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+
+	bodyBytes, err := io.ReadAll(req.Body)
+	if err != nil {
+		writeStatusReadBody(res, err)
+		return
+	}
+	defer req.Body.Close()
+
+	if len(bodyBytes) == 0 {
+		http.Error(res, constants.EmptyBodyError, http.StatusBadRequest)
+		return
+	}
+
+	var update models.URLUpdate
+	if err := json.Unmarshal(bodyBytes, &update); err != nil {
+		http.Error(res, constants.InvalidJSONError, http.StatusBadRequest)
+		return
+	}
+
+	// Получение userID через middleware Auth
+	update.UUID = req.Context().Value(constants.UserIDKey).(string)
+	update.Short = chi.URLParam(req, "short_url")
+	if update.Domain, err = c.requestDomain(req, req.URL.Query().Get("domain")); err != nil {
+		http.Error(res, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	err = c.URLUpdater.UpdateURL(ctx, update)
+	writeStatusUpdate(res, err)
+}
+
 // getURLOriginal - обрабатка HTTP-запроса: тип запроcа - GET, возвращает оригинальный URL.
 func (c *Controller) getURLOriginal(res http.ResponseWriter, req *http.Request) {
-	ctx, cancel := context.WithTimeout(req.Context(), 3*time.Second)
-	defer cancel()
+	ctx := req.Context()
 
 	if req.Method != http.MethodGet {
 		res.WriteHeader(http.StatusMethodNotAllowed)
 		return
 	}
 
-	URLShort := chi.URLParam(req, "short_url")
+	visitorID, isNewVisitor := getVisitorID(req)
+	redirectReq := models.RedirectRequest{
+		Domain:    c.Domains.FromHost(req.Host),
+		Short:     chi.URLParam(req, "short_url"),
+		RawQuery:  req.URL.RawQuery,
+		ExtraPath: chi.URLParam(req, "*"),
+		VisitorID: visitorID,
+
+		UserAgent:      req.UserAgent(),
+		AcceptLanguage: req.Header.Get("Accept-Language"),
+	}
 	defer req.Body.Close()
 
-	urlOriginal, err := c.URLReader.GetOriginalURL(ctx, URLShort)
+	redirectRes, err := c.URLReader.GetRedirectURL(ctx, redirectReq)
 	if err != nil {
 		if errors.Is(err, constants.ErrorURLNotExist) {
 			res.WriteHeader(http.StatusNotFound)
 			return
 		}
-		if errors.Is(err, constants.ErrorURLAlreadyDeleted) {
+		if errors.Is(err, constants.ErrorURLNotYetActive) {
+			// ответ изменится после активации ссылки, поэтому не кэшируется
+			res.Header().Set("Cache-Control", "no-store")
+			if c.Config.HoldingPageURL != "" {
+				res.Header().Set("Location", c.Config.HoldingPageURL)
+				res.WriteHeader(http.StatusTemporaryRedirect)
+				return
+			}
+			res.WriteHeader(http.StatusNotFound)
+			return
+		}
+		if errors.Is(err, constants.ErrorURLAlreadyDeleted) || errors.Is(err, constants.ErrorURLClicksExhausted) {
 			res.WriteHeader(http.StatusGone)
 			return
 		}
+		if errors.Is(err, constants.ErrorURLDisabled) {
+			res.WriteHeader(http.StatusForbidden)
+			return
+		}
+		if limits.IsTimeout(err) {
+			res.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
 
 	}
 	if err != nil {
 		res.WriteHeader(http.StatusBadRequest)
 		return
 	}
-	res.Header().Add("Location", urlOriginal)
+	if redirectRes.Sticky && isNewVisitor {
+		http.SetCookie(res, &http.Cookie{
+			Name:     visitorCookieName,
+			Value:    visitorID,
+			Path:     "/",
+			MaxAge:   int(visitorCookieMaxAge.Seconds()),
+			HttpOnly: true,
+			SameSite: http.SameSiteLaxMode,
+			Secure:   c.Config.EnableHTTPS,
+		})
+	}
+	if redirectRes.Targeted {
+		res.Header().Add("Vary", "User-Agent, Accept-Language")
+	}
+	res.Header().Add("Location", redirectRes.URL)
 	res.Header().Set("Content-Type", "text/plain")
 	res.WriteHeader(http.StatusTemporaryRedirect)
 }
 
+// getVisitorID - получение идентификатора посетителя из cookie
+// или создание нового, если cookie отсутствует.
+func getVisitorID(req *http.Request) (string, bool) {
+	cookie, err := req.Cookie(visitorCookieName)
+	if err == nil && cookie.Value != "" {
+		return cookie.Value, false
+	}
+	return uuid.NewString(), true
+}
+
 // deleteURLs - удаление сокращенных URL.
 func (c *Controller) deleteURLs(res http.ResponseWriter, req *http.Request) {
-	ctx, cancel := context.WithCancel(context.Background())
-	defer cancel()
+	ctx := req.Context()
 
 	if req.Method != http.MethodDelete {
 		res.WriteHeader(http.StatusMethodNotAllowed)
@@ -391,20 +646,35 @@ func (c *Controller) deleteURLs(res http.ResponseWriter, req *http.Request) {
 	urls := []models.URLBase{}
 
 	if err := json.NewDecoder(req.Body).Decode(&shorts); err != nil {
+		if limits.IsBodyTooLarge(err) {
+			http.Error(res, constants.RequestTooLargeError, http.StatusRequestEntityTooLarge)
+			return
+		}
 		http.Error(res, err.Error(), http.StatusBadRequest)
 		return
 	}
 
 	defer req.Body.Close()
 
+	domain, err := c.requestDomain(req, req.URL.Query().Get("domain"))
+	if err != nil {
+		http.Error(res, err.Error(), http.StatusBadRequest)
+		return
+	}
+
 	for _, short := range shorts {
 		urls = append(urls, models.URLBase{
-			Short: short,
-			UUID:  userID,
+			Short:  short,
+			Domain: domain,
+			UUID:   userID,
 		})
 	}
 
 	if err := c.URLDeleter.DeleteURLs(ctx, urls); err != nil {
+		if limits.IsTimeout(err) {
+			res.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
 		res.WriteHeader(http.StatusInternalServerError)
 		return
 	}
@@ -413,30 +683,81 @@ func (c *Controller) deleteURLs(res http.ResponseWriter, req *http.Request) {
 
 // pingDB - пинг БД.
 func (c *Controller) pingDB(res http.ResponseWriter, req *http.Request) {
-	ctx, cancel := context.WithTimeout(req.Context(), 3*time.Second)
-	defer cancel()
+	ctx := req.Context()
 
 	err := c.Pinger.Ping(ctx)
 	writeStatusCodePing(res, err)
 }
 
+// parseStatsTime - разбор границы периода статистики в формате RFC 3339 или YYYY-MM-DD.
+// Дата без времени в качестве конца периода включает весь указанный день.
+func parseStatsTime(value string, end bool) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+	t, err := time.Parse(time.DateOnly, value)
+	if err != nil {
+		return time.Time{}, constants.ErrorInvalidStatsRange
+	}
+	if end {
+		t = t.AddDate(0, 0, 1)
+	}
+	return t, nil
+}
+
+// parseStatsFilter - разбор параметров from, to и top запроса статистики.
+func parseStatsFilter(req *http.Request) (models.StatsFilter, error) {
+	var (
+		filter models.StatsFilter
+		err    error
+	)
+	query := req.URL.Query()
+
+	if filter.From, err = parseStatsTime(query.Get("from"), false); err != nil {
+		return filter, err
+	}
+	if filter.To, err = parseStatsTime(query.Get("to"), true); err != nil {
+		return filter, err
+	}
+	if top := query.Get("top"); top != "" {
+		if filter.Top, err = strconv.Atoi(top); err != nil || filter.Top <= 0 {
+			return filter, constants.ErrorInvalidStatsRange
+		}
+	}
+	return filter, nil
+}
+
 // stats - получение статистики по сокращенным URL.
 func (c *Controller) stats(res http.ResponseWriter, req *http.Request) {
-	ctx, cancel := context.WithCancel(context.Background())
-	defer cancel()
+	ctx := req.Context()
 
 	if req.Method != http.MethodGet {
 		res.WriteHeader(http.StatusMethodNotAllowed)
 		return
 	}
 
-	countURLs, countUsers, err := c.URLStats.GetStats(ctx)
+	filter, err := parseStatsFilter(req)
 	if err != nil {
-		res.WriteHeader(http.StatusInternalServerError)
+		http.Error(res, err.Error(), http.StatusBadRequest)
 		return
 	}
 
-	stats := models.NewStats(countURLs, countUsers)
+	stats, err := c.URLStats.GetStats(ctx, filter)
+	if err != nil {
+		if errors.Is(err, constants.ErrorInvalidStatsRange) {
+			http.Error(res, err.Error(), http.StatusBadRequest)
+			return
+		}
+		if limits.IsTimeout(err) {
+			res.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		res.WriteHeader(http.StatusInternalServerError)
+		return
+	}
 
 	bodyResult, err := json.Marshal(stats)
 	if err != nil {
@@ -1,16 +1,20 @@
 package handler
 
 import (
+	"encoding/json"
 	"fmt"
+	"io"
 	"log"
 	"net/http"
 	"net/http/httptest"
 	"os"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/Di-nis/shortener-url/internal/config"
 	"github.com/Di-nis/shortener-url/internal/constants"
+	"github.com/Di-nis/shortener-url/internal/models"
 	"github.com/Di-nis/shortener-url/internal/repository"
 	"github.com/Di-nis/shortener-url/internal/service"
 	"github.com/Di-nis/shortener-url/internal/storage"
@@ -213,6 +217,19 @@ func TestController_CreateURLFromText(t *testing.T) {
 				contentType: "text/plain; charset=utf-8",
 			},
 		},
+		{
+			name:            "POST, размер тела запроса превышает допустимый",
+			body:            strings.Repeat("a", 2<<20),
+			method:          http.MethodPost,
+			contentType:     "text/plain",
+			contentEncoding: "",
+			acceptEncoding:  "",
+			want: want{
+				statusCode:  http.StatusRequestEntityTooLarge,
+				body:        fmt.Sprintf("%s%s", constants.RequestTooLargeError, "\n"),
+				contentType: "text/plain; charset=utf-8",
+			},
+		},
 	}
 
 	for _, tt := range tests {
@@ -498,6 +515,513 @@ func TestController_GetAllURLs(t *testing.T) {
 	}
 }
 
+func TestController_searchURLs(t *testing.T) {
+	var cookies []*http.Cookie
+
+	t.Run("Предварительное создание данных", func(t *testing.T) {
+		req := resty.New().R()
+		req.Method = http.MethodPost
+		req.Body = "https://Search.Example.com/news"
+		req.URL = testServer.URL
+
+		resp, err := req.Send()
+		if err != nil {
+			assert.True(t, strings.Contains(err.Error(), "auto redirect is disabled"))
+		}
+		cookies = resp.Cookies()
+	})
+
+	type want struct {
+		statusCode int
+		body       string
+	}
+
+	tests := []struct {
+		name    string
+		query   string
+		cookies []*http.Cookie
+		want    want
+	}{
+		{
+			name:    "поиск по хосту",
+			query:   "?mode=host&q=example.com",
+			cookies: cookies,
+			want: want{
+				statusCode: http.StatusOK,
+				body:       "https://Search.Example.com/news",
+			},
+		},
+		{
+			name:    "поиск по подстроке без совпадений",
+			query:   "?mode=contains&q=sports",
+			cookies: cookies,
+			want: want{
+				statusCode: http.StatusNoContent,
+			},
+		},
+		{
+			name:    "неизвестный способ поиска",
+			query:   "?mode=regexp&q=example",
+			cookies: cookies,
+			want: want{
+				statusCode: http.StatusBadRequest,
+			},
+		},
+		{
+			name:    "некорректное ограничение числа URL",
+			query:   "?mode=prefix&q=https&limit=abc",
+			cookies: cookies,
+			want: want{
+				statusCode: http.StatusBadRequest,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := resty.New().R()
+			req.Method = http.MethodGet
+			req.URL = testServer.URL + "/api/user/urls/search" + tt.query
+			req.Cookies = tt.cookies
+
+			resp, err := req.Send()
+			require.NoError(t, err)
+
+			assert.Equal(t, tt.want.statusCode, resp.StatusCode())
+			if tt.want.body != "" {
+				assert.Contains(t, string(resp.Body()), tt.want.body)
+			}
+		})
+	}
+}
+
+func TestController_updateURL(t *testing.T) {
+	var cookies []*http.Cookie
+
+	t.Run("Предварительное создание данных", func(t *testing.T) {
+		req := resty.New().R()
+		req.Method = http.MethodPost
+		req.Body = `{"url":"https://www.championat.com/","tags":["sport"],"folder":"news"}`
+		req.URL = testServer.URL + "/api/shorten"
+
+		resp, err := req.Send()
+		require.NoError(t, err, "error making HTTP request")
+		require.Equal(t, http.StatusCreated, resp.StatusCode())
+		cookies = resp.Cookies()
+	})
+
+	type want struct {
+		statusCode int
+		body       string
+	}
+
+	tests := []struct {
+		name   string
+		method string
+		url    string
+		body   string
+		want   want
+	}{
+		{
+			name:   "изменение тегов и папки",
+			method: http.MethodPatch,
+			url:    "/api/user/urls/lFtQbpHB",
+			body:   `{"tags":["sport","football"," "],"folder":"media"}`,
+			want: want{
+				statusCode: http.StatusNoContent,
+			},
+		},
+		{
+			name:   "отбор по тегу",
+			method: http.MethodGet,
+			url:    "/api/user/urls?tag=football",
+			want: want{
+				statusCode: http.StatusOK,
+				body:       `[{"short_url":"http://localhost:8080/lFtQbpHB","original_url":"https://www.championat.com/","tags":["sport","football"],"folder":"media"}]`,
+			},
+		},
+		{
+			name:   "отбор по отсутствующему тегу",
+			method: http.MethodGet,
+			url:    "/api/user/urls?tag=tennis",
+			want: want{
+				statusCode: http.StatusNoContent,
+			},
+		},
+		{
+			name:   "изменение несуществующего URL",
+			method: http.MethodPatch,
+			url:    "/api/user/urls/nvjkrhsf",
+			body:   `{"tags":["sport"]}`,
+			want: want{
+				statusCode: http.StatusNotFound,
+			},
+		},
+		{
+			name:   "невалидное тело запроса",
+			method: http.MethodPatch,
+			url:    "/api/user/urls/lFtQbpHB",
+			body:   `{"tags":"sport"}`,
+			want: want{
+				statusCode: http.StatusBadRequest,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := resty.New().R()
+			req.Method = tt.method
+			req.Body = tt.body
+			req.URL = testServer.URL + tt.url
+			req.Cookies = cookies
+
+			resp, err := req.Send()
+			require.NoError(t, err, "error making HTTP request")
+
+			assert.Equal(t, tt.want.statusCode, resp.StatusCode())
+			if tt.want.body != "" {
+				assert.Equal(t, tt.want.body, string(resp.Body()))
+			}
+		})
+	}
+}
+
+func TestController_getURLOriginalPassThrough(t *testing.T) {
+	var (
+		cookies []*http.Cookie
+		short   string
+	)
+
+	t.Run("Предварительное создание данных", func(t *testing.T) {
+		req := resty.New().R()
+		req.Method = http.MethodPost
+		req.Body = `{"url":"https://www.kinopoisk.ru/lists?ref=partner","pass_query":true,"pass_path":true}`
+		req.URL = testServer.URL + "/api/shorten"
+
+		var result struct {
+			Result string `json:"result"`
+		}
+		req.SetResult(&result)
+
+		resp, err := req.Send()
+		require.NoError(t, err, "error making HTTP request")
+		require.Equal(t, http.StatusCreated, resp.StatusCode())
+		cookies = resp.Cookies()
+		short = strings.TrimPrefix(result.Result, "http://localhost:8080/")
+	})
+
+	tests := []struct {
+		name         string
+		path         string
+		wantStatus   int
+		wantLocation string
+	}{
+		{
+			name:         "перенос параметров запроса",
+			path:         "?utm_source=telegram&ref=spam",
+			wantStatus:   http.StatusTemporaryRedirect,
+			wantLocation: "https://www.kinopoisk.ru/lists?ref=partner&utm_source=telegram",
+		},
+		{
+			name:         "перенос сегментов пути и параметров запроса",
+			path:         "/top250/?utm_source=telegram",
+			wantStatus:   http.StatusTemporaryRedirect,
+			wantLocation: "https://www.kinopoisk.ru/lists/top250/?ref=partner&utm_source=telegram",
+		},
+		{
+			name:         "без параметров запроса",
+			path:         "",
+			wantStatus:   http.StatusTemporaryRedirect,
+			wantLocation: "https://www.kinopoisk.ru/lists?ref=partner",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := resty.New().SetRedirectPolicy(resty.NoRedirectPolicy())
+			req := client.R()
+			req.Method = http.MethodGet
+			req.Cookies = cookies
+			req.URL = testServer.URL + "/" + short + tt.path
+
+			resp, err := req.Send()
+			if err != nil {
+				assert.True(t, strings.Contains(err.Error(), "auto redirect is disabled"))
+			}
+
+			assert.Equal(t, tt.wantStatus, resp.StatusCode())
+			assert.Equal(t, tt.wantLocation, resp.Header().Get("Location"))
+		})
+	}
+}
+
+func TestController_getURLOriginalDestinations(t *testing.T) {
+	var (
+		cookies []*http.Cookie
+		short   string
+	)
+
+	t.Run("Предварительное создание данных", func(t *testing.T) {
+		req := resty.New().R()
+		req.Method = http.MethodPost
+		req.Body = `{"url":"https://www.ivi.ru/","destinations":[{"url":"https://www.ivi.ru/landing-a","weight":80},{"url":"https://www.ivi.ru/landing-b","weight":20}]}`
+		req.URL = testServer.URL + "/api/shorten"
+
+		var result struct {
+			Result string `json:"result"`
+		}
+		req.SetResult(&result)
+
+		resp, err := req.Send()
+		require.NoError(t, err, "error making HTTP request")
+		require.Equal(t, http.StatusCreated, resp.StatusCode())
+		cookies = resp.Cookies()
+		short = strings.TrimPrefix(result.Result, "http://localhost:8080/")
+	})
+
+	client := resty.New().SetRedirectPolicy(resty.NoRedirectPolicy())
+
+	var (
+		visitorCookie *http.Cookie
+		location      string
+	)
+
+	t.Run("первый переход закрепляет адрес за посетителем", func(t *testing.T) {
+		req := client.R()
+		req.Method = http.MethodGet
+		req.Cookies = cookies
+		req.URL = testServer.URL + "/" + short
+
+		resp, _ := req.Send()
+		require.Equal(t, http.StatusTemporaryRedirect, resp.StatusCode())
+
+		location = resp.Header().Get("Location")
+		assert.Contains(t, []string{"https://www.ivi.ru/landing-a", "https://www.ivi.ru/landing-b"}, location)
+
+		for _, cookie := range resp.Cookies() {
+			if cookie.Name == visitorCookieName {
+				visitorCookie = cookie
+			}
+		}
+		require.NotNil(t, visitorCookie, "visitor cookie is not set")
+	})
+
+	t.Run("повторные переходы ведут на тот же адрес", func(t *testing.T) {
+		for range 5 {
+			req := client.R()
+			req.Method = http.MethodGet
+			req.Cookies = append(cookies, visitorCookie)
+			req.URL = testServer.URL + "/" + short
+
+			resp, _ := req.Send()
+			require.Equal(t, http.StatusTemporaryRedirect, resp.StatusCode())
+			assert.Equal(t, location, resp.Header().Get("Location"))
+		}
+	})
+
+	t.Run("переходы учитываются по адресам назначения", func(t *testing.T) {
+		req := resty.New().R()
+		req.Method = http.MethodGet
+		req.Cookies = cookies
+		req.URL = testServer.URL + "/api/user/urls"
+
+		var urls []models.URLGetAll
+		req.SetResult(&urls)
+
+		resp, err := req.Send()
+		require.NoError(t, err, "error making HTTP request")
+		require.Equal(t, http.StatusOK, resp.StatusCode())
+		require.Len(t, urls, 1)
+		require.Len(t, urls[0].Destinations, 2)
+
+		var clicks int64
+		for _, destination := range urls[0].Destinations {
+			if destination.URL == location {
+				clicks = destination.Clicks
+			}
+		}
+		assert.Equal(t, int64(6), clicks)
+	})
+}
+
+func TestController_getURLOriginalNotBefore(t *testing.T) {
+	var shorts []string
+
+	t.Run("Предварительное создание данных", func(t *testing.T) {
+		for _, body := range []string{
+			`{"url":"https://www.kinopoisk.ru/","not_before":"2999-01-01T00:00:00+03:00"}`,
+			`{"url":"https://www.kinopoisk.ru/media/","not_before":"2020-01-01T00:00:00Z"}`,
+		} {
+			req := resty.New().R()
+			req.Method = http.MethodPost
+			req.Body = body
+			req.URL = testServer.URL + "/api/shorten"
+
+			var result struct {
+				Result string `json:"result"`
+			}
+			req.SetResult(&result)
+
+			resp, err := req.Send()
+			require.NoError(t, err, "error making HTTP request")
+			require.Equal(t, http.StatusCreated, resp.StatusCode())
+			shorts = append(shorts, strings.TrimPrefix(result.Result, "http://localhost:8080/"))
+		}
+	})
+
+	client := resty.New().SetRedirectPolicy(resty.NoRedirectPolicy())
+
+	t.Run("время активации не наступило", func(t *testing.T) {
+		req := client.R()
+		req.Method = http.MethodGet
+		req.URL = testServer.URL + "/" + shorts[0]
+
+		resp, _ := req.Send()
+		assert.Equal(t, http.StatusNotFound, resp.StatusCode())
+		assert.Equal(t, "no-store", resp.Header().Get("Cache-Control"))
+	})
+
+	t.Run("время активации наступило", func(t *testing.T) {
+		req := client.R()
+		req.Method = http.MethodGet
+		req.URL = testServer.URL + "/" + shorts[1]
+
+		resp, _ := req.Send()
+		assert.Equal(t, http.StatusTemporaryRedirect, resp.StatusCode())
+		assert.Equal(t, "https://www.kinopoisk.ru/media/", resp.Header().Get("Location"))
+	})
+}
+
+func TestController_getURLOriginalMaxClicks(t *testing.T) {
+	var short string
+
+	t.Run("Предварительное создание данных", func(t *testing.T) {
+		req := resty.New().R()
+		req.Method = http.MethodPost
+		req.Body = `{"url":"https://www.rutube.ru/download/","max_clicks":1}`
+		req.URL = testServer.URL + "/api/shorten"
+
+		var result struct {
+			Result string `json:"result"`
+		}
+		req.SetResult(&result)
+
+		resp, err := req.Send()
+		require.NoError(t, err, "error making HTTP request")
+		require.Equal(t, http.StatusCreated, resp.StatusCode())
+		short = strings.TrimPrefix(result.Result, "http://localhost:8080/")
+	})
+
+	client := resty.New().SetRedirectPolicy(resty.NoRedirectPolicy())
+
+	tests := []struct {
+		name       string
+		statusCode int
+	}{
+		{
+			name:       "первый переход",
+			statusCode: http.StatusTemporaryRedirect,
+		},
+		{
+			name:       "лимит переходов исчерпан",
+			statusCode: http.StatusGone,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := client.R()
+			req.Method = http.MethodGet
+			req.URL = testServer.URL + "/" + short
+
+			resp, _ := req.Send()
+			assert.Equal(t, tt.statusCode, resp.StatusCode())
+		})
+	}
+}
+
+func TestController_getURLOriginalRules(t *testing.T) {
+	var (
+		cookies []*http.Cookie
+		short   string
+	)
+
+	t.Run("Предварительное создание данных", func(t *testing.T) {
+		req := resty.New().R()
+		req.Method = http.MethodPost
+		req.Body = `{"url":"https://www.okko.tv/","rules":[` +
+			`{"platform":"ios","url":"https://apps.apple.com/ru/app/okko/id1"},` +
+			`{"platform":"Android","url":"https://play.google.com/store/apps/details?id=ru.okko"},` +
+			`{"platform":"tv","url":"https://www.okko.tv/tv"}]}`
+		req.URL = testServer.URL + "/api/shorten"
+
+		var result struct {
+			Result string `json:"result"`
+		}
+		req.SetResult(&result)
+
+		resp, err := req.Send()
+		require.NoError(t, err, "error making HTTP request")
+		require.Equal(t, http.StatusCreated, resp.StatusCode())
+		cookies = resp.Cookies()
+		short = strings.TrimPrefix(result.Result, "http://localhost:8080/")
+	})
+
+	client := resty.New().SetRedirectPolicy(resty.NoRedirectPolicy())
+
+	tests := []struct {
+		name      string
+		userAgent string
+		want      string
+	}{
+		{
+			name:      "iOS",
+			userAgent: "Mozilla/5.0 (iPhone; CPU iPhone OS 17_5 like Mac OS X) AppleWebKit/605.1.15 Mobile/15E148",
+			want:      "https://apps.apple.com/ru/app/okko/id1",
+		},
+		{
+			name:      "Android",
+			userAgent: "Mozilla/5.0 (Linux; Android 14; Pixel 8) AppleWebKit/537.36 Mobile Safari/537.36",
+			want:      "https://play.google.com/store/apps/details?id=ru.okko",
+		},
+		{
+			name:      "компьютер",
+			userAgent: "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 Chrome/126.0 Safari/537.36",
+			want:      "https://www.okko.tv/",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := client.R()
+			req.Method = http.MethodGet
+			req.SetHeader("User-Agent", tt.userAgent)
+			req.URL = testServer.URL + "/" + short
+
+			resp, _ := req.Send()
+			require.Equal(t, http.StatusTemporaryRedirect, resp.StatusCode())
+			assert.Equal(t, tt.want, resp.Header().Get("Location"))
+			assert.Equal(t, "User-Agent, Accept-Language", resp.Header().Get("Vary"))
+		})
+	}
+
+	t.Run("правила возвращаются в списке URL пользователя", func(t *testing.T) {
+		req := resty.New().R()
+		req.Method = http.MethodGet
+		req.Cookies = cookies
+		req.URL = testServer.URL + "/api/user/urls"
+
+		var urls []models.URLGetAll
+		req.SetResult(&urls)
+
+		resp, err := req.Send()
+		require.NoError(t, err, "error making HTTP request")
+		require.Equal(t, http.StatusOK, resp.StatusCode())
+		require.Len(t, urls, 1)
+		assert.Equal(t, []models.TargetRule{
+			{Platform: "ios", URL: "https://apps.apple.com/ru/app/okko/id1"},
+			{Platform: "android", URL: "https://play.google.com/store/apps/details?id=ru.okko"},
+		}, urls[0].Rules)
+	})
+}
+
 func TestController_deleteURLs(t *testing.T) {
 	var cookies []*http.Cookie
 
@@ -648,3 +1172,150 @@ func TestController_stats(t *testing.T) {
 		assert.Equal(t, tt.want.contentType, resp.Header().Get("Content-Type"), "contentType не соответствует ожиданиям")
 	}
 }
+
+func TestParseStatsFilter(t *testing.T) {
+	tests := []struct {
+		name    string
+		query   string
+		want    models.StatsFilter
+		wantErr error
+	}{
+		{
+			name:  "параметры не заданы",
+			query: "",
+			want:  models.StatsFilter{},
+		},
+		{
+			name:  "период в формате RFC 3339",
+			query: "from=2026-10-01T00:00:00Z&to=2026-10-19T12:00:00Z&top=5",
+			want: models.StatsFilter{
+				From: time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC),
+				To:   time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC),
+				Top:  5,
+			},
+		},
+		{
+			name:  "период в виде дат, последний день включается",
+			query: "from=2026-10-01&to=2026-10-19",
+			want: models.StatsFilter{
+				From: time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC),
+				To:   time.Date(2026, 10, 20, 0, 0, 0, 0, time.UTC),
+			},
+		},
+		{
+			name:    "некорректная дата",
+			query:   "from=01.10.2026",
+			wantErr: constants.ErrorInvalidStatsRange,
+		},
+		{
+			name:    "некорректный размер рейтинга",
+			query:   "top=-1",
+			wantErr: constants.ErrorInvalidStatsRange,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/api/internal/stats?"+tt.query, nil)
+
+			got, err := parseStatsFilter(req)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestController_domains(t *testing.T) {
+	cfg := &config.Config{
+		BaseURL:        "http://localhost:8080",
+		Domains:        []string{"https://go.brand-a.com"},
+		MaxBodySize:    1 << 20,
+		HandlerTimeout: time.Second,
+		UseMockAuth:    true,
+	}
+
+	repo := repository.NewRepoFileMemory(&repository.Storage{Producer: storage.NewProducerMemory(nil)})
+	controller := NewСontroller(usecase.NewURLUseCase(repo, service.NewService()), cfg)
+
+	server := httptest.NewServer(controller.SetupRouter())
+	defer server.Close()
+
+	client := server.Client()
+	client.CheckRedirect = func(*http.Request, []*http.Request) error { return http.ErrUseLastResponse }
+
+	send := func(method, path, host, body string) *http.Response {
+		req, err := http.NewRequest(method, server.URL+path, strings.NewReader(body))
+		require.NoError(t, err)
+		if host != "" {
+			req.Host = host
+		}
+		resp, err := client.Do(req)
+		require.NoError(t, err, "error making HTTP request")
+		t.Cleanup(func() { resp.Body.Close() })
+		return resp
+	}
+	readBody := func(resp *http.Response) string {
+		body, err := io.ReadAll(resp.Body)
+		require.NoError(t, err)
+		return string(body)
+	}
+
+	const original = "https://www.hc-sochi.ru/"
+	var short string
+
+	t.Run("ссылка на домене по умолчанию", func(t *testing.T) {
+		resp := send(http.MethodPost, "/", "", original)
+		require.Equal(t, http.StatusCreated, resp.StatusCode)
+		body := readBody(resp)
+		require.True(t, strings.HasPrefix(body, "http://localhost:8080/"), body)
+		short = strings.TrimPrefix(body, "http://localhost:8080/")
+	})
+
+	t.Run("тот же URL на выбранном домене", func(t *testing.T) {
+		resp := send(http.MethodPost, "/api/shorten", "", `{"url":"`+original+`","domain":"go.brand-a.com"}`)
+		require.Equal(t, http.StatusCreated, resp.StatusCode)
+		assert.JSONEq(t, `{"result":"https://go.brand-a.com/`+short+`"}`, readBody(resp))
+	})
+
+	t.Run("повторное создание на выбранном домене", func(t *testing.T) {
+		resp := send(http.MethodPost, "/", "go.brand-a.com", original)
+		assert.Equal(t, http.StatusConflict, resp.StatusCode)
+		assert.Equal(t, "https://go.brand-a.com/"+short, readBody(resp))
+	})
+
+	t.Run("домен не обслуживается", func(t *testing.T) {
+		resp := send(http.MethodPost, "/api/shorten", "", `{"url":"https://www.khl.ru/","domain":"evil.example"}`)
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	})
+
+	t.Run("ссылка ищется в пространстве имен домена запроса", func(t *testing.T) {
+		resp := send(http.MethodGet, "/"+short, "go.brand-a.com", "")
+		assert.Equal(t, http.StatusTemporaryRedirect, resp.StatusCode)
+		assert.Equal(t, original, resp.Header.Get("Location"))
+
+		// ссылка создана только на домене, определенном по заголовку Host
+		resp = send(http.MethodPost, "/", "go.brand-a.com:443", "https://www.khl.ru/")
+		require.Equal(t, http.StatusCreated, resp.StatusCode)
+		brandShort := strings.TrimPrefix(readBody(resp), "https://go.brand-a.com/")
+
+		resp = send(http.MethodGet, "/"+brandShort, "go.brand-a.com", "")
+		assert.Equal(t, http.StatusTemporaryRedirect, resp.StatusCode)
+		resp = send(http.MethodGet, "/"+brandShort, "", "")
+		assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+	})
+
+	t.Run("ссылки пользователя с базовыми URL своих доменов", func(t *testing.T) {
+		resp := send(http.MethodGet, "/api/user/urls", "", "")
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+
+		var urls []models.URLGetAll
+		require.NoError(t, json.Unmarshal([]byte(readBody(resp)), &urls))
+		require.Len(t, urls, 3)
+		assert.Equal(t, "http://localhost:8080/"+short, urls[0].Short)
+		assert.Equal(t, "https://go.brand-a.com/"+short, urls[1].Short)
+		assert.Equal(t, "go.brand-a.com", urls[1].Domain)
+	})
+}
@@ -5,6 +5,7 @@ import (
 	"net/http"
 
 	"github.com/Di-nis/shortener-url/internal/constants"
+	"github.com/Di-nis/shortener-url/internal/limits"
 )
 
 // writeStatusCreate - запись статус-кода в ответ для функций создания url.
@@ -13,11 +14,26 @@ func writeStatusCreate(res http.ResponseWriter, err error) {
 		res.WriteHeader(http.StatusCreated)
 	} else if errors.Is(err, constants.ErrorURLAlreadyExist) {
 		res.WriteHeader(http.StatusConflict)
+	} else if errors.Is(err, constants.ErrorUserBlocked) {
+		res.WriteHeader(http.StatusForbidden)
 	} else {
 		res.WriteHeader(http.StatusServiceUnavailable)
 	}
 }
 
+// writeStatusUpdate - запись статус-кода в ответ для функций изменения url.
+func writeStatusUpdate(res http.ResponseWriter, err error) {
+	if err == nil {
+		res.WriteHeader(http.StatusNoContent)
+	} else if errors.Is(err, constants.ErrorURLNotExist) {
+		res.WriteHeader(http.StatusNotFound)
+	} else if limits.IsTimeout(err) {
+		res.WriteHeader(http.StatusServiceUnavailable)
+	} else {
+		res.WriteHeader(http.StatusInternalServerError)
+	}
+}
+
 // writeStatusCodePing - запись статус-кода в ответ для pingDB.
 func writeStatusCodePing(res http.ResponseWriter, err error) {
 	if err != nil {
@@ -30,3 +46,12 @@ func writeStatusCodePing(res http.ResponseWriter, err error) {
 		res.WriteHeader(http.StatusOK)
 	}
 }
+
+// writeStatusReadBody - запись статус-кода в ответ при ошибке чтения тела запроса.
+func writeStatusReadBody(res http.ResponseWriter, err error) {
+	if limits.IsBodyTooLarge(err) {
+		http.Error(res, constants.RequestTooLargeError, http.StatusRequestEntityTooLarge)
+	} else {
+		http.Error(res, constants.ReadRequestError, http.StatusBadRequest)
+	}
+}
@@ -0,0 +1,141 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+
+	"github.com/Di-nis/shortener-url/internal/constants"
+	"github.com/Di-nis/shortener-url/internal/limits"
+	"github.com/Di-nis/shortener-url/internal/models"
+	"github.com/go-chi/chi/v5"
+)
+
+// WebhookManager - интерфейс, включающий методы по управлению подписками на события.
+type WebhookManager interface {
+	CreateWebhook(context.Context, models.Webhook) (models.Webhook, error)
+	GetWebhooks(context.Context, string) ([]models.Webhook, error)
+	DeleteWebhook(context.Context, string, string) error
+	GetDeliveries(context.Context, string, string) ([]models.WebhookDelivery, error)
+}
+
+// registerWebhookRoutes - регистрация маршрутов управления подписками на события.
+func (c *Controller) registerWebhookRoutes(r chi.Router) {
+	r.Group(func(r chi.Router) {
+		r.Use(limits.WithBodyLimit(c.Config.MaxBodySize))
+		r.Use(limits.WithTimeout(c.Config.HandlerTimeout))
+
+		r.Post("/user/webhooks", c.createWebhook)
+		r.Get("/user/webhooks", c.getWebhooks)
+		r.Delete("/user/webhooks/{id}", c.deleteWebhook)
+		r.Get("/user/webhooks/{id}/deliveries", c.getWebhookDeliveries)
+	})
+}
+
+// writeStatusWebhook - запись статус-кода в ответ при ошибке управления подписками.
+func writeStatusWebhook(res http.ResponseWriter, err error) {
+	if errors.Is(err, constants.ErrorInvalidWebhook) {
+		http.Error(res, err.Error(), http.StatusBadRequest)
+	} else if errors.Is(err, constants.ErrorNotFound) {
+		res.WriteHeader(http.StatusNotFound)
+	} else if limits.IsTimeout(err) {
+		http.Error(res, constants.TimeoutError, http.StatusServiceUnavailable)
+	} else {
+		http.Error(res, constants.InternalError, http.StatusInternalServerError)
+	}
+}
+
+// writeJSON - запись ответа в формате JSON.
+func writeJSON(res http.ResponseWriter, status int, value any) {
+	body, err := json.Marshal(value)
+	if err != nil {
+		http.Error(res, constants.InvalidJSONError, http.StatusInternalServerError)
+		return
+	}
+
+	res.Header().Set("Content-Type", "application/json")
+	res.WriteHeader(status)
+	if _, err = res.Write(body); err != nil {
+		http.Error(res, constants.WriteResponseError, http.StatusInternalServerError)
+	}
+}
+
+// createWebhook - регистрация подписки на события, секрет для проверки подписи возвращается только в ответе.
+func (c *Controller) createWebhook(res http.ResponseWriter, req *http.Request) {
+	ctx := req.Context()
+
+	bodyBytes, err := io.ReadAll(req.Body)
+	if err != nil {
+		writeStatusReadBody(res, err)
+		return
+	}
+	defer req.Body.Close()
+
+	if len(bodyBytes) == 0 {
+		http.Error(res, constants.EmptyBodyError, http.StatusBadRequest)
+		return
+	}
+
+	var webhook models.Webhook
+	if err = json.Unmarshal(bodyBytes, &webhook); err != nil {
+		http.Error(res, constants.InvalidJSONError, http.StatusBadRequest)
+		return
+	}
+
+	// Получение userID через middleware Auth
+	webhook.UUID = ctx.Value(constants.UserIDKey).(string)
+
+	webhook, err = c.Webhooks.CreateWebhook(ctx, webhook)
+	if err != nil {
+		writeStatusWebhook(res, err)
+		return
+	}
+	writeJSON(res, http.StatusCreated, webhook)
+}
+
+// getWebhooks - получение подписок пользователя на события.
+func (c *Controller) getWebhooks(res http.ResponseWriter, req *http.Request) {
+	ctx := req.Context()
+	userID := ctx.Value(constants.UserIDKey).(string)
+
+	webhooks, err := c.Webhooks.GetWebhooks(ctx, userID)
+	if err != nil {
+		writeStatusWebhook(res, err)
+		return
+	}
+	if len(webhooks) == 0 {
+		res.WriteHeader(http.StatusNoContent)
+		return
+	}
+	writeJSON(res, http.StatusOK, webhooks)
+}
+
+// deleteWebhook - удаление подписки пользователя на события.
+func (c *Controller) deleteWebhook(res http.ResponseWriter, req *http.Request) {
+	ctx := req.Context()
+	userID := ctx.Value(constants.UserIDKey).(string)
+
+	if err := c.Webhooks.DeleteWebhook(ctx, userID, chi.URLParam(req, "id")); err != nil {
+		writeStatusWebhook(res, err)
+		return
+	}
+	res.WriteHeader(http.StatusNoContent)
+}
+
+// getWebhookDeliveries - получение журнала доставок подписки пользователя.
+func (c *Controller) getWebhookDeliveries(res http.ResponseWriter, req *http.Request) {
+	ctx := req.Context()
+	userID := ctx.Value(constants.UserIDKey).(string)
+
+	deliveries, err := c.Webhooks.GetDeliveries(ctx, userID, chi.URLParam(req, "id"))
+	if err != nil {
+		writeStatusWebhook(res, err)
+		return
+	}
+	if deliveries == nil {
+		deliveries = []models.WebhookDelivery{}
+	}
+	writeJSON(res, http.StatusOK, deliveries)
+}
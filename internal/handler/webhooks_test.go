@@ -0,0 +1,144 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
+	"testing"
+	"time"
+
+	"github.com/Di-nis/shortener-url/internal/config"
+	"github.com/Di-nis/shortener-url/internal/models"
+	"github.com/Di-nis/shortener-url/internal/repository"
+	"github.com/Di-nis/shortener-url/internal/service"
+	"github.com/Di-nis/shortener-url/internal/usecase"
+	"github.com/go-resty/resty/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// resolverStub - разрешение имен хостов для тестов.
+type resolverStub map[string][]netip.Addr
+
+func (r resolverStub) LookupNetIP(ctx context.Context, network, host string) ([]netip.Addr, error) {
+	addrs, ok := r[host]
+	if !ok {
+		return nil, errors.New("no such host")
+	}
+	return addrs, nil
+}
+
+func TestController_webhooks(t *testing.T) {
+	cfg := &config.Config{
+		BaseURL:        "http://localhost:8080",
+		MaxBodySize:    1 << 20,
+		HandlerTimeout: time.Second,
+		UseMockAuth:    true,
+	}
+
+	repo := repository.NewRepoFileMemory(&repository.Storage{})
+	controller := NewСontroller(usecase.NewURLUseCase(repo, service.NewService()), cfg)
+	webhooks := usecase.NewWebhookUseCase(repo)
+	webhooks.Resolver = resolverStub{"hooks.example.com": {netip.MustParseAddr("93.184.216.34")}}
+	controller.Webhooks = webhooks
+
+	server := httptest.NewServer(controller.SetupRouter())
+	defer server.Close()
+
+	client := resty.New()
+	var webhook models.Webhook
+
+	t.Run("создание подписки", func(t *testing.T) {
+		resp, err := client.R().
+			SetBody(`{"url":"https://hooks.example.com/shortener","events":["url.created"]}`).
+			SetResult(&webhook).
+			Post(server.URL + "/api/user/webhooks")
+		require.NoError(t, err, "error making HTTP request")
+		require.Equal(t, http.StatusCreated, resp.StatusCode())
+		assert.NotEmpty(t, webhook.ID)
+		assert.NotEmpty(t, webhook.Secret)
+		assert.Equal(t, []string{models.EventURLCreated}, webhook.Events)
+	})
+
+	tests := []struct {
+		name       string
+		method     string
+		path       string
+		body       string
+		statusCode int
+	}{
+		{
+			name:       "некорректный адрес подписки",
+			method:     http.MethodPost,
+			path:       "/api/user/webhooks",
+			body:       `{"url":"ftp://hooks.example.com"}`,
+			statusCode: http.StatusBadRequest,
+		},
+		{
+			name:       "внутренний адрес подписки",
+			method:     http.MethodPost,
+			path:       "/api/user/webhooks",
+			body:       `{"url":"http://169.254.169.254/latest/meta-data/"}`,
+			statusCode: http.StatusBadRequest,
+		},
+		{
+			name:       "неизвестный тип события",
+			method:     http.MethodPost,
+			path:       "/api/user/webhooks",
+			body:       `{"url":"https://hooks.example.com","events":["url.updated"]}`,
+			statusCode: http.StatusBadRequest,
+		},
+		{
+			name:       "список подписок",
+			method:     http.MethodGet,
+			path:       "/api/user/webhooks",
+			statusCode: http.StatusOK,
+		},
+		{
+			name:       "журнал доставок",
+			method:     http.MethodGet,
+			path:       "/api/user/webhooks/" + webhook.ID + "/deliveries",
+			statusCode: http.StatusOK,
+		},
+		{
+			name:       "журнал доставок несуществующей подписки",
+			method:     http.MethodGet,
+			path:       "/api/user/webhooks/6f9619ff-8b86-d011-b42d-00cf4fc964ff/deliveries",
+			statusCode: http.StatusNotFound,
+		},
+		{
+			name:       "удаление подписки",
+			method:     http.MethodDelete,
+			path:       "/api/user/webhooks/" + webhook.ID,
+			statusCode: http.StatusNoContent,
+		},
+		{
+			name:       "повторное удаление подписки",
+			method:     http.MethodDelete,
+			path:       "/api/user/webhooks/" + webhook.ID,
+			statusCode: http.StatusNotFound,
+		},
+		{
+			name:       "список подписок пуст",
+			method:     http.MethodGet,
+			path:       "/api/user/webhooks",
+			statusCode: http.StatusNoContent,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := client.R()
+			req.Method = tt.method
+			req.URL = server.URL + tt.path
+			if tt.body != "" {
+				req.Body = tt.body
+			}
+
+			resp, err := req.Send()
+			require.NoError(t, err, "error making HTTP request")
+			assert.Equal(t, tt.statusCode, resp.StatusCode())
+		})
+	}
+}
@@ -0,0 +1,232 @@
+// Package healthcheck реализует фоновую проверку доступности оригинальных URL
+// с ограничением по числу одновременных запросов, частоте запросов к одному хосту и времени ожидания.
+package healthcheck
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/Di-nis/shortener-url/internal/logger"
+	"github.com/Di-nis/shortener-url/internal/models"
+)
+
+// ErrUnsupportedScheme - схема URL не поддерживается.
+var ErrUnsupportedScheme = errors.New("unsupported URL scheme")
+
+// maxDrainBytes - максимальный объем тела ответа, который вычитывается для повторного использования соединения.
+const maxDrainBytes = 64 << 10
+
+// Repository - интерфейс хранилища проверяемых URL.
+type Repository interface {
+	SelectForHealthCheck(context.Context) ([]models.URLBase, error)
+	UpdateHealth(context.Context, string, string, models.URLHealth) error
+}
+
+// Options - параметры проверки.
+type Options struct {
+	// Interval - период между проверками
+	Interval time.Duration
+	// Timeout - время ожидания ответа
+	Timeout time.Duration
+	// Concurrency - число одновременных запросов
+	Concurrency int
+	// HostInterval - минимальный интервал между запросами к одному хосту
+	HostInterval time.Duration
+	// MarkBroken - помечать недоступные URL как неработающие
+	MarkBroken bool
+}
+
+// Checker - структура для проверки доступности URL.
+type Checker struct {
+	repo   Repository
+	client *http.Client
+	opts   Options
+}
+
+// NewChecker - создание структуры Checker.
+func NewChecker(repo Repository, client *http.Client, opts Options) *Checker {
+	if opts.Concurrency <= 0 {
+		opts.Concurrency = 1
+	}
+	return &Checker{
+		repo:   repo,
+		client: client,
+		opts:   opts,
+	}
+}
+
+// Run - периодическая проверка доступности URL до отмены контекста.
+func (c *Checker) Run(ctx context.Context) {
+	ticker := time.NewTicker(c.opts.Interval)
+	defer ticker.Stop()
+
+	for {
+		if err := c.CheckAll(ctx); err != nil && ctx.Err() == nil {
+			logger.Sugar.Warnw("failed to check urls health", "error", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// CheckAll - однократная проверка доступности всех URL.
+func (c *Checker) CheckAll(ctx context.Context) error {
+	urls, err := c.repo.SelectForHealthCheck(ctx)
+	if err != nil {
+		return fmt.Errorf("path: internal/healthcheck/healthcheck.go, func CheckAll(), failed to select urls: %w", err)
+	}
+
+	limiter := newHostLimiter(c.opts.HostInterval)
+	jobs := make(chan models.URLBase)
+
+	var wg sync.WaitGroup
+	for range c.opts.Concurrency {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for url := range jobs {
+				health, err := c.check(ctx, limiter, url.Original)
+				if err != nil {
+					// проверка прервана отменой контекста
+					continue
+				}
+				if err := c.repo.UpdateHealth(ctx, url.Domain, url.Short, health); err != nil {
+					logger.Sugar.Warnw("failed to save url health", "short", url.Short, "domain", url.Domain, "error", err)
+				}
+			}
+		}()
+	}
+
+	for _, url := range urls {
+		select {
+		case jobs <- url:
+		case <-ctx.Done():
+		}
+		if ctx.Err() != nil {
+			break
+		}
+	}
+	close(jobs)
+	wg.Wait()
+
+	return ctx.Err()
+}
+
+// check - проверка доступности URL.
+// Сначала выполняется запрос HEAD, если сервер его не поддерживает - GET.
+func (c *Checker) check(ctx context.Context, limiter *hostLimiter, target string) (models.URLHealth, error) {
+	parsedURL, err := url.Parse(target)
+	if err != nil {
+		return c.result(0, err), nil
+	}
+	if parsedURL.Scheme != "http" && parsedURL.Scheme != "https" {
+		return c.result(0, ErrUnsupportedScheme), nil
+	}
+
+	if err = limiter.wait(ctx, parsedURL.Host); err != nil {
+		return models.URLHealth{}, err
+	}
+	status, err := c.request(ctx, http.MethodHead, target)
+	if err == nil && (status == http.StatusMethodNotAllowed || status == http.StatusNotImplemented) {
+		if err = limiter.wait(ctx, parsedURL.Host); err != nil {
+			return models.URLHealth{}, err
+		}
+		status, err = c.request(ctx, http.MethodGet, target)
+	}
+	if ctx.Err() != nil {
+		return models.URLHealth{}, ctx.Err()
+	}
+	return c.result(status, err), nil
+}
+
+// request - выполнение запроса с ограничением времени ожидания.
+func (c *Checker) request(ctx context.Context, method, target string) (int, error) {
+	if c.opts.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, c.opts.Timeout)
+		defer cancel()
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, target, nil)
+	if err != nil {
+		return 0, err
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, io.LimitReader(resp.Body, maxDrainBytes))
+
+	return resp.StatusCode, nil
+}
+
+// result - формирование результата проверки.
+func (c *Checker) result(status int, err error) models.URLHealth {
+	health := models.URLHealth{
+		Status:    status,
+		CheckedAt: time.Now().UTC(),
+	}
+	if err != nil {
+		health.Error = err.Error()
+	}
+	failed := err != nil || status >= http.StatusBadRequest
+	health.Broken = c.opts.MarkBroken && failed
+	return health
+}
+
+// hostLimiter - ограничение частоты запросов к одному хосту.
+type hostLimiter struct {
+	mu       sync.Mutex
+	interval time.Duration
+	next     map[string]time.Time
+}
+
+// newHostLimiter - создание структуры hostLimiter.
+func newHostLimiter(interval time.Duration) *hostLimiter {
+	return &hostLimiter{
+		interval: interval,
+		next:     make(map[string]time.Time),
+	}
+}
+
+// wait - ожидание очереди запроса к хосту.
+func (l *hostLimiter) wait(ctx context.Context, host string) error {
+	if l.interval <= 0 {
+		return nil
+	}
+
+	l.mu.Lock()
+	now := time.Now()
+	slot := l.next[host]
+	if slot.Before(now) {
+		slot = now
+	}
+	l.next[host] = slot.Add(l.interval)
+	l.mu.Unlock()
+
+	delay := time.Until(slot)
+	if delay <= 0 {
+		return nil
+	}
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
@@ -0,0 +1,243 @@
+package healthcheck
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/Di-nis/shortener-url/internal/models"
+	"github.com/Di-nis/shortener-url/internal/netguard"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// repoStub - хранилище проверяемых URL для тестов.
+type repoStub struct {
+	mu   sync.Mutex
+	urls []models.URLBase
+	// health - результаты проверки по ключу "домен/короткий URL"
+	health map[string]models.URLHealth
+}
+
+func newRepoStub(urls ...models.URLBase) *repoStub {
+	return &repoStub{urls: urls, health: make(map[string]models.URLHealth)}
+}
+
+func (r *repoStub) SelectForHealthCheck(ctx context.Context) ([]models.URLBase, error) {
+	return r.urls, nil
+}
+
+func (r *repoStub) UpdateHealth(ctx context.Context, domain, short string, health models.URLHealth) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.health[domain+"/"+short] = health
+	return nil
+}
+
+func TestChecker_CheckAll(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/ok", func(w http.ResponseWriter, r *http.Request) {})
+	mux.HandleFunc("/head-not-allowed", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodHead {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+		}
+	})
+	mux.HandleFunc("/slow", func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-time.After(time.Second):
+		case <-r.Context().Done():
+		}
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	tests := []struct {
+		name       string
+		url        string
+		markBroken bool
+		want       models.URLHealth
+		wantError  bool
+	}{
+		{
+			name: "URL доступен",
+			url:  server.URL + "/ok",
+			want: models.URLHealth{Status: http.StatusOK},
+		},
+		{
+			name: "запрос HEAD не поддерживается, выполняется GET",
+			url:  server.URL + "/head-not-allowed",
+			want: models.URLHealth{Status: http.StatusOK},
+		},
+		{
+			name:       "URL не найден, отметка о неработающем URL",
+			url:        server.URL + "/missing",
+			markBroken: true,
+			want:       models.URLHealth{Status: http.StatusNotFound, Broken: true},
+		},
+		{
+			name: "URL не найден, отметка отключена",
+			url:  server.URL + "/missing",
+			want: models.URLHealth{Status: http.StatusNotFound},
+		},
+		{
+			name:       "превышено время ожидания",
+			url:        server.URL + "/slow",
+			markBroken: true,
+			want:       models.URLHealth{Broken: true},
+			wantError:  true,
+		},
+		{
+			name:      "схема URL не поддерживается",
+			url:       "ftp://ftp.example.com/file.zip",
+			want:      models.URLHealth{},
+			wantError: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := newRepoStub(models.URLBase{Short: "lJJpJV7h", Original: tt.url})
+			checker := NewChecker(repo, server.Client(), Options{
+				Timeout:     100 * time.Millisecond,
+				Concurrency: 1,
+				MarkBroken:  tt.markBroken,
+			})
+
+			require.NoError(t, checker.CheckAll(context.Background()))
+
+			got, ok := repo.health["/lJJpJV7h"]
+			require.True(t, ok, "health is not saved")
+			assert.Equal(t, tt.want.Status, got.Status)
+			assert.Equal(t, tt.want.Broken, got.Broken)
+			assert.Equal(t, tt.wantError, got.Error != "")
+			assert.WithinDuration(t, time.Now(), got.CheckedAt, time.Second)
+		})
+	}
+}
+
+func TestChecker_CheckAllDomains(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/missing" {
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	// один короткий URL в двух доменах указывает на разные страницы
+	repo := newRepoStub(
+		models.URLBase{Short: "lJJpJV7h", Original: server.URL + "/ok"},
+		models.URLBase{Short: "lJJpJV7h", Domain: "go.example.com", Original: server.URL + "/missing"},
+	)
+	checker := NewChecker(repo, server.Client(), Options{Timeout: time.Second, Concurrency: 1})
+
+	require.NoError(t, checker.CheckAll(context.Background()))
+
+	require.Len(t, repo.health, 2)
+	assert.Equal(t, http.StatusOK, repo.health["/lJJpJV7h"].Status)
+	assert.Equal(t, http.StatusNotFound, repo.health["go.example.com/lJJpJV7h"].Status)
+}
+
+func TestChecker_CheckAllPublicClient(t *testing.T) {
+	var requested atomic.Bool
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requested.Store(true)
+	}))
+	defer server.Close()
+
+	// адрес loopback не проверяется клиентом, соединяющимся только с публичными адресами
+	repo := newRepoStub(models.URLBase{Short: "lJJpJV7h", Original: server.URL + "/ok"})
+	checker := NewChecker(repo, netguard.NewPublicClient(), Options{Timeout: time.Second, Concurrency: 1, MarkBroken: true})
+
+	require.NoError(t, checker.CheckAll(context.Background()))
+
+	got := repo.health["/lJJpJV7h"]
+	assert.False(t, requested.Load(), "request reached loopback server")
+	assert.Zero(t, got.Status)
+	assert.True(t, got.Broken)
+	assert.Contains(t, got.Error, netguard.ErrForbiddenAddress.Error())
+}
+
+func TestChecker_CheckAllLimits(t *testing.T) {
+	const (
+		concurrency  = 2
+		hostInterval = 50 * time.Millisecond
+	)
+
+	var (
+		mu       sync.Mutex
+		requests []time.Time
+		active   atomic.Int32
+		maxSeen  atomic.Int32
+	)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		current := active.Add(1)
+		defer active.Add(-1)
+		for {
+			seen := maxSeen.Load()
+			if current <= seen || maxSeen.CompareAndSwap(seen, current) {
+				break
+			}
+		}
+
+		mu.Lock()
+		requests = append(requests, time.Now())
+		mu.Unlock()
+	}))
+	defer server.Close()
+
+	var urls []models.URLBase
+	for _, short := range []string{"a", "b", "c", "d"} {
+		urls = append(urls, models.URLBase{Short: short, Original: server.URL + "/" + short})
+	}
+	repo := newRepoStub(urls...)
+
+	checker := NewChecker(repo, server.Client(), Options{
+		Timeout:      time.Second,
+		Concurrency:  concurrency,
+		HostInterval: hostInterval,
+	})
+	require.NoError(t, checker.CheckAll(context.Background()))
+
+	assert.Len(t, repo.health, len(urls))
+	assert.LessOrEqual(t, maxSeen.Load(), int32(concurrency))
+
+	require.Len(t, requests, len(urls))
+	// запросы к одному хосту выполняются не чаще одного раза в hostInterval
+	for i := 1; i < len(requests); i++ {
+		assert.GreaterOrEqual(t, requests[i].Sub(requests[i-1]), hostInterval-10*time.Millisecond)
+	}
+}
+
+func TestChecker_Run(t *testing.T) {
+	var checks atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		checks.Add(1)
+	}))
+	defer server.Close()
+
+	repo := newRepoStub(models.URLBase{Short: "lJJpJV7h", Original: server.URL})
+	checker := NewChecker(repo, server.Client(), Options{
+		Interval:    20 * time.Millisecond,
+		Timeout:     time.Second,
+		Concurrency: 1,
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		checker.Run(ctx)
+		close(done)
+	}()
+
+	assert.Eventually(t, func() bool { return checks.Load() >= 2 }, time.Second, 10*time.Millisecond)
+	cancel()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("checker did not stop after context cancellation")
+	}
+}
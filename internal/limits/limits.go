@@ -0,0 +1,50 @@
+// Package limits предоставляет middleware для ограничения размера тела запроса
+// и времени обработки запроса.
+package limits
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+)
+
+// WithBodyLimit - middleware для ограничения размера тела запроса.
+func WithBodyLimit(maxBytes int64) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if maxBytes > 0 && r.Body != nil {
+				r.Body = http.MaxBytesReader(w, r.Body, maxBytes)
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// WithTimeout - middleware для ограничения времени обработки запроса.
+func WithTimeout(timeout time.Duration) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if timeout <= 0 {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			ctx, cancel := context.WithTimeout(r.Context(), timeout)
+			defer cancel()
+
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
+
+// IsBodyTooLarge - проверка, что ошибка вызвана превышением размера тела запроса.
+func IsBodyTooLarge(err error) bool {
+	var maxBytesErr *http.MaxBytesError
+	return errors.As(err, &maxBytesErr)
+}
+
+// IsTimeout - проверка, что ошибка вызвана истечением времени обработки запроса.
+func IsTimeout(err error) bool {
+	return errors.Is(err, context.DeadlineExceeded)
+}
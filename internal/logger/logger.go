@@ -12,7 +12,7 @@ import (
 var Log *zap.Logger = zap.NewNop()
 
 // Sugar *zap.SugaredLogger.
-var Sugar *zap.SugaredLogger
+var Sugar *zap.SugaredLogger = Log.Sugar()
 
 // Initialize - инициализирует логгер.
 func Initialize(level string) error {
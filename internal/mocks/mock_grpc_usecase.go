@@ -0,0 +1,184 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/Di-nis/shortener-url/internal/server/grpc (interfaces: URLUseCase)
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	models "github.com/Di-nis/shortener-url/internal/models"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockGRPCUseCase is a mock of URLUseCase interface.
+type MockGRPCUseCase struct {
+	ctrl     *gomock.Controller
+	recorder *MockGRPCUseCaseMockRecorder
+}
+
+// MockGRPCUseCaseMockRecorder is the mock recorder for MockGRPCUseCase.
+type MockGRPCUseCaseMockRecorder struct {
+	mock *MockGRPCUseCase
+}
+
+// NewMockGRPCUseCase creates a new mock instance.
+func NewMockGRPCUseCase(ctrl *gomock.Controller) *MockGRPCUseCase {
+	mock := &MockGRPCUseCase{ctrl: ctrl}
+	mock.recorder = &MockGRPCUseCaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockGRPCUseCase) EXPECT() *MockGRPCUseCaseMockRecorder {
+	return m.recorder
+}
+
+// CreateURLBatch mocks base method.
+func (m *MockGRPCUseCase) CreateURLBatch(arg0 context.Context, arg1 []models.URLBase) ([]models.URLBase, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateURLBatch", arg0, arg1)
+	ret0, _ := ret[0].([]models.URLBase)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateURLBatch indicates an expected call of CreateURLBatch.
+func (mr *MockGRPCUseCaseMockRecorder) CreateURLBatch(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateURLBatch", reflect.TypeOf((*MockGRPCUseCase)(nil).CreateURLBatch), arg0, arg1)
+}
+
+// CreateURLBulk mocks base method.
+func (m *MockGRPCUseCase) CreateURLBulk(arg0 context.Context, arg1 []models.URLBase) ([]models.BulkResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateURLBulk", arg0, arg1)
+	ret0, _ := ret[0].([]models.BulkResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateURLBulk indicates an expected call of CreateURLBulk.
+func (mr *MockGRPCUseCaseMockRecorder) CreateURLBulk(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateURLBulk", reflect.TypeOf((*MockGRPCUseCase)(nil).CreateURLBulk), arg0, arg1)
+}
+
+// CreateURLOrdinary mocks base method.
+func (m *MockGRPCUseCase) CreateURLOrdinary(arg0 context.Context, arg1 interface{}) (models.URLBase, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateURLOrdinary", arg0, arg1)
+	ret0, _ := ret[0].(models.URLBase)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateURLOrdinary indicates an expected call of CreateURLOrdinary.
+func (mr *MockGRPCUseCaseMockRecorder) CreateURLOrdinary(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateURLOrdinary", reflect.TypeOf((*MockGRPCUseCase)(nil).CreateURLOrdinary), arg0, arg1)
+}
+
+// DeleteURLs mocks base method.
+func (m *MockGRPCUseCase) DeleteURLs(arg0 context.Context, arg1 []models.URLBase) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteURLs", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteURLs indicates an expected call of DeleteURLs.
+func (mr *MockGRPCUseCaseMockRecorder) DeleteURLs(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteURLs", reflect.TypeOf((*MockGRPCUseCase)(nil).DeleteURLs), arg0, arg1)
+}
+
+// GetAllURLs mocks base method.
+func (m *MockGRPCUseCase) GetAllURLs(arg0 context.Context, arg1 string, arg2 models.URLFilter) ([]models.URLBase, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAllURLs", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]models.URLBase)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAllURLs indicates an expected call of GetAllURLs.
+func (mr *MockGRPCUseCaseMockRecorder) GetAllURLs(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllURLs", reflect.TypeOf((*MockGRPCUseCase)(nil).GetAllURLs), arg0, arg1, arg2)
+}
+
+// GetOriginalURL mocks base method.
+func (m *MockGRPCUseCase) GetOriginalURL(arg0 context.Context, arg1, arg2 string) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOriginalURL", arg0, arg1, arg2)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOriginalURL indicates an expected call of GetOriginalURL.
+func (mr *MockGRPCUseCaseMockRecorder) GetOriginalURL(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOriginalURL", reflect.TypeOf((*MockGRPCUseCase)(nil).GetOriginalURL), arg0, arg1, arg2)
+}
+
+// GetStats mocks base method.
+func (m *MockGRPCUseCase) GetStats(arg0 context.Context, arg1 models.StatsFilter) (*models.Stats, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetStats", arg0, arg1)
+	ret0, _ := ret[0].(*models.Stats)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetStats indicates an expected call of GetStats.
+func (mr *MockGRPCUseCaseMockRecorder) GetStats(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetStats", reflect.TypeOf((*MockGRPCUseCase)(nil).GetStats), arg0, arg1)
+}
+
+// GetURLsPage mocks base method.
+func (m *MockGRPCUseCase) GetURLsPage(arg0 context.Context, arg1 string, arg2 models.URLFilter, arg3 string, arg4 int) (models.URLPage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetURLsPage", arg0, arg1, arg2, arg3, arg4)
+	ret0, _ := ret[0].(models.URLPage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetURLsPage indicates an expected call of GetURLsPage.
+func (mr *MockGRPCUseCaseMockRecorder) GetURLsPage(arg0, arg1, arg2, arg3, arg4 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetURLsPage", reflect.TypeOf((*MockGRPCUseCase)(nil).GetURLsPage), arg0, arg1, arg2, arg3, arg4)
+}
+
+// LookupURLs mocks base method.
+func (m *MockGRPCUseCase) LookupURLs(arg0 context.Context, arg1 string, arg2 models.URLLookup) ([]models.URLBase, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LookupURLs", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]models.URLBase)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LookupURLs indicates an expected call of LookupURLs.
+func (mr *MockGRPCUseCaseMockRecorder) LookupURLs(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LookupURLs", reflect.TypeOf((*MockGRPCUseCase)(nil).LookupURLs), arg0, arg1, arg2)
+}
+
+// Ping mocks base method.
+func (m *MockGRPCUseCase) Ping(arg0 context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Ping", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Ping indicates an expected call of Ping.
+func (mr *MockGRPCUseCaseMockRecorder) Ping(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Ping", reflect.TypeOf((*MockGRPCUseCase)(nil).Ping), arg0)
+}
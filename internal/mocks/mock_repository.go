@@ -7,6 +7,7 @@ package mocks
 import (
 	context "context"
 	reflect "reflect"
+	time "time"
 
 	models "github.com/Di-nis/shortener-url/internal/models"
 	gomock "github.com/golang/mock/gomock"
@@ -35,6 +36,35 @@ func (m *MockURLRepository) EXPECT() *MockURLRepositoryMockRecorder {
 	return m.recorder
 }
 
+// BlockUser mocks base method.
+func (m *MockURLRepository) BlockUser(arg0 context.Context, arg1 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BlockUser", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// BlockUser indicates an expected call of BlockUser.
+func (mr *MockURLRepositoryMockRecorder) BlockUser(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BlockUser", reflect.TypeOf((*MockURLRepository)(nil).BlockUser), arg0, arg1)
+}
+
+// ClaimDueDeliveries mocks base method.
+func (m *MockURLRepository) ClaimDueDeliveries(arg0 context.Context, arg1, arg2 time.Time, arg3 int) ([]models.WebhookDelivery, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClaimDueDeliveries", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].([]models.WebhookDelivery)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ClaimDueDeliveries indicates an expected call of ClaimDueDeliveries.
+func (mr *MockURLRepositoryMockRecorder) ClaimDueDeliveries(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClaimDueDeliveries", reflect.TypeOf((*MockURLRepository)(nil).ClaimDueDeliveries), arg0, arg1, arg2, arg3)
+}
+
 // Close mocks base method.
 func (m *MockURLRepository) Close() error {
 	m.ctrl.T.Helper()
@@ -49,48 +79,76 @@ func (mr *MockURLRepositoryMockRecorder) Close() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Close", reflect.TypeOf((*MockURLRepository)(nil).Close))
 }
 
-// Delete mocks base method.
-func (m *MockURLRepository) Delete(arg0 context.Context, arg1 []models.URLBase) error {
+// ConsumeClick mocks base method.
+func (m *MockURLRepository) ConsumeClick(arg0 context.Context, arg1, arg2 string) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Delete", arg0, arg1)
+	ret := m.ctrl.Call(m, "ConsumeClick", arg0, arg1, arg2)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
+// ConsumeClick indicates an expected call of ConsumeClick.
+func (mr *MockURLRepositoryMockRecorder) ConsumeClick(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ConsumeClick", reflect.TypeOf((*MockURLRepository)(nil).ConsumeClick), arg0, arg1, arg2)
+}
+
+// Delete mocks base method.
+func (m *MockURLRepository) Delete(arg0 context.Context, arg1 []models.URLBase) ([]models.URLBase, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", arg0, arg1)
+	ret0, _ := ret[0].([]models.URLBase)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
 // Delete indicates an expected call of Delete.
 func (mr *MockURLRepositoryMockRecorder) Delete(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockURLRepository)(nil).Delete), arg0, arg1)
 }
 
-// GetCountURLs mocks base method.
-func (m *MockURLRepository) GetCountURLs(arg0 context.Context) (int, error) {
+// DeleteWebhook mocks base method.
+func (m *MockURLRepository) DeleteWebhook(arg0 context.Context, arg1, arg2 string) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetCountURLs", arg0)
-	ret0, _ := ret[0].(int)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
+	ret := m.ctrl.Call(m, "DeleteWebhook", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
 }
 
-// GetCountURLs indicates an expected call of GetCountURLs.
-func (mr *MockURLRepositoryMockRecorder) GetCountURLs(arg0 interface{}) *gomock.Call {
+// DeleteWebhook indicates an expected call of DeleteWebhook.
+func (mr *MockURLRepositoryMockRecorder) DeleteWebhook(arg0, arg1, arg2 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCountURLs", reflect.TypeOf((*MockURLRepository)(nil).GetCountURLs), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteWebhook", reflect.TypeOf((*MockURLRepository)(nil).DeleteWebhook), arg0, arg1, arg2)
 }
 
-// GetCountUsers mocks base method.
-func (m *MockURLRepository) GetCountUsers(arg0 context.Context) (int, error) {
+// ForceDelete mocks base method.
+func (m *MockURLRepository) ForceDelete(arg0 context.Context, arg1, arg2 string) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetCountUsers", arg0)
-	ret0, _ := ret[0].(int)
+	ret := m.ctrl.Call(m, "ForceDelete", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ForceDelete indicates an expected call of ForceDelete.
+func (mr *MockURLRepositoryMockRecorder) ForceDelete(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ForceDelete", reflect.TypeOf((*MockURLRepository)(nil).ForceDelete), arg0, arg1, arg2)
+}
+
+// GetStats mocks base method.
+func (m *MockURLRepository) GetStats(arg0 context.Context, arg1 models.StatsFilter) (*models.Stats, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetStats", arg0, arg1)
+	ret0, _ := ret[0].(*models.Stats)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetCountUsers indicates an expected call of GetCountUsers.
-func (mr *MockURLRepositoryMockRecorder) GetCountUsers(arg0 interface{}) *gomock.Call {
+// GetStats indicates an expected call of GetStats.
+func (mr *MockURLRepositoryMockRecorder) GetStats(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCountUsers", reflect.TypeOf((*MockURLRepository)(nil).GetCountUsers), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetStats", reflect.TypeOf((*MockURLRepository)(nil).GetStats), arg0, arg1)
 }
 
 // InsertBatch mocks base method.
@@ -107,6 +165,20 @@ func (mr *MockURLRepositoryMockRecorder) InsertBatch(arg0, arg1 interface{}) *go
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertBatch", reflect.TypeOf((*MockURLRepository)(nil).InsertBatch), arg0, arg1)
 }
 
+// InsertDeliveries mocks base method.
+func (m *MockURLRepository) InsertDeliveries(arg0 context.Context, arg1 []models.WebhookDelivery) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InsertDeliveries", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// InsertDeliveries indicates an expected call of InsertDeliveries.
+func (mr *MockURLRepositoryMockRecorder) InsertDeliveries(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertDeliveries", reflect.TypeOf((*MockURLRepository)(nil).InsertDeliveries), arg0, arg1)
+}
+
 // InsertOrdinary mocks base method.
 func (m *MockURLRepository) InsertOrdinary(arg0 context.Context, arg1 models.URLBase) error {
 	m.ctrl.T.Helper()
@@ -121,6 +193,65 @@ func (mr *MockURLRepositoryMockRecorder) InsertOrdinary(arg0, arg1 interface{})
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertOrdinary", reflect.TypeOf((*MockURLRepository)(nil).InsertOrdinary), arg0, arg1)
 }
 
+// InsertWebhook mocks base method.
+func (m *MockURLRepository) InsertWebhook(arg0 context.Context, arg1 models.Webhook) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InsertWebhook", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// InsertWebhook indicates an expected call of InsertWebhook.
+func (mr *MockURLRepositoryMockRecorder) InsertWebhook(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertWebhook", reflect.TypeOf((*MockURLRepository)(nil).InsertWebhook), arg0, arg1)
+}
+
+// IsUserBlocked mocks base method.
+func (m *MockURLRepository) IsUserBlocked(arg0 context.Context, arg1 string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsUserBlocked", arg0, arg1)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IsUserBlocked indicates an expected call of IsUserBlocked.
+func (mr *MockURLRepositoryMockRecorder) IsUserBlocked(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsUserBlocked", reflect.TypeOf((*MockURLRepository)(nil).IsUserBlocked), arg0, arg1)
+}
+
+// LookupURLs mocks base method.
+func (m *MockURLRepository) LookupURLs(arg0 context.Context, arg1 string, arg2 models.URLLookup) ([]models.URLBase, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LookupURLs", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]models.URLBase)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LookupURLs indicates an expected call of LookupURLs.
+func (mr *MockURLRepositoryMockRecorder) LookupURLs(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LookupURLs", reflect.TypeOf((*MockURLRepository)(nil).LookupURLs), arg0, arg1, arg2)
+}
+
+// NextID mocks base method.
+func (m *MockURLRepository) NextID(arg0 context.Context) (uint64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "NextID", arg0)
+	ret0, _ := ret[0].(uint64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// NextID indicates an expected call of NextID.
+func (mr *MockURLRepositoryMockRecorder) NextID(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NextID", reflect.TypeOf((*MockURLRepository)(nil).NextID), arg0)
+}
+
 // Ping mocks base method.
 func (m *MockURLRepository) Ping(arg0 context.Context) error {
 	m.ctrl.T.Helper()
@@ -135,47 +266,207 @@ func (mr *MockURLRepositoryMockRecorder) Ping(arg0 interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Ping", reflect.TypeOf((*MockURLRepository)(nil).Ping), arg0)
 }
 
+// RecordClick mocks base method.
+func (m *MockURLRepository) RecordClick(arg0 context.Context, arg1, arg2 string, arg3 int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RecordClick", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RecordClick indicates an expected call of RecordClick.
+func (mr *MockURLRepositoryMockRecorder) RecordClick(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecordClick", reflect.TypeOf((*MockURLRepository)(nil).RecordClick), arg0, arg1, arg2, arg3)
+}
+
+// SearchURLs mocks base method.
+func (m *MockURLRepository) SearchURLs(arg0 context.Context, arg1 models.AdminFilter) ([]models.URLBase, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SearchURLs", arg0, arg1)
+	ret0, _ := ret[0].([]models.URLBase)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SearchURLs indicates an expected call of SearchURLs.
+func (mr *MockURLRepositoryMockRecorder) SearchURLs(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchURLs", reflect.TypeOf((*MockURLRepository)(nil).SearchURLs), arg0, arg1)
+}
+
 // SelectAll mocks base method.
-func (m *MockURLRepository) SelectAll(arg0 context.Context, arg1 string) ([]models.URLBase, error) {
+func (m *MockURLRepository) SelectAll(arg0 context.Context, arg1 string, arg2 models.URLFilter) ([]models.URLBase, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "SelectAll", arg0, arg1)
+	ret := m.ctrl.Call(m, "SelectAll", arg0, arg1, arg2)
 	ret0, _ := ret[0].([]models.URLBase)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // SelectAll indicates an expected call of SelectAll.
-func (mr *MockURLRepositoryMockRecorder) SelectAll(arg0, arg1 interface{}) *gomock.Call {
+func (mr *MockURLRepositoryMockRecorder) SelectAll(arg0, arg1, arg2 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SelectAll", reflect.TypeOf((*MockURLRepository)(nil).SelectAll), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SelectAll", reflect.TypeOf((*MockURLRepository)(nil).SelectAll), arg0, arg1, arg2)
+}
+
+// SelectDeliveries mocks base method.
+func (m *MockURLRepository) SelectDeliveries(arg0 context.Context, arg1, arg2 string, arg3 int) ([]models.WebhookDelivery, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SelectDeliveries", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].([]models.WebhookDelivery)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SelectDeliveries indicates an expected call of SelectDeliveries.
+func (mr *MockURLRepositoryMockRecorder) SelectDeliveries(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SelectDeliveries", reflect.TypeOf((*MockURLRepository)(nil).SelectDeliveries), arg0, arg1, arg2, arg3)
+}
+
+// SelectForHealthCheck mocks base method.
+func (m *MockURLRepository) SelectForHealthCheck(arg0 context.Context) ([]models.URLBase, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SelectForHealthCheck", arg0)
+	ret0, _ := ret[0].([]models.URLBase)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SelectForHealthCheck indicates an expected call of SelectForHealthCheck.
+func (mr *MockURLRepositoryMockRecorder) SelectForHealthCheck(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SelectForHealthCheck", reflect.TypeOf((*MockURLRepository)(nil).SelectForHealthCheck), arg0)
 }
 
 // SelectOriginal mocks base method.
-func (m *MockURLRepository) SelectOriginal(arg0 context.Context, arg1 string) (string, error) {
+func (m *MockURLRepository) SelectOriginal(arg0 context.Context, arg1, arg2 string) (models.URLBase, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "SelectOriginal", arg0, arg1)
-	ret0, _ := ret[0].(string)
+	ret := m.ctrl.Call(m, "SelectOriginal", arg0, arg1, arg2)
+	ret0, _ := ret[0].(models.URLBase)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // SelectOriginal indicates an expected call of SelectOriginal.
-func (mr *MockURLRepositoryMockRecorder) SelectOriginal(arg0, arg1 interface{}) *gomock.Call {
+func (mr *MockURLRepositoryMockRecorder) SelectOriginal(arg0, arg1, arg2 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SelectOriginal", reflect.TypeOf((*MockURLRepository)(nil).SelectOriginal), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SelectOriginal", reflect.TypeOf((*MockURLRepository)(nil).SelectOriginal), arg0, arg1, arg2)
+}
+
+// SelectPage mocks base method.
+func (m *MockURLRepository) SelectPage(arg0 context.Context, arg1 string, arg2 models.URLCursor) ([]models.URLBase, int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SelectPage", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]models.URLBase)
+	ret1, _ := ret[1].(int64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// SelectPage indicates an expected call of SelectPage.
+func (mr *MockURLRepositoryMockRecorder) SelectPage(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SelectPage", reflect.TypeOf((*MockURLRepository)(nil).SelectPage), arg0, arg1, arg2)
 }
 
 // SelectShort mocks base method.
-func (m *MockURLRepository) SelectShort(arg0 context.Context, arg1 string) (string, error) {
+func (m *MockURLRepository) SelectShort(arg0 context.Context, arg1, arg2, arg3 string) (string, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "SelectShort", arg0, arg1)
+	ret := m.ctrl.Call(m, "SelectShort", arg0, arg1, arg2, arg3)
 	ret0, _ := ret[0].(string)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // SelectShort indicates an expected call of SelectShort.
-func (mr *MockURLRepositoryMockRecorder) SelectShort(arg0, arg1 interface{}) *gomock.Call {
+func (mr *MockURLRepositoryMockRecorder) SelectShort(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SelectShort", reflect.TypeOf((*MockURLRepository)(nil).SelectShort), arg0, arg1, arg2, arg3)
+}
+
+// SelectWebhooks mocks base method.
+func (m *MockURLRepository) SelectWebhooks(arg0 context.Context, arg1 string) ([]models.Webhook, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SelectWebhooks", arg0, arg1)
+	ret0, _ := ret[0].([]models.Webhook)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SelectWebhooks indicates an expected call of SelectWebhooks.
+func (mr *MockURLRepositoryMockRecorder) SelectWebhooks(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SelectWebhooks", reflect.TypeOf((*MockURLRepository)(nil).SelectWebhooks), arg0, arg1)
+}
+
+// SetDisabled mocks base method.
+func (m *MockURLRepository) SetDisabled(arg0 context.Context, arg1, arg2 string, arg3 bool) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetDisabled", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetDisabled indicates an expected call of SetDisabled.
+func (mr *MockURLRepositoryMockRecorder) SetDisabled(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetDisabled", reflect.TypeOf((*MockURLRepository)(nil).SetDisabled), arg0, arg1, arg2, arg3)
+}
+
+// UnblockUser mocks base method.
+func (m *MockURLRepository) UnblockUser(arg0 context.Context, arg1 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UnblockUser", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UnblockUser indicates an expected call of UnblockUser.
+func (mr *MockURLRepositoryMockRecorder) UnblockUser(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnblockUser", reflect.TypeOf((*MockURLRepository)(nil).UnblockUser), arg0, arg1)
+}
+
+// Update mocks base method.
+func (m *MockURLRepository) Update(arg0 context.Context, arg1 models.URLUpdate) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockURLRepositoryMockRecorder) Update(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockURLRepository)(nil).Update), arg0, arg1)
+}
+
+// UpdateDelivery mocks base method.
+func (m *MockURLRepository) UpdateDelivery(arg0 context.Context, arg1 models.WebhookDelivery) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateDelivery", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateDelivery indicates an expected call of UpdateDelivery.
+func (mr *MockURLRepositoryMockRecorder) UpdateDelivery(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateDelivery", reflect.TypeOf((*MockURLRepository)(nil).UpdateDelivery), arg0, arg1)
+}
+
+// UpdateHealth mocks base method.
+func (m *MockURLRepository) UpdateHealth(arg0 context.Context, arg1, arg2 string, arg3 models.URLHealth) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateHealth", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateHealth indicates an expected call of UpdateHealth.
+func (mr *MockURLRepositoryMockRecorder) UpdateHealth(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SelectShort", reflect.TypeOf((*MockURLRepository)(nil).SelectShort), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateHealth", reflect.TypeOf((*MockURLRepository)(nil).UpdateHealth), arg0, arg1, arg2, arg3)
 }
@@ -80,49 +80,78 @@ func (mr *MockURLUseCaseMockRecorder) DeleteURLs(arg0, arg1 interface{}) *gomock
 }
 
 // GetAllURLs mocks base method.
-func (m *MockURLUseCase) GetAllURLs(arg0 context.Context, arg1 string) ([]models.URLBase, error) {
+func (m *MockURLUseCase) GetAllURLs(arg0 context.Context, arg1 string, arg2 models.URLFilter) ([]models.URLBase, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetAllURLs", arg0, arg1)
+	ret := m.ctrl.Call(m, "GetAllURLs", arg0, arg1, arg2)
 	ret0, _ := ret[0].([]models.URLBase)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetAllURLs indicates an expected call of GetAllURLs.
-func (mr *MockURLUseCaseMockRecorder) GetAllURLs(arg0, arg1 interface{}) *gomock.Call {
+func (mr *MockURLUseCaseMockRecorder) GetAllURLs(arg0, arg1, arg2 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllURLs", reflect.TypeOf((*MockURLUseCase)(nil).GetAllURLs), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllURLs", reflect.TypeOf((*MockURLUseCase)(nil).GetAllURLs), arg0, arg1, arg2)
 }
 
 // GetOriginalURL mocks base method.
-func (m *MockURLUseCase) GetOriginalURL(arg0 context.Context, arg1 string) (string, error) {
+func (m *MockURLUseCase) GetOriginalURL(arg0 context.Context, arg1, arg2 string) (string, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetOriginalURL", arg0, arg1)
+	ret := m.ctrl.Call(m, "GetOriginalURL", arg0, arg1, arg2)
 	ret0, _ := ret[0].(string)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetOriginalURL indicates an expected call of GetOriginalURL.
-func (mr *MockURLUseCaseMockRecorder) GetOriginalURL(arg0, arg1 interface{}) *gomock.Call {
+func (mr *MockURLUseCaseMockRecorder) GetOriginalURL(arg0, arg1, arg2 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOriginalURL", reflect.TypeOf((*MockURLUseCase)(nil).GetOriginalURL), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOriginalURL", reflect.TypeOf((*MockURLUseCase)(nil).GetOriginalURL), arg0, arg1, arg2)
+}
+
+// GetRedirectURL mocks base method.
+func (m *MockURLUseCase) GetRedirectURL(arg0 context.Context, arg1 models.RedirectRequest) (models.RedirectResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRedirectURL", arg0, arg1)
+	ret0, _ := ret[0].(models.RedirectResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRedirectURL indicates an expected call of GetRedirectURL.
+func (mr *MockURLUseCaseMockRecorder) GetRedirectURL(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRedirectURL", reflect.TypeOf((*MockURLUseCase)(nil).GetRedirectURL), arg0, arg1)
 }
 
 // GetStats mocks base method.
-func (m *MockURLUseCase) GetStats(arg0 context.Context) (int, int, error) {
+func (m *MockURLUseCase) GetStats(arg0 context.Context, arg1 models.StatsFilter) (*models.Stats, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetStats", arg0)
-	ret0, _ := ret[0].(int)
-	ret1, _ := ret[1].(int)
-	ret2, _ := ret[2].(error)
-	return ret0, ret1, ret2
+	ret := m.ctrl.Call(m, "GetStats", arg0, arg1)
+	ret0, _ := ret[0].(*models.Stats)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
 // GetStats indicates an expected call of GetStats.
-func (mr *MockURLUseCaseMockRecorder) GetStats(arg0 interface{}) *gomock.Call {
+func (mr *MockURLUseCaseMockRecorder) GetStats(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetStats", reflect.TypeOf((*MockURLUseCase)(nil).GetStats), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetStats", reflect.TypeOf((*MockURLUseCase)(nil).GetStats), arg0, arg1)
+}
+
+// LookupURLs mocks base method.
+func (m *MockURLUseCase) LookupURLs(arg0 context.Context, arg1 string, arg2 models.URLLookup) ([]models.URLBase, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LookupURLs", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]models.URLBase)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LookupURLs indicates an expected call of LookupURLs.
+func (mr *MockURLUseCaseMockRecorder) LookupURLs(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LookupURLs", reflect.TypeOf((*MockURLUseCase)(nil).LookupURLs), arg0, arg1, arg2)
 }
 
 // Ping mocks base method.
@@ -138,3 +167,17 @@ func (mr *MockURLUseCaseMockRecorder) Ping(arg0 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Ping", reflect.TypeOf((*MockURLUseCase)(nil).Ping), arg0)
 }
+
+// UpdateURL mocks base method.
+func (m *MockURLUseCase) UpdateURL(arg0 context.Context, arg1 models.URLUpdate) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateURL", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateURL indicates an expected call of UpdateURL.
+func (mr *MockURLUseCaseMockRecorder) UpdateURL(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateURL", reflect.TypeOf((*MockURLUseCase)(nil).UpdateURL), arg0, arg1)
+}
@@ -3,6 +3,8 @@ package models
 
 import (
 	"encoding/json"
+	"fmt"
+	"time"
 )
 
 // User - модель пользователя.
@@ -10,13 +12,59 @@ type User struct {
 	ID int
 }
 
+// Destination - адрес назначения URL с весом для распределения переходов.
+type Destination struct {
+	URL    string `json:"url"`
+	Weight int    `json:"weight"`
+	Clicks int64  `json:"clicks"`
+}
+
+// TargetRule - правило перенаправления по платформе и языку клиента.
+// Пустое условие соответствует любому значению.
+type TargetRule struct {
+	Platform string `json:"platform,omitempty"`
+	Language string `json:"language,omitempty"`
+	URL      string `json:"url"`
+}
+
+// URLHealth - результат последней проверки доступности оригинального URL.
+type URLHealth struct {
+	Status    int       `json:"status,omitempty"`
+	Error     string    `json:"error,omitempty"`
+	CheckedAt time.Time `json:"checked_at"`
+	Broken    bool      `json:"broken,omitempty"`
+}
+
 // URLBase - основная модель для сущности url.
 type URLBase struct {
 	UUID        string `db:"user_id"`
 	Short       string `db:"short"`
 	Original    string `db:"original"`
 	URLID       string
-	DeletedFlag bool `db:"is_deleted"`
+	DeletedFlag bool     `db:"is_deleted"`
+	Tags        []string `db:"tags"`
+	Folder      string   `db:"folder"`
+	Title       string   `db:"title"`
+	Description string   `db:"description"`
+	PassQuery   *bool    `db:"pass_query"`
+	PassPath    *bool    `db:"pass_path"`
+
+	Destinations []Destination `db:"destinations"`
+	Rules        []TargetRule  `db:"rules"`
+	// NotBefore - время, до которого ссылка не активна
+	NotBefore *time.Time `db:"not_before"`
+	// MaxClicks - оставшееся количество переходов, nil - без ограничения
+	MaxClicks *int `db:"max_clicks"`
+	// Health - результат последней проверки доступности
+	Health *URLHealth `db:"-"`
+	// DisabledFlag - ссылка отключена администратором
+	DisabledFlag bool `db:"is_disabled"`
+	// CreatedAt - время создания ссылки
+	CreatedAt time.Time `db:"created_at"`
+	// Clicks - общее количество переходов по ссылке
+	Clicks int64 `db:"clicks"`
+	// Domain - домен ссылки, пустое значение - домен по умолчанию
+	Domain string `db:"domain"`
 }
 
 // MarshalJSON - метод для сериализации модели URL.
@@ -37,9 +85,21 @@ func (url URLBase) MarshalJSON() ([]byte, error) {
 // UnmarshalJSON - метод для десериализации модели URL.
 func (url *URLBase) UnmarshalJSON(data []byte) error {
 	type URLAlias struct {
-		Short    string `json:"-"`
-		Original string `json:"original_url"`
-		URLID    string `json:"correlation_id"`
+		Short       string   `json:"-"`
+		Original    string   `json:"original_url"`
+		URLID       string   `json:"correlation_id"`
+		Tags        []string `json:"tags"`
+		Folder      string   `json:"folder"`
+		Title       string   `json:"title"`
+		Description string   `json:"description"`
+		PassQuery   *bool    `json:"pass_query"`
+		PassPath    *bool    `json:"pass_path"`
+
+		Destinations []Destination `json:"destinations"`
+		Rules        []TargetRule  `json:"rules"`
+		NotBefore    *time.Time    `json:"not_before"`
+		MaxClicks    *int          `json:"max_clicks"`
+		Domain       string        `json:"domain"`
 	}
 
 	var urlAlias URLAlias
@@ -49,6 +109,17 @@ func (url *URLBase) UnmarshalJSON(data []byte) error {
 	}
 	url.Original = urlAlias.Original
 	url.URLID = urlAlias.URLID
+	url.Tags = urlAlias.Tags
+	url.Folder = urlAlias.Folder
+	url.Title = urlAlias.Title
+	url.Description = urlAlias.Description
+	url.PassQuery = urlAlias.PassQuery
+	url.PassPath = urlAlias.PassPath
+	url.Destinations = urlAlias.Destinations
+	url.Rules = urlAlias.Rules
+	url.NotBefore = urlAlias.NotBefore
+	url.MaxClicks = urlAlias.MaxClicks
+	url.Domain = urlAlias.Domain
 	return nil
 }
 
@@ -59,6 +130,22 @@ type URLJSON struct {
 	Original    string
 	URLID       string
 	DeletedFlag bool
+	Tags        []string
+	Folder      string
+	Title       string
+	Description string
+	PassQuery   *bool
+	PassPath    *bool
+
+	Destinations []Destination
+	Rules        []TargetRule
+	NotBefore    *time.Time
+	MaxClicks    *int
+	Health       *URLHealth
+	DisabledFlag bool
+	CreatedAt    time.Time
+	Clicks       int64
+	Domain       string
 }
 
 // MarshalJSON - метод для сериализации модели URL.
@@ -75,7 +162,19 @@ func (url URLJSON) MarshalJSON() ([]byte, error) {
 // UnmarshalJSON - метод для десериализации модели URL.
 func (url *URLJSON) UnmarshalJSON(data []byte) error {
 	type URLAlias struct {
-		Original string `json:"url"`
+		Original    string   `json:"url"`
+		Tags        []string `json:"tags"`
+		Folder      string   `json:"folder"`
+		Title       string   `json:"title"`
+		Description string   `json:"description"`
+		PassQuery   *bool    `json:"pass_query"`
+		PassPath    *bool    `json:"pass_path"`
+
+		Destinations []Destination `json:"destinations"`
+		Rules        []TargetRule  `json:"rules"`
+		NotBefore    *time.Time    `json:"not_before"`
+		MaxClicks    *int          `json:"max_clicks"`
+		Domain       string        `json:"domain"`
 	}
 
 	var urlAlias URLAlias
@@ -84,25 +183,258 @@ func (url *URLJSON) UnmarshalJSON(data []byte) error {
 		return err
 	}
 	url.Original = urlAlias.Original
+	url.Tags = urlAlias.Tags
+	url.Folder = urlAlias.Folder
+	url.Title = urlAlias.Title
+	url.Description = urlAlias.Description
+	url.PassQuery = urlAlias.PassQuery
+	url.PassPath = urlAlias.PassPath
+	url.Destinations = urlAlias.Destinations
+	url.Rules = urlAlias.Rules
+	url.NotBefore = urlAlias.NotBefore
+	url.MaxClicks = urlAlias.MaxClicks
+	url.Domain = urlAlias.Domain
 	return nil
 }
 
 // URLStorage - сопутствующая модель для сущности url.
 type URLStorage struct {
-	UUID        string `json:"uuid"`
-	Short       string `json:"url_short"`
-	Original    string `json:"url_original"`
-	URLID       string `json:"-"`
-	DeletedFlag bool   `json:"-"`
+	UUID        string   `json:"uuid"`
+	Short       string   `json:"url_short"`
+	Original    string   `json:"url_original"`
+	URLID       string   `json:"-"`
+	DeletedFlag bool     `json:"is_deleted,omitempty"`
+	Tags        []string `json:"tags,omitempty"`
+	Folder      string   `json:"folder,omitempty"`
+	Title       string   `json:"title,omitempty"`
+	Description string   `json:"description,omitempty"`
+	PassQuery   *bool    `json:"pass_query,omitempty"`
+	PassPath    *bool    `json:"pass_path,omitempty"`
+
+	Destinations []Destination `json:"destinations,omitempty"`
+	Rules        []TargetRule  `json:"rules,omitempty"`
+	NotBefore    *time.Time    `json:"not_before,omitempty"`
+	MaxClicks    *int          `json:"max_clicks,omitempty"`
+	// результат проверки доступности не сохраняется в файл-хранилище
+	Health       *URLHealth `json:"-"`
+	DisabledFlag bool       `json:"is_disabled,omitempty"`
+	CreatedAt    time.Time  `json:"created_at"`
+	// счетчик переходов хранится в памяти и не сохраняется в файл-хранилище
+	Clicks int64  `json:"-"`
+	Domain string `json:"domain,omitempty"`
 }
 
 // URLGetAll - модель URL.
 type URLGetAll struct {
-	UUID        string `json:"-"`
-	Short       string `json:"short_url"`
-	Original    string `json:"original_url"`
-	URLID       string `json:"-"`
-	DeletedFlag bool   `json:"-"`
+	UUID        string   `json:"-"`
+	Short       string   `json:"short_url"`
+	Original    string   `json:"original_url"`
+	URLID       string   `json:"-"`
+	DeletedFlag bool     `json:"-"`
+	Tags        []string `json:"tags,omitempty"`
+	Folder      string   `json:"folder,omitempty"`
+	Title       string   `json:"title,omitempty"`
+	Description string   `json:"description,omitempty"`
+	PassQuery   *bool    `json:"pass_query,omitempty"`
+	PassPath    *bool    `json:"pass_path,omitempty"`
+
+	Destinations []Destination `json:"destinations,omitempty"`
+	Rules        []TargetRule  `json:"rules,omitempty"`
+	NotBefore    *time.Time    `json:"not_before,omitempty"`
+	MaxClicks    *int          `json:"max_clicks,omitempty"`
+	Health       *URLHealth    `json:"health,omitempty"`
+	DisabledFlag bool          `json:"disabled,omitempty"`
+	CreatedAt    time.Time     `json:"-"`
+	Clicks       int64         `json:"-"`
+	Domain       string        `json:"domain,omitempty"`
+}
+
+// URLFilter - параметры отбора URL пользователя.
+type URLFilter struct {
+	Tag    string
+	Folder string
+}
+
+// URLCursor - параметры постраничного получения URL пользователя.
+type URLCursor struct {
+	Filter URLFilter
+	// After - позиция последнего URL предыдущей страницы, 0 - с начала списка
+	After int64
+	Limit int
+}
+
+// URLPage - страница URL пользователя.
+type URLPage struct {
+	URLs []URLBase
+	// NextToken - токен продолжения, пустое значение - URL больше нет
+	NextToken string
+}
+
+// Способы поиска URL пользователя по оригинальному URL.
+const (
+	LookupExact    = "exact"
+	LookupHost     = "host"
+	LookupPrefix   = "prefix"
+	LookupContains = "contains"
+)
+
+// URLLookup - параметры поиска URL пользователя по оригинальному URL.
+type URLLookup struct {
+	// Mode - способ поиска: точное совпадение, хост (включая поддомены), префикс или подстрока
+	Mode  string
+	Query string
+	Limit int
+}
+
+// AdminFilter - параметры отбора URL всех пользователей.
+type AdminFilter struct {
+	// UserID - идентификатор пользователя, пустое значение - все пользователи
+	UserID string
+	// Query - подстрока оригинального URL или короткий URL
+	Query  string
+	Limit  int
+	Offset int
+}
+
+// AdminURL - модель URL для администратора.
+type AdminURL struct {
+	UUID         string `json:"user_id"`
+	Short        string `json:"short_url"`
+	Original     string `json:"original_url"`
+	DeletedFlag  bool   `json:"is_deleted"`
+	DisabledFlag bool   `json:"is_disabled"`
+}
+
+// URLUpdate - модель изменения атрибутов сокращенного URL.
+// Поля со значением nil не изменяются.
+type URLUpdate struct {
+	UUID        string    `json:"-"`
+	Short       string    `json:"-"`
+	Domain      string    `json:"-"`
+	Tags        *[]string `json:"tags"`
+	Folder      *string   `json:"folder"`
+	Title       *string   `json:"title"`
+	Description *string   `json:"description"`
+	PassQuery   *bool     `json:"pass_query"`
+	PassPath    *bool     `json:"pass_path"`
+
+	Destinations *[]Destination `json:"destinations"`
+	Rules        *[]TargetRule  `json:"rules"`
+	NotBefore    *time.Time     `json:"not_before"`
+	MaxClicks    *int           `json:"max_clicks"`
+}
+
+// BatchItemError - ошибка сохранения отдельного URL из пакета.
+type BatchItemError struct {
+	// Index - позиция URL в сохраняемом пакете
+	Index int
+	Err   error
+}
+
+// Error - текст ошибки с позицией URL в пакете.
+func (e *BatchItemError) Error() string {
+	return fmt.Sprintf("batch item %d: %v", e.Index, e.Err)
+}
+
+// Unwrap - исходная ошибка сохранения URL.
+func (e *BatchItemError) Unwrap() error {
+	return e.Err
+}
+
+// Результаты сокращения URL при массовой загрузке.
+const (
+	BulkCreated  = "created"
+	BulkExisting = "existing"
+	BulkInvalid  = "invalid"
+)
+
+// BulkResult - результат сокращения одного URL при массовой загрузке.
+type BulkResult struct {
+	URLID  string
+	Short  string
+	Domain string
+	// Status - created, existing или invalid
+	Status string
+}
+
+// RedirectRequest - параметры входящего запроса на перенаправление.
+type RedirectRequest struct {
+	// Domain - домен, в пространстве имен которого ищется короткий URL
+	Domain    string
+	Short     string
+	RawQuery  string
+	ExtraPath string
+	VisitorID string
+
+	UserAgent      string
+	AcceptLanguage string
+}
+
+// RedirectResult - результат выбора адреса перенаправления.
+type RedirectResult struct {
+	URL string
+	// Sticky - адрес выбран по идентификатору посетителя среди нескольких адресов назначения
+	Sticky bool
+	// Targeted - адрес зависит от платформы и языка клиента
+	Targeted bool
+}
+
+// События жизненного цикла URL, на которые можно подписаться.
+const (
+	EventURLCreated  = "url.created"
+	EventURLFollowed = "url.followed"
+	EventURLDeleted  = "url.deleted"
+)
+
+// WebhookEvents - допустимые типы событий.
+var WebhookEvents = []string{EventURLCreated, EventURLFollowed, EventURLDeleted}
+
+// Статусы доставки события.
+const (
+	DeliveryPending   = "pending"
+	DeliveryDelivered = "delivered"
+	DeliveryFailed    = "failed"
+)
+
+// Webhook - подписка пользователя на события жизненного цикла URL.
+// Пустой список событий означает подписку на все события.
+type Webhook struct {
+	ID        string    `json:"id"`
+	UUID      string    `json:"-"`
+	URL       string    `json:"url"`
+	Events    []string  `json:"events,omitempty"`
+	Secret    string    `json:"secret,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// WebhookEvent - событие жизненного цикла URL.
+type WebhookEvent struct {
+	ID         string    `json:"id"`
+	Type       string    `json:"type"`
+	UserID     string    `json:"user_id"`
+	Short      string    `json:"short_url"`
+	Domain     string    `json:"domain,omitempty"`
+	Original   string    `json:"original_url,omitempty"`
+	OccurredAt time.Time `json:"occurred_at"`
+}
+
+// WebhookDelivery - доставка события на адрес подписки.
+type WebhookDelivery struct {
+	ID             string    `json:"id"`
+	WebhookID      string    `json:"webhook_id"`
+	EventType      string    `json:"event_type"`
+	Payload        []byte    `json:"-"`
+	Status         string    `json:"status"`
+	Attempts       int       `json:"attempts"`
+	LastStatusCode int       `json:"last_status_code,omitempty"`
+	LastError      string    `json:"last_error,omitempty"`
+	NextAttemptAt  time.Time `json:"next_attempt_at"`
+	CreatedAt      time.Time `json:"created_at"`
+	UpdatedAt      time.Time `json:"updated_at"`
+
+	// адрес и секрет подписки заполняются при выборке доставок для отправки
+	URL    string `json:"-"`
+	Secret string `json:"-"`
 }
 
 // Pooler - интерфейс для пула.
@@ -137,16 +469,46 @@ func (p *Pool[T]) Put(t T) {
 	p.Buf = append(p.Buf, t)
 }
 
+// StatsFilter - параметры расчета статистики.
+// Период [From, To) ограничивает ссылки по времени создания при расчете
+// количества ссылок по дням, самых посещаемых ссылок и самых активных пользователей.
+type StatsFilter struct {
+	From time.Time
+	To   time.Time
+	// Top - количество записей в рейтингах
+	Top int
+}
+
+// DailyStats - количество ссылок, созданных за день.
+type DailyStats struct {
+	Date  string `json:"date"`
+	Count int    `json:"count"`
+}
+
+// URLClicks - количество переходов по ссылке.
+type URLClicks struct {
+	Short    string `json:"short_url"`
+	Original string `json:"original_url"`
+	Clicks   int64  `json:"clicks"`
+}
+
+// UserStats - количество ссылок, созданных пользователем.
+type UserStats struct {
+	UUID  string `json:"user_id"`
+	Count int    `json:"urls"`
+}
+
 // Stats - модель статистики.
 type Stats struct {
-	CountURL   int `json:"urls"`
-	CountUsers int `json:"users"`
-}
+	CountURL     int   `json:"urls"`
+	CountUsers   int   `json:"users"`
+	CountActive  int   `json:"active_urls"`
+	CountDeleted int   `json:"deleted_urls"`
+	StorageBytes int64 `json:"storage_bytes"`
 
-// NewStats - конструктор модели статистики.
-func NewStats(countURLs, countUsers int) *Stats {
-	return &Stats{
-		CountURL:   countURLs,
-		CountUsers: countUsers,
-	}
+	From     time.Time    `json:"from"`
+	To       time.Time    `json:"to"`
+	PerDay   []DailyStats `json:"per_day"`
+	TopURLs  []URLClicks  `json:"top_urls"`
+	TopUsers []UserStats  `json:"top_users"`
 }
@@ -0,0 +1,91 @@
+// Package netguard реализует HTTP-клиент для запросов по адресам, заданным пользователями,
+// соединяющийся только с публичными адресами.
+package netguard
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/netip"
+	"syscall"
+	"time"
+)
+
+// ErrForbiddenAddress - адрес назначения не является публичным.
+var ErrForbiddenAddress = errors.New("forbidden destination address")
+
+// Resolver - интерфейс разрешения имени хоста в адреса, реализуемый *net.Resolver.
+type Resolver interface {
+	LookupNetIP(ctx context.Context, network, host string) ([]netip.Addr, error)
+}
+
+// sharedAddressSpace - диапазон адресов операторского NAT (RFC 6598).
+var sharedAddressSpace = netip.MustParsePrefix("100.64.0.0/10")
+
+// NewPublicClient - создание HTTP-клиента, соединяющегося только с публичными адресами.
+// Адрес проверяется при установке соединения, поэтому проверка распространяется
+// на перенаправления и на имена, разрешающиеся во внутренние адреса.
+func NewPublicClient() *http.Client {
+	dialer := &net.Dialer{
+		Timeout: 30 * time.Second,
+		Control: publicOnly,
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	// соединение через прокси не позволило бы проверить адрес назначения
+	transport.Proxy = nil
+	transport.DialContext = dialer.DialContext
+
+	return &http.Client{Transport: transport}
+}
+
+// publicOnly - проверка адреса перед установкой соединения.
+func publicOnly(_, address string, _ syscall.RawConn) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return fmt.Errorf("path: internal/netguard/netguard.go, func publicOnly(), failed to split address: %w", err)
+	}
+
+	addr, err := netip.ParseAddr(host)
+	if err != nil {
+		return fmt.Errorf("path: internal/netguard/netguard.go, func publicOnly(), failed to parse address: %w", err)
+	}
+	if !IsPublic(addr) {
+		return fmt.Errorf("path: internal/netguard/netguard.go, func publicOnly(), %s: %w", addr, ErrForbiddenAddress)
+	}
+	return nil
+}
+
+// IsPublic - проверка, что адрес не относится к локальным, частным и служебным сетям.
+func IsPublic(addr netip.Addr) bool {
+	addr = addr.Unmap()
+	return addr.IsGlobalUnicast() &&
+		!addr.IsPrivate() &&
+		!sharedAddressSpace.Contains(addr)
+}
+
+// CheckHost - проверка, что хост является публичным адресом или разрешается только в публичные адреса.
+// Проверка при регистрации адреса не заменяет NewPublicClient: адрес имени может измениться позже.
+func CheckHost(ctx context.Context, resolver Resolver, host string) error {
+	addrs := make([]netip.Addr, 0, 1)
+	if addr, err := netip.ParseAddr(host); err == nil {
+		addrs = append(addrs, addr)
+	} else {
+		addrs, err = resolver.LookupNetIP(ctx, "ip", host)
+		if err != nil {
+			return fmt.Errorf("path: internal/netguard/netguard.go, func CheckHost(), failed to resolve %s: %w", host, err)
+		}
+	}
+
+	if len(addrs) == 0 {
+		return fmt.Errorf("path: internal/netguard/netguard.go, func CheckHost(), %s has no addresses: %w", host, ErrForbiddenAddress)
+	}
+	for _, addr := range addrs {
+		if !IsPublic(addr) {
+			return fmt.Errorf("path: internal/netguard/netguard.go, func CheckHost(), %s resolves to %s: %w", host, addr, ErrForbiddenAddress)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,93 @@
+package netguard
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewPublicClient(t *testing.T) {
+	var requested bool
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requested = true
+	}))
+	defer server.Close()
+
+	req, err := http.NewRequestWithContext(context.Background(), http.MethodGet, server.URL, nil)
+	require.NoError(t, err)
+
+	_, err = NewPublicClient().Do(req)
+	assert.ErrorIs(t, err, ErrForbiddenAddress)
+	assert.False(t, requested, "request reached loopback server")
+}
+
+func TestIsPublic(t *testing.T) {
+	tests := []struct {
+		name string
+		addr string
+		want bool
+	}{
+		{name: "публичный IPv4", addr: "93.184.216.34", want: true},
+		{name: "публичный IPv6", addr: "2606:2800:220:1:248:1893:25c8:1946", want: true},
+		{name: "loopback", addr: "127.0.0.1"},
+		{name: "loopback IPv6", addr: "::1"},
+		{name: "частная сеть", addr: "10.1.2.3"},
+		{name: "частная сеть 192.168.0.0/16", addr: "192.168.0.10"},
+		{name: "link-local (метаданные облака)", addr: "169.254.169.254"},
+		{name: "link-local IPv6", addr: "fe80::1"},
+		{name: "unique local IPv6", addr: "fd00::1"},
+		{name: "операторский NAT", addr: "100.64.0.1"},
+		{name: "неопределенный адрес", addr: "0.0.0.0"},
+		{name: "IPv4 в IPv6", addr: "::ffff:127.0.0.1"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, IsPublic(netip.MustParseAddr(tt.addr)))
+		})
+	}
+}
+
+// resolverStub - разрешение имен хостов для тестов.
+type resolverStub map[string][]netip.Addr
+
+func (r resolverStub) LookupNetIP(ctx context.Context, network, host string) ([]netip.Addr, error) {
+	addrs, ok := r[host]
+	if !ok {
+		return nil, errors.New("no such host")
+	}
+	return addrs, nil
+}
+
+func TestCheckHost(t *testing.T) {
+	resolver := resolverStub{
+		"hooks.example.com":    {netip.MustParseAddr("93.184.216.34")},
+		"internal.example.com": {netip.MustParseAddr("93.184.216.34"), netip.MustParseAddr("10.0.0.1")},
+		"empty.example.com":    {},
+	}
+
+	tests := []struct {
+		name    string
+		host    string
+		wantErr bool
+	}{
+		{name: "имя разрешается в публичный адрес", host: "hooks.example.com"},
+		{name: "публичный адрес", host: "93.184.216.34"},
+		{name: "имя разрешается во внутренний адрес", host: "internal.example.com", wantErr: true},
+		{name: "имя не разрешается", host: "missing.example.com", wantErr: true},
+		{name: "имя без адресов", host: "empty.example.com", wantErr: true},
+		{name: "loopback", host: "127.0.0.1", wantErr: true},
+		{name: "метаданные облака", host: "169.254.169.254", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := CheckHost(context.Background(), resolver, tt.host)
+			assert.Equal(t, tt.wantErr, err != nil, "CheckHost() = %v", err)
+		})
+	}
+}
@@ -0,0 +1,123 @@
+// Package pagetitle реализует получение заголовка (<title>) веб-страницы
+// с ограничением по времени, объему загружаемых данных и числу одновременных запросов.
+package pagetitle
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"html"
+	"io"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// Ошибки получения заголовка.
+var (
+	// Превышено число одновременных запросов
+	ErrBusy = errors.New("too many concurrent title fetches")
+	// Схема URL не поддерживается
+	ErrUnsupportedScheme = errors.New("unsupported URL scheme")
+	// Заголовок не найден
+	ErrNoTitle = errors.New("title not found")
+)
+
+// maxTitleLength - максимальная длина заголовка в символах.
+const maxTitleLength = 255
+
+var titleRegexp = regexp.MustCompile(`(?is)<title[^>]*>(.*?)</title>`)
+
+// Fetcher - структура для получения заголовка страницы.
+type Fetcher struct {
+	client   *http.Client
+	timeout  time.Duration
+	maxBytes int64
+	slots    chan struct{}
+}
+
+// NewFetcher - создание структуры Fetcher.
+func NewFetcher(client *http.Client, timeout time.Duration, maxBytes int64, concurrency int) *Fetcher {
+	if concurrency <= 0 {
+		concurrency = 1
+	}
+	return &Fetcher{
+		client:   client,
+		timeout:  timeout,
+		maxBytes: maxBytes,
+		slots:    make(chan struct{}, concurrency),
+	}
+}
+
+// Fetch - получение заголовка страницы.
+// Если все слоты заняты, запрос не выполняется и возвращается ErrBusy.
+func (f *Fetcher) Fetch(ctx context.Context, pageURL string) (string, error) {
+	parsedURL, err := url.Parse(pageURL)
+	if err != nil {
+		return "", fmt.Errorf("path: internal/pagetitle/pagetitle.go, func Fetch(), failed to parse url: %w", err)
+	}
+	if parsedURL.Scheme != "http" && parsedURL.Scheme != "https" {
+		return "", ErrUnsupportedScheme
+	}
+
+	select {
+	case f.slots <- struct{}{}:
+		defer func() { <-f.slots }()
+	default:
+		return "", ErrBusy
+	}
+
+	if f.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, f.timeout)
+		defer cancel()
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, parsedURL.String(), nil)
+	if err != nil {
+		return "", fmt.Errorf("path: internal/pagetitle/pagetitle.go, func Fetch(), failed to create request: %w", err)
+	}
+	req.Header.Set("Accept", "text/html")
+
+	resp, err := f.client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("path: internal/pagetitle/pagetitle.go, func Fetch(), request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return "", fmt.Errorf("path: internal/pagetitle/pagetitle.go, func Fetch(), unexpected status code: %d", resp.StatusCode)
+	}
+
+	var body io.Reader = resp.Body
+	if f.maxBytes > 0 {
+		body = io.LimitReader(resp.Body, f.maxBytes)
+	}
+
+	data, err := io.ReadAll(body)
+	if err != nil {
+		return "", fmt.Errorf("path: internal/pagetitle/pagetitle.go, func Fetch(), failed to read body: %w", err)
+	}
+
+	return extractTitle(data)
+}
+
+// extractTitle - извлечение заголовка из HTML-документа.
+func extractTitle(data []byte) (string, error) {
+	match := titleRegexp.FindSubmatch(data)
+	if match == nil {
+		return "", ErrNoTitle
+	}
+
+	title := strings.Join(strings.Fields(html.UnescapeString(string(match[1]))), " ")
+	if title == "" {
+		return "", ErrNoTitle
+	}
+
+	if runes := []rune(title); len(runes) > maxTitleLength {
+		title = string(runes[:maxTitleLength])
+	}
+	return title, nil
+}
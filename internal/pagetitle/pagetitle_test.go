@@ -0,0 +1,121 @@
+package pagetitle
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Di-nis/shortener-url/internal/netguard"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFetcher_Fetch(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/page", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("<html><head><TITLE>\n  Хоккей &amp; КХЛ\n</TITLE></head><body></body></html>"))
+	})
+	mux.HandleFunc("/no-title", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("<html><head></head><body>text</body></html>"))
+	})
+	mux.HandleFunc("/large", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("<html><head>" + strings.Repeat(" ", 1024) + "<title>late</title></head></html>"))
+	})
+	mux.HandleFunc("/slow", func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-time.After(time.Second):
+		case <-r.Context().Done():
+		}
+	})
+	mux.HandleFunc("/not-found", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	})
+
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	fetcher := NewFetcher(server.Client(), 100*time.Millisecond, 512, 2)
+
+	tests := []struct {
+		name    string
+		url     string
+		want    string
+		wantErr error
+		anyErr  bool
+	}{
+		{
+			name: "заголовок получен",
+			url:  server.URL + "/page",
+			want: "Хоккей & КХЛ",
+		},
+		{
+			name:    "заголовок отсутствует",
+			url:     server.URL + "/no-title",
+			wantErr: ErrNoTitle,
+		},
+		{
+			name:    "заголовок за пределами допустимого объема",
+			url:     server.URL + "/large",
+			wantErr: ErrNoTitle,
+		},
+		{
+			name:   "превышено время ожидания",
+			url:    server.URL + "/slow",
+			anyErr: true,
+		},
+		{
+			name:   "страница не найдена",
+			url:    server.URL + "/not-found",
+			anyErr: true,
+		},
+		{
+			name:    "неподдерживаемая схема",
+			url:     "ftp://example.com/file",
+			wantErr: ErrUnsupportedScheme,
+		},
+		{
+			name:    "URL без схемы",
+			url:     "google.ru",
+			wantErr: ErrUnsupportedScheme,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, gotErr := fetcher.Fetch(context.Background(), tt.url)
+
+			assert.Equal(t, tt.want, got)
+			switch {
+			case tt.wantErr != nil:
+				assert.True(t, errors.Is(gotErr, tt.wantErr), "error = %v, want %v", gotErr, tt.wantErr)
+			case tt.anyErr:
+				assert.Error(t, gotErr)
+			default:
+				assert.NoError(t, gotErr)
+			}
+		})
+	}
+}
+
+func TestFetcher_FetchBusy(t *testing.T) {
+	fetcher := NewFetcher(http.DefaultClient, time.Second, 512, 1)
+	fetcher.slots <- struct{}{}
+
+	_, err := fetcher.Fetch(context.Background(), "http://example.com")
+	assert.ErrorIs(t, err, ErrBusy)
+}
+
+func TestFetcher_FetchPublicClient(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("<title>internal</title>"))
+	}))
+	defer server.Close()
+
+	fetcher := NewFetcher(netguard.NewPublicClient(), time.Second, 512, 1)
+
+	got, err := fetcher.Fetch(context.Background(), server.URL)
+	assert.Empty(t, got)
+	assert.ErrorIs(t, err, netguard.ErrForbiddenAddress)
+}
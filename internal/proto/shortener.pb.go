@@ -12,7 +12,7 @@ import (
 
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
-	emptypb "google.golang.org/protobuf/types/known/emptypb"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
 )
 
 const (
@@ -23,12 +23,19 @@ const (
 )
 
 type URLShortenRequest struct {
-	state                  protoimpl.MessageState `protogen:"opaque.v1"`
-	xxx_hidden_Url         *string                `protobuf:"bytes,1,opt,name=url"`
-	XXX_raceDetectHookData protoimpl.RaceDetectHookData
-	XXX_presence           [1]uint32
-	unknownFields          protoimpl.UnknownFields
-	sizeCache              protoimpl.SizeCache
+	state                   protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Url          *string                `protobuf:"bytes,1,opt,name=url"`
+	xxx_hidden_Tags         []string               `protobuf:"bytes,2,rep,name=tags"`
+	xxx_hidden_Folder       *string                `protobuf:"bytes,3,opt,name=folder"`
+	xxx_hidden_Title        *string                `protobuf:"bytes,4,opt,name=title"`
+	xxx_hidden_Description  *string                `protobuf:"bytes,5,opt,name=description"`
+	xxx_hidden_Destinations *[]*Destination        `protobuf:"bytes,6,rep,name=destinations"`
+	xxx_hidden_Rules        *[]*TargetRule         `protobuf:"bytes,7,rep,name=rules"`
+	xxx_hidden_Domain       *string                `protobuf:"bytes,8,opt,name=domain"`
+	XXX_raceDetectHookData  protoimpl.RaceDetectHookData
+	XXX_presence            [1]uint32
+	unknownFields           protoimpl.UnknownFields
+	sizeCache               protoimpl.SizeCache
 }
 
 func (x *URLShortenRequest) Reset() {
@@ -66,9 +73,106 @@ func (x *URLShortenRequest) GetUrl() string {
 	return ""
 }
 
+func (x *URLShortenRequest) GetTags() []string {
+	if x != nil {
+		return x.xxx_hidden_Tags
+	}
+	return nil
+}
+
+func (x *URLShortenRequest) GetFolder() string {
+	if x != nil {
+		if x.xxx_hidden_Folder != nil {
+			return *x.xxx_hidden_Folder
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *URLShortenRequest) GetTitle() string {
+	if x != nil {
+		if x.xxx_hidden_Title != nil {
+			return *x.xxx_hidden_Title
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *URLShortenRequest) GetDescription() string {
+	if x != nil {
+		if x.xxx_hidden_Description != nil {
+			return *x.xxx_hidden_Description
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *URLShortenRequest) GetDestinations() []*Destination {
+	if x != nil {
+		if x.xxx_hidden_Destinations != nil {
+			return *x.xxx_hidden_Destinations
+		}
+	}
+	return nil
+}
+
+func (x *URLShortenRequest) GetRules() []*TargetRule {
+	if x != nil {
+		if x.xxx_hidden_Rules != nil {
+			return *x.xxx_hidden_Rules
+		}
+	}
+	return nil
+}
+
+func (x *URLShortenRequest) GetDomain() string {
+	if x != nil {
+		if x.xxx_hidden_Domain != nil {
+			return *x.xxx_hidden_Domain
+		}
+		return ""
+	}
+	return ""
+}
+
 func (x *URLShortenRequest) SetUrl(v string) {
 	x.xxx_hidden_Url = &v
-	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 0, 1)
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 0, 8)
+}
+
+func (x *URLShortenRequest) SetTags(v []string) {
+	x.xxx_hidden_Tags = v
+}
+
+func (x *URLShortenRequest) SetFolder(v string) {
+	x.xxx_hidden_Folder = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 2, 8)
+}
+
+func (x *URLShortenRequest) SetTitle(v string) {
+	x.xxx_hidden_Title = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 3, 8)
+}
+
+func (x *URLShortenRequest) SetDescription(v string) {
+	x.xxx_hidden_Description = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 4, 8)
+}
+
+func (x *URLShortenRequest) SetDestinations(v []*Destination) {
+	x.xxx_hidden_Destinations = &v
+}
+
+func (x *URLShortenRequest) SetRules(v []*TargetRule) {
+	x.xxx_hidden_Rules = &v
+}
+
+func (x *URLShortenRequest) SetDomain(v string) {
+	x.xxx_hidden_Domain = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 7, 8)
 }
 
 func (x *URLShortenRequest) HasUrl() bool {
@@ -78,15 +182,71 @@ func (x *URLShortenRequest) HasUrl() bool {
 	return protoimpl.X.Present(&(x.XXX_presence[0]), 0)
 }
 
+func (x *URLShortenRequest) HasFolder() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 2)
+}
+
+func (x *URLShortenRequest) HasTitle() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 3)
+}
+
+func (x *URLShortenRequest) HasDescription() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 4)
+}
+
+func (x *URLShortenRequest) HasDomain() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 7)
+}
+
 func (x *URLShortenRequest) ClearUrl() {
 	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 0)
 	x.xxx_hidden_Url = nil
 }
 
+func (x *URLShortenRequest) ClearFolder() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 2)
+	x.xxx_hidden_Folder = nil
+}
+
+func (x *URLShortenRequest) ClearTitle() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 3)
+	x.xxx_hidden_Title = nil
+}
+
+func (x *URLShortenRequest) ClearDescription() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 4)
+	x.xxx_hidden_Description = nil
+}
+
+func (x *URLShortenRequest) ClearDomain() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 7)
+	x.xxx_hidden_Domain = nil
+}
+
 type URLShortenRequest_builder struct {
 	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
 
-	Url *string
+	Url          *string
+	Tags         []string
+	Folder       *string
+	Title        *string
+	Description  *string
+	Destinations []*Destination
+	Rules        []*TargetRule
+	// домен короткого URL, пустое значение - домен по умолчанию
+	Domain *string
 }
 
 func (b0 URLShortenRequest_builder) Build() *URLShortenRequest {
@@ -94,9 +254,28 @@ func (b0 URLShortenRequest_builder) Build() *URLShortenRequest {
 	b, x := &b0, m0
 	_, _ = b, x
 	if b.Url != nil {
-		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 0, 1)
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 0, 8)
 		x.xxx_hidden_Url = b.Url
 	}
+	x.xxx_hidden_Tags = b.Tags
+	if b.Folder != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 2, 8)
+		x.xxx_hidden_Folder = b.Folder
+	}
+	if b.Title != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 3, 8)
+		x.xxx_hidden_Title = b.Title
+	}
+	if b.Description != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 4, 8)
+		x.xxx_hidden_Description = b.Description
+	}
+	x.xxx_hidden_Destinations = &b.Destinations
+	x.xxx_hidden_Rules = &b.Rules
+	if b.Domain != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 7, 8)
+		x.xxx_hidden_Domain = b.Domain
+	}
 	return m0
 }
 
@@ -181,6 +360,7 @@ func (b0 URLShortenResponse_builder) Build() *URLShortenResponse {
 type URLExpandRequest struct {
 	state                  protoimpl.MessageState `protogen:"opaque.v1"`
 	xxx_hidden_Id          *string                `protobuf:"bytes,1,opt,name=id"`
+	xxx_hidden_Domain      *string                `protobuf:"bytes,2,opt,name=domain"`
 	XXX_raceDetectHookData protoimpl.RaceDetectHookData
 	XXX_presence           [1]uint32
 	unknownFields          protoimpl.UnknownFields
@@ -222,9 +402,24 @@ func (x *URLExpandRequest) GetId() string {
 	return ""
 }
 
+func (x *URLExpandRequest) GetDomain() string {
+	if x != nil {
+		if x.xxx_hidden_Domain != nil {
+			return *x.xxx_hidden_Domain
+		}
+		return ""
+	}
+	return ""
+}
+
 func (x *URLExpandRequest) SetId(v string) {
 	x.xxx_hidden_Id = &v
-	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 0, 1)
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 0, 2)
+}
+
+func (x *URLExpandRequest) SetDomain(v string) {
+	x.xxx_hidden_Domain = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 1, 2)
 }
 
 func (x *URLExpandRequest) HasId() bool {
@@ -234,15 +429,29 @@ func (x *URLExpandRequest) HasId() bool {
 	return protoimpl.X.Present(&(x.XXX_presence[0]), 0)
 }
 
+func (x *URLExpandRequest) HasDomain() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 1)
+}
+
 func (x *URLExpandRequest) ClearId() {
 	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 0)
 	x.xxx_hidden_Id = nil
 }
 
+func (x *URLExpandRequest) ClearDomain() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 1)
+	x.xxx_hidden_Domain = nil
+}
+
 type URLExpandRequest_builder struct {
 	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
 
 	Id *string
+	// домен короткого URL, пустое значение - домен по умолчанию
+	Domain *string
 }
 
 func (b0 URLExpandRequest_builder) Build() *URLExpandRequest {
@@ -250,9 +459,13 @@ func (b0 URLExpandRequest_builder) Build() *URLExpandRequest {
 	b, x := &b0, m0
 	_, _ = b, x
 	if b.Id != nil {
-		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 0, 1)
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 0, 2)
 		x.xxx_hidden_Id = b.Id
 	}
+	if b.Domain != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 1, 2)
+		x.xxx_hidden_Domain = b.Domain
+	}
 	return m0
 }
 
@@ -334,27 +547,30 @@ func (b0 URLExpandResponse_builder) Build() *URLExpandResponse {
 	return m0
 }
 
-type UserURLsResponse struct {
-	state          protoimpl.MessageState `protogen:"opaque.v1"`
-	xxx_hidden_Url *[]*URLData            `protobuf:"bytes,1,rep,name=url"`
-	unknownFields  protoimpl.UnknownFields
-	sizeCache      protoimpl.SizeCache
+type ListUserURLsRequest struct {
+	state                  protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Tag         *string                `protobuf:"bytes,1,opt,name=tag"`
+	xxx_hidden_Folder      *string                `protobuf:"bytes,2,opt,name=folder"`
+	XXX_raceDetectHookData protoimpl.RaceDetectHookData
+	XXX_presence           [1]uint32
+	unknownFields          protoimpl.UnknownFields
+	sizeCache              protoimpl.SizeCache
 }
 
-func (x *UserURLsResponse) Reset() {
-	*x = UserURLsResponse{}
+func (x *ListUserURLsRequest) Reset() {
+	*x = ListUserURLsRequest{}
 	mi := &file_internal_proto_shortener_proto_msgTypes[4]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *UserURLsResponse) String() string {
+func (x *ListUserURLsRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*UserURLsResponse) ProtoMessage() {}
+func (*ListUserURLsRequest) ProtoMessage() {}
 
-func (x *UserURLsResponse) ProtoReflect() protoreflect.Message {
+func (x *ListUserURLsRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_internal_proto_shortener_proto_msgTypes[4]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -366,57 +582,108 @@ func (x *UserURLsResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-func (x *UserURLsResponse) GetUrl() []*URLData {
+func (x *ListUserURLsRequest) GetTag() string {
 	if x != nil {
-		if x.xxx_hidden_Url != nil {
-			return *x.xxx_hidden_Url
+		if x.xxx_hidden_Tag != nil {
+			return *x.xxx_hidden_Tag
 		}
+		return ""
 	}
-	return nil
+	return ""
 }
 
-func (x *UserURLsResponse) SetUrl(v []*URLData) {
-	x.xxx_hidden_Url = &v
+func (x *ListUserURLsRequest) GetFolder() string {
+	if x != nil {
+		if x.xxx_hidden_Folder != nil {
+			return *x.xxx_hidden_Folder
+		}
+		return ""
+	}
+	return ""
 }
 
-type UserURLsResponse_builder struct {
+func (x *ListUserURLsRequest) SetTag(v string) {
+	x.xxx_hidden_Tag = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 0, 2)
+}
+
+func (x *ListUserURLsRequest) SetFolder(v string) {
+	x.xxx_hidden_Folder = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 1, 2)
+}
+
+func (x *ListUserURLsRequest) HasTag() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 0)
+}
+
+func (x *ListUserURLsRequest) HasFolder() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 1)
+}
+
+func (x *ListUserURLsRequest) ClearTag() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 0)
+	x.xxx_hidden_Tag = nil
+}
+
+func (x *ListUserURLsRequest) ClearFolder() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 1)
+	x.xxx_hidden_Folder = nil
+}
+
+type ListUserURLsRequest_builder struct {
 	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
 
-	Url []*URLData
+	Tag    *string
+	Folder *string
 }
 
-func (b0 UserURLsResponse_builder) Build() *UserURLsResponse {
-	m0 := &UserURLsResponse{}
+func (b0 ListUserURLsRequest_builder) Build() *ListUserURLsRequest {
+	m0 := &ListUserURLsRequest{}
 	b, x := &b0, m0
 	_, _ = b, x
-	x.xxx_hidden_Url = &b.Url
+	if b.Tag != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 0, 2)
+		x.xxx_hidden_Tag = b.Tag
+	}
+	if b.Folder != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 1, 2)
+		x.xxx_hidden_Folder = b.Folder
+	}
 	return m0
 }
 
-type URLData struct {
+type StreamUserURLsRequest struct {
 	state                  protoimpl.MessageState `protogen:"opaque.v1"`
-	xxx_hidden_ShortUrl    *string                `protobuf:"bytes,1,opt,name=short_url,json=shortUrl"`
-	xxx_hidden_OriginalUrl *string                `protobuf:"bytes,2,opt,name=original_url,json=originalUrl"`
+	xxx_hidden_Tag         *string                `protobuf:"bytes,1,opt,name=tag"`
+	xxx_hidden_Folder      *string                `protobuf:"bytes,2,opt,name=folder"`
+	xxx_hidden_PageSize    int32                  `protobuf:"varint,3,opt,name=page_size,json=pageSize"`
+	xxx_hidden_ResumeToken *string                `protobuf:"bytes,4,opt,name=resume_token,json=resumeToken"`
 	XXX_raceDetectHookData protoimpl.RaceDetectHookData
 	XXX_presence           [1]uint32
 	unknownFields          protoimpl.UnknownFields
 	sizeCache              protoimpl.SizeCache
 }
 
-func (x *URLData) Reset() {
-	*x = URLData{}
+func (x *StreamUserURLsRequest) Reset() {
+	*x = StreamUserURLsRequest{}
 	mi := &file_internal_proto_shortener_proto_msgTypes[5]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *URLData) String() string {
+func (x *StreamUserURLsRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*URLData) ProtoMessage() {}
+func (*StreamUserURLsRequest) ProtoMessage() {}
 
-func (x *URLData) ProtoReflect() protoreflect.Message {
+func (x *StreamUserURLsRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_internal_proto_shortener_proto_msgTypes[5]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -428,129 +695,2805 @@ func (x *URLData) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-func (x *URLData) GetShortUrl() string {
+func (x *StreamUserURLsRequest) GetTag() string {
 	if x != nil {
-		if x.xxx_hidden_ShortUrl != nil {
-			return *x.xxx_hidden_ShortUrl
+		if x.xxx_hidden_Tag != nil {
+			return *x.xxx_hidden_Tag
 		}
 		return ""
 	}
 	return ""
 }
 
-func (x *URLData) GetOriginalUrl() string {
+func (x *StreamUserURLsRequest) GetFolder() string {
 	if x != nil {
-		if x.xxx_hidden_OriginalUrl != nil {
-			return *x.xxx_hidden_OriginalUrl
+		if x.xxx_hidden_Folder != nil {
+			return *x.xxx_hidden_Folder
 		}
 		return ""
 	}
 	return ""
 }
 
-func (x *URLData) SetShortUrl(v string) {
-	x.xxx_hidden_ShortUrl = &v
-	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 0, 2)
+func (x *StreamUserURLsRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.xxx_hidden_PageSize
+	}
+	return 0
 }
 
-func (x *URLData) SetOriginalUrl(v string) {
-	x.xxx_hidden_OriginalUrl = &v
-	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 1, 2)
+func (x *StreamUserURLsRequest) GetResumeToken() string {
+	if x != nil {
+		if x.xxx_hidden_ResumeToken != nil {
+			return *x.xxx_hidden_ResumeToken
+		}
+		return ""
+	}
+	return ""
 }
 
-func (x *URLData) HasShortUrl() bool {
+func (x *StreamUserURLsRequest) SetTag(v string) {
+	x.xxx_hidden_Tag = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 0, 4)
+}
+
+func (x *StreamUserURLsRequest) SetFolder(v string) {
+	x.xxx_hidden_Folder = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 1, 4)
+}
+
+func (x *StreamUserURLsRequest) SetPageSize(v int32) {
+	x.xxx_hidden_PageSize = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 2, 4)
+}
+
+func (x *StreamUserURLsRequest) SetResumeToken(v string) {
+	x.xxx_hidden_ResumeToken = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 3, 4)
+}
+
+func (x *StreamUserURLsRequest) HasTag() bool {
 	if x == nil {
 		return false
 	}
 	return protoimpl.X.Present(&(x.XXX_presence[0]), 0)
 }
 
-func (x *URLData) HasOriginalUrl() bool {
+func (x *StreamUserURLsRequest) HasFolder() bool {
 	if x == nil {
 		return false
 	}
 	return protoimpl.X.Present(&(x.XXX_presence[0]), 1)
 }
 
-func (x *URLData) ClearShortUrl() {
+func (x *StreamUserURLsRequest) HasPageSize() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 2)
+}
+
+func (x *StreamUserURLsRequest) HasResumeToken() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 3)
+}
+
+func (x *StreamUserURLsRequest) ClearTag() {
 	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 0)
-	x.xxx_hidden_ShortUrl = nil
+	x.xxx_hidden_Tag = nil
 }
 
-func (x *URLData) ClearOriginalUrl() {
+func (x *StreamUserURLsRequest) ClearFolder() {
 	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 1)
-	x.xxx_hidden_OriginalUrl = nil
+	x.xxx_hidden_Folder = nil
 }
 
-type URLData_builder struct {
+func (x *StreamUserURLsRequest) ClearPageSize() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 2)
+	x.xxx_hidden_PageSize = 0
+}
+
+func (x *StreamUserURLsRequest) ClearResumeToken() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 3)
+	x.xxx_hidden_ResumeToken = nil
+}
+
+type StreamUserURLsRequest_builder struct {
 	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
 
-	ShortUrl    *string
-	OriginalUrl *string
+	Tag    *string
+	Folder *string
+	// число URL в одной части, по умолчанию 100
+	PageSize *int32
+	// токен продолжения из последней полученной части
+	ResumeToken *string
 }
 
-func (b0 URLData_builder) Build() *URLData {
-	m0 := &URLData{}
+func (b0 StreamUserURLsRequest_builder) Build() *StreamUserURLsRequest {
+	m0 := &StreamUserURLsRequest{}
 	b, x := &b0, m0
 	_, _ = b, x
-	if b.ShortUrl != nil {
-		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 0, 2)
-		x.xxx_hidden_ShortUrl = b.ShortUrl
+	if b.Tag != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 0, 4)
+		x.xxx_hidden_Tag = b.Tag
 	}
-	if b.OriginalUrl != nil {
-		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 1, 2)
-		x.xxx_hidden_OriginalUrl = b.OriginalUrl
+	if b.Folder != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 1, 4)
+		x.xxx_hidden_Folder = b.Folder
+	}
+	if b.PageSize != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 2, 4)
+		x.xxx_hidden_PageSize = *b.PageSize
+	}
+	if b.ResumeToken != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 3, 4)
+		x.xxx_hidden_ResumeToken = b.ResumeToken
 	}
 	return m0
 }
 
-var File_internal_proto_shortener_proto protoreflect.FileDescriptor
-
-const file_internal_proto_shortener_proto_rawDesc = "" +
+type UserURLsChunk struct {
+	state                  protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Url         *[]*URLData            `protobuf:"bytes,1,rep,name=url"`
+	xxx_hidden_ResumeToken *string                `protobuf:"bytes,2,opt,name=resume_token,json=resumeToken"`
+	XXX_raceDetectHookData protoimpl.RaceDetectHookData
+	XXX_presence           [1]uint32
+	unknownFields          protoimpl.UnknownFields
+	sizeCache              protoimpl.SizeCache
+}
+
+func (x *UserURLsChunk) Reset() {
+	*x = UserURLsChunk{}
+	mi := &file_internal_proto_shortener_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UserURLsChunk) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UserURLsChunk) ProtoMessage() {}
+
+func (x *UserURLsChunk) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_proto_shortener_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *UserURLsChunk) GetUrl() []*URLData {
+	if x != nil {
+		if x.xxx_hidden_Url != nil {
+			return *x.xxx_hidden_Url
+		}
+	}
+	return nil
+}
+
+func (x *UserURLsChunk) GetResumeToken() string {
+	if x != nil {
+		if x.xxx_hidden_ResumeToken != nil {
+			return *x.xxx_hidden_ResumeToken
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *UserURLsChunk) SetUrl(v []*URLData) {
+	x.xxx_hidden_Url = &v
+}
+
+func (x *UserURLsChunk) SetResumeToken(v string) {
+	x.xxx_hidden_ResumeToken = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 1, 2)
+}
+
+func (x *UserURLsChunk) HasResumeToken() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 1)
+}
+
+func (x *UserURLsChunk) ClearResumeToken() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 1)
+	x.xxx_hidden_ResumeToken = nil
+}
+
+type UserURLsChunk_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	Url []*URLData
+	// токен продолжения после этой части, пустое значение - последняя часть
+	ResumeToken *string
+}
+
+func (b0 UserURLsChunk_builder) Build() *UserURLsChunk {
+	m0 := &UserURLsChunk{}
+	b, x := &b0, m0
+	_, _ = b, x
+	x.xxx_hidden_Url = &b.Url
+	if b.ResumeToken != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 1, 2)
+		x.xxx_hidden_ResumeToken = b.ResumeToken
+	}
+	return m0
+}
+
+type SearchUserURLsRequest struct {
+	state                  protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Mode        *string                `protobuf:"bytes,1,opt,name=mode"`
+	xxx_hidden_Query       *string                `protobuf:"bytes,2,opt,name=query"`
+	xxx_hidden_Limit       int32                  `protobuf:"varint,3,opt,name=limit"`
+	XXX_raceDetectHookData protoimpl.RaceDetectHookData
+	XXX_presence           [1]uint32
+	unknownFields          protoimpl.UnknownFields
+	sizeCache              protoimpl.SizeCache
+}
+
+func (x *SearchUserURLsRequest) Reset() {
+	*x = SearchUserURLsRequest{}
+	mi := &file_internal_proto_shortener_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SearchUserURLsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchUserURLsRequest) ProtoMessage() {}
+
+func (x *SearchUserURLsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_proto_shortener_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *SearchUserURLsRequest) GetMode() string {
+	if x != nil {
+		if x.xxx_hidden_Mode != nil {
+			return *x.xxx_hidden_Mode
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *SearchUserURLsRequest) GetQuery() string {
+	if x != nil {
+		if x.xxx_hidden_Query != nil {
+			return *x.xxx_hidden_Query
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *SearchUserURLsRequest) GetLimit() int32 {
+	if x != nil {
+		return x.xxx_hidden_Limit
+	}
+	return 0
+}
+
+func (x *SearchUserURLsRequest) SetMode(v string) {
+	x.xxx_hidden_Mode = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 0, 3)
+}
+
+func (x *SearchUserURLsRequest) SetQuery(v string) {
+	x.xxx_hidden_Query = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 1, 3)
+}
+
+func (x *SearchUserURLsRequest) SetLimit(v int32) {
+	x.xxx_hidden_Limit = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 2, 3)
+}
+
+func (x *SearchUserURLsRequest) HasMode() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 0)
+}
+
+func (x *SearchUserURLsRequest) HasQuery() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 1)
+}
+
+func (x *SearchUserURLsRequest) HasLimit() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 2)
+}
+
+func (x *SearchUserURLsRequest) ClearMode() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 0)
+	x.xxx_hidden_Mode = nil
+}
+
+func (x *SearchUserURLsRequest) ClearQuery() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 1)
+	x.xxx_hidden_Query = nil
+}
+
+func (x *SearchUserURLsRequest) ClearLimit() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 2)
+	x.xxx_hidden_Limit = 0
+}
+
+type SearchUserURLsRequest_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	// exact, host, prefix или contains, по умолчанию exact
+	Mode  *string
+	Query *string
+	Limit *int32
+}
+
+func (b0 SearchUserURLsRequest_builder) Build() *SearchUserURLsRequest {
+	m0 := &SearchUserURLsRequest{}
+	b, x := &b0, m0
+	_, _ = b, x
+	if b.Mode != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 0, 3)
+		x.xxx_hidden_Mode = b.Mode
+	}
+	if b.Query != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 1, 3)
+		x.xxx_hidden_Query = b.Query
+	}
+	if b.Limit != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 2, 3)
+		x.xxx_hidden_Limit = *b.Limit
+	}
+	return m0
+}
+
+type UserURLsResponse struct {
+	state          protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Url *[]*URLData            `protobuf:"bytes,1,rep,name=url"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *UserURLsResponse) Reset() {
+	*x = UserURLsResponse{}
+	mi := &file_internal_proto_shortener_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UserURLsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UserURLsResponse) ProtoMessage() {}
+
+func (x *UserURLsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_proto_shortener_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *UserURLsResponse) GetUrl() []*URLData {
+	if x != nil {
+		if x.xxx_hidden_Url != nil {
+			return *x.xxx_hidden_Url
+		}
+	}
+	return nil
+}
+
+func (x *UserURLsResponse) SetUrl(v []*URLData) {
+	x.xxx_hidden_Url = &v
+}
+
+type UserURLsResponse_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	Url []*URLData
+}
+
+func (b0 UserURLsResponse_builder) Build() *UserURLsResponse {
+	m0 := &UserURLsResponse{}
+	b, x := &b0, m0
+	_, _ = b, x
+	x.xxx_hidden_Url = &b.Url
+	return m0
+}
+
+type URLData struct {
+	state                   protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_ShortUrl     *string                `protobuf:"bytes,1,opt,name=short_url,json=shortUrl"`
+	xxx_hidden_OriginalUrl  *string                `protobuf:"bytes,2,opt,name=original_url,json=originalUrl"`
+	xxx_hidden_Tags         []string               `protobuf:"bytes,3,rep,name=tags"`
+	xxx_hidden_Folder       *string                `protobuf:"bytes,4,opt,name=folder"`
+	xxx_hidden_Title        *string                `protobuf:"bytes,5,opt,name=title"`
+	xxx_hidden_Description  *string                `protobuf:"bytes,6,opt,name=description"`
+	xxx_hidden_Destinations *[]*Destination        `protobuf:"bytes,7,rep,name=destinations"`
+	xxx_hidden_Rules        *[]*TargetRule         `protobuf:"bytes,8,rep,name=rules"`
+	XXX_raceDetectHookData  protoimpl.RaceDetectHookData
+	XXX_presence            [1]uint32
+	unknownFields           protoimpl.UnknownFields
+	sizeCache               protoimpl.SizeCache
+}
+
+func (x *URLData) Reset() {
+	*x = URLData{}
+	mi := &file_internal_proto_shortener_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *URLData) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*URLData) ProtoMessage() {}
+
+func (x *URLData) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_proto_shortener_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *URLData) GetShortUrl() string {
+	if x != nil {
+		if x.xxx_hidden_ShortUrl != nil {
+			return *x.xxx_hidden_ShortUrl
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *URLData) GetOriginalUrl() string {
+	if x != nil {
+		if x.xxx_hidden_OriginalUrl != nil {
+			return *x.xxx_hidden_OriginalUrl
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *URLData) GetTags() []string {
+	if x != nil {
+		return x.xxx_hidden_Tags
+	}
+	return nil
+}
+
+func (x *URLData) GetFolder() string {
+	if x != nil {
+		if x.xxx_hidden_Folder != nil {
+			return *x.xxx_hidden_Folder
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *URLData) GetTitle() string {
+	if x != nil {
+		if x.xxx_hidden_Title != nil {
+			return *x.xxx_hidden_Title
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *URLData) GetDescription() string {
+	if x != nil {
+		if x.xxx_hidden_Description != nil {
+			return *x.xxx_hidden_Description
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *URLData) GetDestinations() []*Destination {
+	if x != nil {
+		if x.xxx_hidden_Destinations != nil {
+			return *x.xxx_hidden_Destinations
+		}
+	}
+	return nil
+}
+
+func (x *URLData) GetRules() []*TargetRule {
+	if x != nil {
+		if x.xxx_hidden_Rules != nil {
+			return *x.xxx_hidden_Rules
+		}
+	}
+	return nil
+}
+
+func (x *URLData) SetShortUrl(v string) {
+	x.xxx_hidden_ShortUrl = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 0, 8)
+}
+
+func (x *URLData) SetOriginalUrl(v string) {
+	x.xxx_hidden_OriginalUrl = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 1, 8)
+}
+
+func (x *URLData) SetTags(v []string) {
+	x.xxx_hidden_Tags = v
+}
+
+func (x *URLData) SetFolder(v string) {
+	x.xxx_hidden_Folder = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 3, 8)
+}
+
+func (x *URLData) SetTitle(v string) {
+	x.xxx_hidden_Title = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 4, 8)
+}
+
+func (x *URLData) SetDescription(v string) {
+	x.xxx_hidden_Description = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 5, 8)
+}
+
+func (x *URLData) SetDestinations(v []*Destination) {
+	x.xxx_hidden_Destinations = &v
+}
+
+func (x *URLData) SetRules(v []*TargetRule) {
+	x.xxx_hidden_Rules = &v
+}
+
+func (x *URLData) HasShortUrl() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 0)
+}
+
+func (x *URLData) HasOriginalUrl() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 1)
+}
+
+func (x *URLData) HasFolder() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 3)
+}
+
+func (x *URLData) HasTitle() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 4)
+}
+
+func (x *URLData) HasDescription() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 5)
+}
+
+func (x *URLData) ClearShortUrl() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 0)
+	x.xxx_hidden_ShortUrl = nil
+}
+
+func (x *URLData) ClearOriginalUrl() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 1)
+	x.xxx_hidden_OriginalUrl = nil
+}
+
+func (x *URLData) ClearFolder() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 3)
+	x.xxx_hidden_Folder = nil
+}
+
+func (x *URLData) ClearTitle() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 4)
+	x.xxx_hidden_Title = nil
+}
+
+func (x *URLData) ClearDescription() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 5)
+	x.xxx_hidden_Description = nil
+}
+
+type URLData_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	ShortUrl     *string
+	OriginalUrl  *string
+	Tags         []string
+	Folder       *string
+	Title        *string
+	Description  *string
+	Destinations []*Destination
+	Rules        []*TargetRule
+}
+
+func (b0 URLData_builder) Build() *URLData {
+	m0 := &URLData{}
+	b, x := &b0, m0
+	_, _ = b, x
+	if b.ShortUrl != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 0, 8)
+		x.xxx_hidden_ShortUrl = b.ShortUrl
+	}
+	if b.OriginalUrl != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 1, 8)
+		x.xxx_hidden_OriginalUrl = b.OriginalUrl
+	}
+	x.xxx_hidden_Tags = b.Tags
+	if b.Folder != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 3, 8)
+		x.xxx_hidden_Folder = b.Folder
+	}
+	if b.Title != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 4, 8)
+		x.xxx_hidden_Title = b.Title
+	}
+	if b.Description != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 5, 8)
+		x.xxx_hidden_Description = b.Description
+	}
+	x.xxx_hidden_Destinations = &b.Destinations
+	x.xxx_hidden_Rules = &b.Rules
+	return m0
+}
+
+type Destination struct {
+	state                  protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Url         *string                `protobuf:"bytes,1,opt,name=url"`
+	xxx_hidden_Weight      int32                  `protobuf:"varint,2,opt,name=weight"`
+	xxx_hidden_Clicks      int64                  `protobuf:"varint,3,opt,name=clicks"`
+	XXX_raceDetectHookData protoimpl.RaceDetectHookData
+	XXX_presence           [1]uint32
+	unknownFields          protoimpl.UnknownFields
+	sizeCache              protoimpl.SizeCache
+}
+
+func (x *Destination) Reset() {
+	*x = Destination{}
+	mi := &file_internal_proto_shortener_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Destination) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Destination) ProtoMessage() {}
+
+func (x *Destination) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_proto_shortener_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *Destination) GetUrl() string {
+	if x != nil {
+		if x.xxx_hidden_Url != nil {
+			return *x.xxx_hidden_Url
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *Destination) GetWeight() int32 {
+	if x != nil {
+		return x.xxx_hidden_Weight
+	}
+	return 0
+}
+
+func (x *Destination) GetClicks() int64 {
+	if x != nil {
+		return x.xxx_hidden_Clicks
+	}
+	return 0
+}
+
+func (x *Destination) SetUrl(v string) {
+	x.xxx_hidden_Url = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 0, 3)
+}
+
+func (x *Destination) SetWeight(v int32) {
+	x.xxx_hidden_Weight = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 1, 3)
+}
+
+func (x *Destination) SetClicks(v int64) {
+	x.xxx_hidden_Clicks = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 2, 3)
+}
+
+func (x *Destination) HasUrl() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 0)
+}
+
+func (x *Destination) HasWeight() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 1)
+}
+
+func (x *Destination) HasClicks() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 2)
+}
+
+func (x *Destination) ClearUrl() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 0)
+	x.xxx_hidden_Url = nil
+}
+
+func (x *Destination) ClearWeight() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 1)
+	x.xxx_hidden_Weight = 0
+}
+
+func (x *Destination) ClearClicks() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 2)
+	x.xxx_hidden_Clicks = 0
+}
+
+type Destination_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	Url    *string
+	Weight *int32
+	Clicks *int64
+}
+
+func (b0 Destination_builder) Build() *Destination {
+	m0 := &Destination{}
+	b, x := &b0, m0
+	_, _ = b, x
+	if b.Url != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 0, 3)
+		x.xxx_hidden_Url = b.Url
+	}
+	if b.Weight != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 1, 3)
+		x.xxx_hidden_Weight = *b.Weight
+	}
+	if b.Clicks != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 2, 3)
+		x.xxx_hidden_Clicks = *b.Clicks
+	}
+	return m0
+}
+
+type TargetRule struct {
+	state                  protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Platform    *string                `protobuf:"bytes,1,opt,name=platform"`
+	xxx_hidden_Language    *string                `protobuf:"bytes,2,opt,name=language"`
+	xxx_hidden_Url         *string                `protobuf:"bytes,3,opt,name=url"`
+	XXX_raceDetectHookData protoimpl.RaceDetectHookData
+	XXX_presence           [1]uint32
+	unknownFields          protoimpl.UnknownFields
+	sizeCache              protoimpl.SizeCache
+}
+
+func (x *TargetRule) Reset() {
+	*x = TargetRule{}
+	mi := &file_internal_proto_shortener_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TargetRule) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TargetRule) ProtoMessage() {}
+
+func (x *TargetRule) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_proto_shortener_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *TargetRule) GetPlatform() string {
+	if x != nil {
+		if x.xxx_hidden_Platform != nil {
+			return *x.xxx_hidden_Platform
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *TargetRule) GetLanguage() string {
+	if x != nil {
+		if x.xxx_hidden_Language != nil {
+			return *x.xxx_hidden_Language
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *TargetRule) GetUrl() string {
+	if x != nil {
+		if x.xxx_hidden_Url != nil {
+			return *x.xxx_hidden_Url
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *TargetRule) SetPlatform(v string) {
+	x.xxx_hidden_Platform = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 0, 3)
+}
+
+func (x *TargetRule) SetLanguage(v string) {
+	x.xxx_hidden_Language = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 1, 3)
+}
+
+func (x *TargetRule) SetUrl(v string) {
+	x.xxx_hidden_Url = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 2, 3)
+}
+
+func (x *TargetRule) HasPlatform() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 0)
+}
+
+func (x *TargetRule) HasLanguage() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 1)
+}
+
+func (x *TargetRule) HasUrl() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 2)
+}
+
+func (x *TargetRule) ClearPlatform() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 0)
+	x.xxx_hidden_Platform = nil
+}
+
+func (x *TargetRule) ClearLanguage() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 1)
+	x.xxx_hidden_Language = nil
+}
+
+func (x *TargetRule) ClearUrl() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 2)
+	x.xxx_hidden_Url = nil
+}
+
+type TargetRule_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	Platform *string
+	Language *string
+	Url      *string
+}
+
+func (b0 TargetRule_builder) Build() *TargetRule {
+	m0 := &TargetRule{}
+	b, x := &b0, m0
+	_, _ = b, x
+	if b.Platform != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 0, 3)
+		x.xxx_hidden_Platform = b.Platform
+	}
+	if b.Language != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 1, 3)
+		x.xxx_hidden_Language = b.Language
+	}
+	if b.Url != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 2, 3)
+		x.xxx_hidden_Url = b.Url
+	}
+	return m0
+}
+
+type BatchItem struct {
+	state                    protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_CorrelationId *string                `protobuf:"bytes,1,opt,name=correlation_id,json=correlationId"`
+	xxx_hidden_OriginalUrl   *string                `protobuf:"bytes,2,opt,name=original_url,json=originalUrl"`
+	xxx_hidden_Tags          []string               `protobuf:"bytes,3,rep,name=tags"`
+	xxx_hidden_Folder        *string                `protobuf:"bytes,4,opt,name=folder"`
+	xxx_hidden_Title         *string                `protobuf:"bytes,5,opt,name=title"`
+	xxx_hidden_Description   *string                `protobuf:"bytes,6,opt,name=description"`
+	xxx_hidden_Domain        *string                `protobuf:"bytes,7,opt,name=domain"`
+	XXX_raceDetectHookData   protoimpl.RaceDetectHookData
+	XXX_presence             [1]uint32
+	unknownFields            protoimpl.UnknownFields
+	sizeCache                protoimpl.SizeCache
+}
+
+func (x *BatchItem) Reset() {
+	*x = BatchItem{}
+	mi := &file_internal_proto_shortener_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BatchItem) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchItem) ProtoMessage() {}
+
+func (x *BatchItem) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_proto_shortener_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *BatchItem) GetCorrelationId() string {
+	if x != nil {
+		if x.xxx_hidden_CorrelationId != nil {
+			return *x.xxx_hidden_CorrelationId
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *BatchItem) GetOriginalUrl() string {
+	if x != nil {
+		if x.xxx_hidden_OriginalUrl != nil {
+			return *x.xxx_hidden_OriginalUrl
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *BatchItem) GetTags() []string {
+	if x != nil {
+		return x.xxx_hidden_Tags
+	}
+	return nil
+}
+
+func (x *BatchItem) GetFolder() string {
+	if x != nil {
+		if x.xxx_hidden_Folder != nil {
+			return *x.xxx_hidden_Folder
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *BatchItem) GetTitle() string {
+	if x != nil {
+		if x.xxx_hidden_Title != nil {
+			return *x.xxx_hidden_Title
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *BatchItem) GetDescription() string {
+	if x != nil {
+		if x.xxx_hidden_Description != nil {
+			return *x.xxx_hidden_Description
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *BatchItem) GetDomain() string {
+	if x != nil {
+		if x.xxx_hidden_Domain != nil {
+			return *x.xxx_hidden_Domain
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *BatchItem) SetCorrelationId(v string) {
+	x.xxx_hidden_CorrelationId = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 0, 7)
+}
+
+func (x *BatchItem) SetOriginalUrl(v string) {
+	x.xxx_hidden_OriginalUrl = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 1, 7)
+}
+
+func (x *BatchItem) SetTags(v []string) {
+	x.xxx_hidden_Tags = v
+}
+
+func (x *BatchItem) SetFolder(v string) {
+	x.xxx_hidden_Folder = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 3, 7)
+}
+
+func (x *BatchItem) SetTitle(v string) {
+	x.xxx_hidden_Title = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 4, 7)
+}
+
+func (x *BatchItem) SetDescription(v string) {
+	x.xxx_hidden_Description = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 5, 7)
+}
+
+func (x *BatchItem) SetDomain(v string) {
+	x.xxx_hidden_Domain = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 6, 7)
+}
+
+func (x *BatchItem) HasCorrelationId() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 0)
+}
+
+func (x *BatchItem) HasOriginalUrl() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 1)
+}
+
+func (x *BatchItem) HasFolder() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 3)
+}
+
+func (x *BatchItem) HasTitle() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 4)
+}
+
+func (x *BatchItem) HasDescription() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 5)
+}
+
+func (x *BatchItem) HasDomain() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 6)
+}
+
+func (x *BatchItem) ClearCorrelationId() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 0)
+	x.xxx_hidden_CorrelationId = nil
+}
+
+func (x *BatchItem) ClearOriginalUrl() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 1)
+	x.xxx_hidden_OriginalUrl = nil
+}
+
+func (x *BatchItem) ClearFolder() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 3)
+	x.xxx_hidden_Folder = nil
+}
+
+func (x *BatchItem) ClearTitle() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 4)
+	x.xxx_hidden_Title = nil
+}
+
+func (x *BatchItem) ClearDescription() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 5)
+	x.xxx_hidden_Description = nil
+}
+
+func (x *BatchItem) ClearDomain() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 6)
+	x.xxx_hidden_Domain = nil
+}
+
+type BatchItem_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	CorrelationId *string
+	OriginalUrl   *string
+	Tags          []string
+	Folder        *string
+	Title         *string
+	Description   *string
+	// домен короткого URL, пустое значение - домен по умолчанию
+	Domain *string
+}
+
+func (b0 BatchItem_builder) Build() *BatchItem {
+	m0 := &BatchItem{}
+	b, x := &b0, m0
+	_, _ = b, x
+	if b.CorrelationId != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 0, 7)
+		x.xxx_hidden_CorrelationId = b.CorrelationId
+	}
+	if b.OriginalUrl != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 1, 7)
+		x.xxx_hidden_OriginalUrl = b.OriginalUrl
+	}
+	x.xxx_hidden_Tags = b.Tags
+	if b.Folder != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 3, 7)
+		x.xxx_hidden_Folder = b.Folder
+	}
+	if b.Title != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 4, 7)
+		x.xxx_hidden_Title = b.Title
+	}
+	if b.Description != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 5, 7)
+		x.xxx_hidden_Description = b.Description
+	}
+	if b.Domain != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 6, 7)
+		x.xxx_hidden_Domain = b.Domain
+	}
+	return m0
+}
+
+type ShortenBatchRequest struct {
+	state            protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Items *[]*BatchItem          `protobuf:"bytes,1,rep,name=items"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *ShortenBatchRequest) Reset() {
+	*x = ShortenBatchRequest{}
+	mi := &file_internal_proto_shortener_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ShortenBatchRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ShortenBatchRequest) ProtoMessage() {}
+
+func (x *ShortenBatchRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_proto_shortener_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *ShortenBatchRequest) GetItems() []*BatchItem {
+	if x != nil {
+		if x.xxx_hidden_Items != nil {
+			return *x.xxx_hidden_Items
+		}
+	}
+	return nil
+}
+
+func (x *ShortenBatchRequest) SetItems(v []*BatchItem) {
+	x.xxx_hidden_Items = &v
+}
+
+type ShortenBatchRequest_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	Items []*BatchItem
+}
+
+func (b0 ShortenBatchRequest_builder) Build() *ShortenBatchRequest {
+	m0 := &ShortenBatchRequest{}
+	b, x := &b0, m0
+	_, _ = b, x
+	x.xxx_hidden_Items = &b.Items
+	return m0
+}
+
+type BatchResult struct {
+	state                    protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_CorrelationId *string                `protobuf:"bytes,1,opt,name=correlation_id,json=correlationId"`
+	xxx_hidden_ShortUrl      *string                `protobuf:"bytes,2,opt,name=short_url,json=shortUrl"`
+	XXX_raceDetectHookData   protoimpl.RaceDetectHookData
+	XXX_presence             [1]uint32
+	unknownFields            protoimpl.UnknownFields
+	sizeCache                protoimpl.SizeCache
+}
+
+func (x *BatchResult) Reset() {
+	*x = BatchResult{}
+	mi := &file_internal_proto_shortener_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BatchResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchResult) ProtoMessage() {}
+
+func (x *BatchResult) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_proto_shortener_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *BatchResult) GetCorrelationId() string {
+	if x != nil {
+		if x.xxx_hidden_CorrelationId != nil {
+			return *x.xxx_hidden_CorrelationId
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *BatchResult) GetShortUrl() string {
+	if x != nil {
+		if x.xxx_hidden_ShortUrl != nil {
+			return *x.xxx_hidden_ShortUrl
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *BatchResult) SetCorrelationId(v string) {
+	x.xxx_hidden_CorrelationId = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 0, 2)
+}
+
+func (x *BatchResult) SetShortUrl(v string) {
+	x.xxx_hidden_ShortUrl = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 1, 2)
+}
+
+func (x *BatchResult) HasCorrelationId() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 0)
+}
+
+func (x *BatchResult) HasShortUrl() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 1)
+}
+
+func (x *BatchResult) ClearCorrelationId() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 0)
+	x.xxx_hidden_CorrelationId = nil
+}
+
+func (x *BatchResult) ClearShortUrl() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 1)
+	x.xxx_hidden_ShortUrl = nil
+}
+
+type BatchResult_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	CorrelationId *string
+	ShortUrl      *string
+}
+
+func (b0 BatchResult_builder) Build() *BatchResult {
+	m0 := &BatchResult{}
+	b, x := &b0, m0
+	_, _ = b, x
+	if b.CorrelationId != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 0, 2)
+		x.xxx_hidden_CorrelationId = b.CorrelationId
+	}
+	if b.ShortUrl != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 1, 2)
+		x.xxx_hidden_ShortUrl = b.ShortUrl
+	}
+	return m0
+}
+
+type ShortenBatchResponse struct {
+	state              protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Results *[]*BatchResult        `protobuf:"bytes,1,rep,name=results"`
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
+}
+
+func (x *ShortenBatchResponse) Reset() {
+	*x = ShortenBatchResponse{}
+	mi := &file_internal_proto_shortener_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ShortenBatchResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ShortenBatchResponse) ProtoMessage() {}
+
+func (x *ShortenBatchResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_proto_shortener_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *ShortenBatchResponse) GetResults() []*BatchResult {
+	if x != nil {
+		if x.xxx_hidden_Results != nil {
+			return *x.xxx_hidden_Results
+		}
+	}
+	return nil
+}
+
+func (x *ShortenBatchResponse) SetResults(v []*BatchResult) {
+	x.xxx_hidden_Results = &v
+}
+
+type ShortenBatchResponse_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	Results []*BatchResult
+}
+
+func (b0 ShortenBatchResponse_builder) Build() *ShortenBatchResponse {
+	m0 := &ShortenBatchResponse{}
+	b, x := &b0, m0
+	_, _ = b, x
+	x.xxx_hidden_Results = &b.Results
+	return m0
+}
+
+type ShortenStreamResult struct {
+	state                    protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_CorrelationId *string                `protobuf:"bytes,1,opt,name=correlation_id,json=correlationId"`
+	xxx_hidden_ShortUrl      *string                `protobuf:"bytes,2,opt,name=short_url,json=shortUrl"`
+	xxx_hidden_Status        *string                `protobuf:"bytes,3,opt,name=status"`
+	XXX_raceDetectHookData   protoimpl.RaceDetectHookData
+	XXX_presence             [1]uint32
+	unknownFields            protoimpl.UnknownFields
+	sizeCache                protoimpl.SizeCache
+}
+
+func (x *ShortenStreamResult) Reset() {
+	*x = ShortenStreamResult{}
+	mi := &file_internal_proto_shortener_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ShortenStreamResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ShortenStreamResult) ProtoMessage() {}
+
+func (x *ShortenStreamResult) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_proto_shortener_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *ShortenStreamResult) GetCorrelationId() string {
+	if x != nil {
+		if x.xxx_hidden_CorrelationId != nil {
+			return *x.xxx_hidden_CorrelationId
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *ShortenStreamResult) GetShortUrl() string {
+	if x != nil {
+		if x.xxx_hidden_ShortUrl != nil {
+			return *x.xxx_hidden_ShortUrl
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *ShortenStreamResult) GetStatus() string {
+	if x != nil {
+		if x.xxx_hidden_Status != nil {
+			return *x.xxx_hidden_Status
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *ShortenStreamResult) SetCorrelationId(v string) {
+	x.xxx_hidden_CorrelationId = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 0, 3)
+}
+
+func (x *ShortenStreamResult) SetShortUrl(v string) {
+	x.xxx_hidden_ShortUrl = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 1, 3)
+}
+
+func (x *ShortenStreamResult) SetStatus(v string) {
+	x.xxx_hidden_Status = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 2, 3)
+}
+
+func (x *ShortenStreamResult) HasCorrelationId() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 0)
+}
+
+func (x *ShortenStreamResult) HasShortUrl() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 1)
+}
+
+func (x *ShortenStreamResult) HasStatus() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 2)
+}
+
+func (x *ShortenStreamResult) ClearCorrelationId() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 0)
+	x.xxx_hidden_CorrelationId = nil
+}
+
+func (x *ShortenStreamResult) ClearShortUrl() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 1)
+	x.xxx_hidden_ShortUrl = nil
+}
+
+func (x *ShortenStreamResult) ClearStatus() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 2)
+	x.xxx_hidden_Status = nil
+}
+
+type ShortenStreamResult_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	CorrelationId *string
+	// пустое значение для некорректного URL
+	ShortUrl *string
+	// created, existing или invalid
+	Status *string
+}
+
+func (b0 ShortenStreamResult_builder) Build() *ShortenStreamResult {
+	m0 := &ShortenStreamResult{}
+	b, x := &b0, m0
+	_, _ = b, x
+	if b.CorrelationId != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 0, 3)
+		x.xxx_hidden_CorrelationId = b.CorrelationId
+	}
+	if b.ShortUrl != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 1, 3)
+		x.xxx_hidden_ShortUrl = b.ShortUrl
+	}
+	if b.Status != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 2, 3)
+		x.xxx_hidden_Status = b.Status
+	}
+	return m0
+}
+
+type DeleteUserURLsRequest struct {
+	state                  protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_ShortUrls   []string               `protobuf:"bytes,1,rep,name=short_urls,json=shortUrls"`
+	xxx_hidden_Domain      *string                `protobuf:"bytes,2,opt,name=domain"`
+	XXX_raceDetectHookData protoimpl.RaceDetectHookData
+	XXX_presence           [1]uint32
+	unknownFields          protoimpl.UnknownFields
+	sizeCache              protoimpl.SizeCache
+}
+
+func (x *DeleteUserURLsRequest) Reset() {
+	*x = DeleteUserURLsRequest{}
+	mi := &file_internal_proto_shortener_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteUserURLsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteUserURLsRequest) ProtoMessage() {}
+
+func (x *DeleteUserURLsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_proto_shortener_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *DeleteUserURLsRequest) GetShortUrls() []string {
+	if x != nil {
+		return x.xxx_hidden_ShortUrls
+	}
+	return nil
+}
+
+func (x *DeleteUserURLsRequest) GetDomain() string {
+	if x != nil {
+		if x.xxx_hidden_Domain != nil {
+			return *x.xxx_hidden_Domain
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *DeleteUserURLsRequest) SetShortUrls(v []string) {
+	x.xxx_hidden_ShortUrls = v
+}
+
+func (x *DeleteUserURLsRequest) SetDomain(v string) {
+	x.xxx_hidden_Domain = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 1, 2)
+}
+
+func (x *DeleteUserURLsRequest) HasDomain() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 1)
+}
+
+func (x *DeleteUserURLsRequest) ClearDomain() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 1)
+	x.xxx_hidden_Domain = nil
+}
+
+type DeleteUserURLsRequest_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	// короткие URL без базового адреса
+	ShortUrls []string
+	// домен коротких URL, пустое значение - домен по умолчанию
+	Domain *string
+}
+
+func (b0 DeleteUserURLsRequest_builder) Build() *DeleteUserURLsRequest {
+	m0 := &DeleteUserURLsRequest{}
+	b, x := &b0, m0
+	_, _ = b, x
+	x.xxx_hidden_ShortUrls = b.ShortUrls
+	if b.Domain != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 1, 2)
+		x.xxx_hidden_Domain = b.Domain
+	}
+	return m0
+}
+
+type DeleteUserURLsResponse struct {
+	state         protoimpl.MessageState `protogen:"opaque.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteUserURLsResponse) Reset() {
+	*x = DeleteUserURLsResponse{}
+	mi := &file_internal_proto_shortener_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteUserURLsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteUserURLsResponse) ProtoMessage() {}
+
+func (x *DeleteUserURLsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_proto_shortener_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+type DeleteUserURLsResponse_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+}
+
+func (b0 DeleteUserURLsResponse_builder) Build() *DeleteUserURLsResponse {
+	m0 := &DeleteUserURLsResponse{}
+	b, x := &b0, m0
+	_, _ = b, x
+	return m0
+}
+
+type PingRequest struct {
+	state         protoimpl.MessageState `protogen:"opaque.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PingRequest) Reset() {
+	*x = PingRequest{}
+	mi := &file_internal_proto_shortener_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PingRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PingRequest) ProtoMessage() {}
+
+func (x *PingRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_proto_shortener_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+type PingRequest_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+}
+
+func (b0 PingRequest_builder) Build() *PingRequest {
+	m0 := &PingRequest{}
+	b, x := &b0, m0
+	_, _ = b, x
+	return m0
+}
+
+type PingResponse struct {
+	state         protoimpl.MessageState `protogen:"opaque.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PingResponse) Reset() {
+	*x = PingResponse{}
+	mi := &file_internal_proto_shortener_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PingResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PingResponse) ProtoMessage() {}
+
+func (x *PingResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_proto_shortener_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+type PingResponse_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+}
+
+func (b0 PingResponse_builder) Build() *PingResponse {
+	m0 := &PingResponse{}
+	b, x := &b0, m0
+	_, _ = b, x
+	return m0
+}
+
+type StatsRequest struct {
+	state                  protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_From        *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=from"`
+	xxx_hidden_To          *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=to"`
+	xxx_hidden_Top         int32                  `protobuf:"varint,3,opt,name=top"`
+	XXX_raceDetectHookData protoimpl.RaceDetectHookData
+	XXX_presence           [1]uint32
+	unknownFields          protoimpl.UnknownFields
+	sizeCache              protoimpl.SizeCache
+}
+
+func (x *StatsRequest) Reset() {
+	*x = StatsRequest{}
+	mi := &file_internal_proto_shortener_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StatsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StatsRequest) ProtoMessage() {}
+
+func (x *StatsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_proto_shortener_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *StatsRequest) GetFrom() *timestamppb.Timestamp {
+	if x != nil {
+		return x.xxx_hidden_From
+	}
+	return nil
+}
+
+func (x *StatsRequest) GetTo() *timestamppb.Timestamp {
+	if x != nil {
+		return x.xxx_hidden_To
+	}
+	return nil
+}
+
+func (x *StatsRequest) GetTop() int32 {
+	if x != nil {
+		return x.xxx_hidden_Top
+	}
+	return 0
+}
+
+func (x *StatsRequest) SetFrom(v *timestamppb.Timestamp) {
+	x.xxx_hidden_From = v
+}
+
+func (x *StatsRequest) SetTo(v *timestamppb.Timestamp) {
+	x.xxx_hidden_To = v
+}
+
+func (x *StatsRequest) SetTop(v int32) {
+	x.xxx_hidden_Top = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 2, 3)
+}
+
+func (x *StatsRequest) HasFrom() bool {
+	if x == nil {
+		return false
+	}
+	return x.xxx_hidden_From != nil
+}
+
+func (x *StatsRequest) HasTo() bool {
+	if x == nil {
+		return false
+	}
+	return x.xxx_hidden_To != nil
+}
+
+func (x *StatsRequest) HasTop() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 2)
+}
+
+func (x *StatsRequest) ClearFrom() {
+	x.xxx_hidden_From = nil
+}
+
+func (x *StatsRequest) ClearTo() {
+	x.xxx_hidden_To = nil
+}
+
+func (x *StatsRequest) ClearTop() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 2)
+	x.xxx_hidden_Top = 0
+}
+
+type StatsRequest_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	// по умолчанию - последние 30 дней
+	From *timestamppb.Timestamp
+	To   *timestamppb.Timestamp
+	Top  *int32
+}
+
+func (b0 StatsRequest_builder) Build() *StatsRequest {
+	m0 := &StatsRequest{}
+	b, x := &b0, m0
+	_, _ = b, x
+	x.xxx_hidden_From = b.From
+	x.xxx_hidden_To = b.To
+	if b.Top != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 2, 3)
+		x.xxx_hidden_Top = *b.Top
+	}
+	return m0
+}
+
+type DailyStats struct {
+	state                  protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Date        *string                `protobuf:"bytes,1,opt,name=date"`
+	xxx_hidden_Count       int32                  `protobuf:"varint,2,opt,name=count"`
+	XXX_raceDetectHookData protoimpl.RaceDetectHookData
+	XXX_presence           [1]uint32
+	unknownFields          protoimpl.UnknownFields
+	sizeCache              protoimpl.SizeCache
+}
+
+func (x *DailyStats) Reset() {
+	*x = DailyStats{}
+	mi := &file_internal_proto_shortener_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DailyStats) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DailyStats) ProtoMessage() {}
+
+func (x *DailyStats) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_proto_shortener_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *DailyStats) GetDate() string {
+	if x != nil {
+		if x.xxx_hidden_Date != nil {
+			return *x.xxx_hidden_Date
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *DailyStats) GetCount() int32 {
+	if x != nil {
+		return x.xxx_hidden_Count
+	}
+	return 0
+}
+
+func (x *DailyStats) SetDate(v string) {
+	x.xxx_hidden_Date = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 0, 2)
+}
+
+func (x *DailyStats) SetCount(v int32) {
+	x.xxx_hidden_Count = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 1, 2)
+}
+
+func (x *DailyStats) HasDate() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 0)
+}
+
+func (x *DailyStats) HasCount() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 1)
+}
+
+func (x *DailyStats) ClearDate() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 0)
+	x.xxx_hidden_Date = nil
+}
+
+func (x *DailyStats) ClearCount() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 1)
+	x.xxx_hidden_Count = 0
+}
+
+type DailyStats_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	Date  *string
+	Count *int32
+}
+
+func (b0 DailyStats_builder) Build() *DailyStats {
+	m0 := &DailyStats{}
+	b, x := &b0, m0
+	_, _ = b, x
+	if b.Date != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 0, 2)
+		x.xxx_hidden_Date = b.Date
+	}
+	if b.Count != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 1, 2)
+		x.xxx_hidden_Count = *b.Count
+	}
+	return m0
+}
+
+type URLClicks struct {
+	state                  protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_ShortUrl    *string                `protobuf:"bytes,1,opt,name=short_url,json=shortUrl"`
+	xxx_hidden_OriginalUrl *string                `protobuf:"bytes,2,opt,name=original_url,json=originalUrl"`
+	xxx_hidden_Clicks      int64                  `protobuf:"varint,3,opt,name=clicks"`
+	XXX_raceDetectHookData protoimpl.RaceDetectHookData
+	XXX_presence           [1]uint32
+	unknownFields          protoimpl.UnknownFields
+	sizeCache              protoimpl.SizeCache
+}
+
+func (x *URLClicks) Reset() {
+	*x = URLClicks{}
+	mi := &file_internal_proto_shortener_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *URLClicks) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*URLClicks) ProtoMessage() {}
+
+func (x *URLClicks) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_proto_shortener_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *URLClicks) GetShortUrl() string {
+	if x != nil {
+		if x.xxx_hidden_ShortUrl != nil {
+			return *x.xxx_hidden_ShortUrl
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *URLClicks) GetOriginalUrl() string {
+	if x != nil {
+		if x.xxx_hidden_OriginalUrl != nil {
+			return *x.xxx_hidden_OriginalUrl
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *URLClicks) GetClicks() int64 {
+	if x != nil {
+		return x.xxx_hidden_Clicks
+	}
+	return 0
+}
+
+func (x *URLClicks) SetShortUrl(v string) {
+	x.xxx_hidden_ShortUrl = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 0, 3)
+}
+
+func (x *URLClicks) SetOriginalUrl(v string) {
+	x.xxx_hidden_OriginalUrl = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 1, 3)
+}
+
+func (x *URLClicks) SetClicks(v int64) {
+	x.xxx_hidden_Clicks = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 2, 3)
+}
+
+func (x *URLClicks) HasShortUrl() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 0)
+}
+
+func (x *URLClicks) HasOriginalUrl() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 1)
+}
+
+func (x *URLClicks) HasClicks() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 2)
+}
+
+func (x *URLClicks) ClearShortUrl() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 0)
+	x.xxx_hidden_ShortUrl = nil
+}
+
+func (x *URLClicks) ClearOriginalUrl() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 1)
+	x.xxx_hidden_OriginalUrl = nil
+}
+
+func (x *URLClicks) ClearClicks() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 2)
+	x.xxx_hidden_Clicks = 0
+}
+
+type URLClicks_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	ShortUrl    *string
+	OriginalUrl *string
+	Clicks      *int64
+}
+
+func (b0 URLClicks_builder) Build() *URLClicks {
+	m0 := &URLClicks{}
+	b, x := &b0, m0
+	_, _ = b, x
+	if b.ShortUrl != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 0, 3)
+		x.xxx_hidden_ShortUrl = b.ShortUrl
+	}
+	if b.OriginalUrl != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 1, 3)
+		x.xxx_hidden_OriginalUrl = b.OriginalUrl
+	}
+	if b.Clicks != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 2, 3)
+		x.xxx_hidden_Clicks = *b.Clicks
+	}
+	return m0
+}
+
+type UserStats struct {
+	state                  protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_UserId      *string                `protobuf:"bytes,1,opt,name=user_id,json=userId"`
+	xxx_hidden_Urls        int32                  `protobuf:"varint,2,opt,name=urls"`
+	XXX_raceDetectHookData protoimpl.RaceDetectHookData
+	XXX_presence           [1]uint32
+	unknownFields          protoimpl.UnknownFields
+	sizeCache              protoimpl.SizeCache
+}
+
+func (x *UserStats) Reset() {
+	*x = UserStats{}
+	mi := &file_internal_proto_shortener_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UserStats) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UserStats) ProtoMessage() {}
+
+func (x *UserStats) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_proto_shortener_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *UserStats) GetUserId() string {
+	if x != nil {
+		if x.xxx_hidden_UserId != nil {
+			return *x.xxx_hidden_UserId
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *UserStats) GetUrls() int32 {
+	if x != nil {
+		return x.xxx_hidden_Urls
+	}
+	return 0
+}
+
+func (x *UserStats) SetUserId(v string) {
+	x.xxx_hidden_UserId = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 0, 2)
+}
+
+func (x *UserStats) SetUrls(v int32) {
+	x.xxx_hidden_Urls = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 1, 2)
+}
+
+func (x *UserStats) HasUserId() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 0)
+}
+
+func (x *UserStats) HasUrls() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 1)
+}
+
+func (x *UserStats) ClearUserId() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 0)
+	x.xxx_hidden_UserId = nil
+}
+
+func (x *UserStats) ClearUrls() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 1)
+	x.xxx_hidden_Urls = 0
+}
+
+type UserStats_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	UserId *string
+	Urls   *int32
+}
+
+func (b0 UserStats_builder) Build() *UserStats {
+	m0 := &UserStats{}
+	b, x := &b0, m0
+	_, _ = b, x
+	if b.UserId != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 0, 2)
+		x.xxx_hidden_UserId = b.UserId
+	}
+	if b.Urls != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 1, 2)
+		x.xxx_hidden_Urls = *b.Urls
+	}
+	return m0
+}
+
+type StatsResponse struct {
+	state                   protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Urls         int32                  `protobuf:"varint,1,opt,name=urls"`
+	xxx_hidden_Users        int32                  `protobuf:"varint,2,opt,name=users"`
+	xxx_hidden_ActiveUrls   int32                  `protobuf:"varint,3,opt,name=active_urls,json=activeUrls"`
+	xxx_hidden_DeletedUrls  int32                  `protobuf:"varint,4,opt,name=deleted_urls,json=deletedUrls"`
+	xxx_hidden_StorageBytes int64                  `protobuf:"varint,5,opt,name=storage_bytes,json=storageBytes"`
+	xxx_hidden_From         *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=from"`
+	xxx_hidden_To           *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=to"`
+	xxx_hidden_PerDay       *[]*DailyStats         `protobuf:"bytes,8,rep,name=per_day,json=perDay"`
+	xxx_hidden_TopUrls      *[]*URLClicks          `protobuf:"bytes,9,rep,name=top_urls,json=topUrls"`
+	xxx_hidden_TopUsers     *[]*UserStats          `protobuf:"bytes,10,rep,name=top_users,json=topUsers"`
+	XXX_raceDetectHookData  protoimpl.RaceDetectHookData
+	XXX_presence            [1]uint32
+	unknownFields           protoimpl.UnknownFields
+	sizeCache               protoimpl.SizeCache
+}
+
+func (x *StatsResponse) Reset() {
+	*x = StatsResponse{}
+	mi := &file_internal_proto_shortener_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StatsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StatsResponse) ProtoMessage() {}
+
+func (x *StatsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_proto_shortener_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *StatsResponse) GetUrls() int32 {
+	if x != nil {
+		return x.xxx_hidden_Urls
+	}
+	return 0
+}
+
+func (x *StatsResponse) GetUsers() int32 {
+	if x != nil {
+		return x.xxx_hidden_Users
+	}
+	return 0
+}
+
+func (x *StatsResponse) GetActiveUrls() int32 {
+	if x != nil {
+		return x.xxx_hidden_ActiveUrls
+	}
+	return 0
+}
+
+func (x *StatsResponse) GetDeletedUrls() int32 {
+	if x != nil {
+		return x.xxx_hidden_DeletedUrls
+	}
+	return 0
+}
+
+func (x *StatsResponse) GetStorageBytes() int64 {
+	if x != nil {
+		return x.xxx_hidden_StorageBytes
+	}
+	return 0
+}
+
+func (x *StatsResponse) GetFrom() *timestamppb.Timestamp {
+	if x != nil {
+		return x.xxx_hidden_From
+	}
+	return nil
+}
+
+func (x *StatsResponse) GetTo() *timestamppb.Timestamp {
+	if x != nil {
+		return x.xxx_hidden_To
+	}
+	return nil
+}
+
+func (x *StatsResponse) GetPerDay() []*DailyStats {
+	if x != nil {
+		if x.xxx_hidden_PerDay != nil {
+			return *x.xxx_hidden_PerDay
+		}
+	}
+	return nil
+}
+
+func (x *StatsResponse) GetTopUrls() []*URLClicks {
+	if x != nil {
+		if x.xxx_hidden_TopUrls != nil {
+			return *x.xxx_hidden_TopUrls
+		}
+	}
+	return nil
+}
+
+func (x *StatsResponse) GetTopUsers() []*UserStats {
+	if x != nil {
+		if x.xxx_hidden_TopUsers != nil {
+			return *x.xxx_hidden_TopUsers
+		}
+	}
+	return nil
+}
+
+func (x *StatsResponse) SetUrls(v int32) {
+	x.xxx_hidden_Urls = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 0, 10)
+}
+
+func (x *StatsResponse) SetUsers(v int32) {
+	x.xxx_hidden_Users = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 1, 10)
+}
+
+func (x *StatsResponse) SetActiveUrls(v int32) {
+	x.xxx_hidden_ActiveUrls = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 2, 10)
+}
+
+func (x *StatsResponse) SetDeletedUrls(v int32) {
+	x.xxx_hidden_DeletedUrls = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 3, 10)
+}
+
+func (x *StatsResponse) SetStorageBytes(v int64) {
+	x.xxx_hidden_StorageBytes = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 4, 10)
+}
+
+func (x *StatsResponse) SetFrom(v *timestamppb.Timestamp) {
+	x.xxx_hidden_From = v
+}
+
+func (x *StatsResponse) SetTo(v *timestamppb.Timestamp) {
+	x.xxx_hidden_To = v
+}
+
+func (x *StatsResponse) SetPerDay(v []*DailyStats) {
+	x.xxx_hidden_PerDay = &v
+}
+
+func (x *StatsResponse) SetTopUrls(v []*URLClicks) {
+	x.xxx_hidden_TopUrls = &v
+}
+
+func (x *StatsResponse) SetTopUsers(v []*UserStats) {
+	x.xxx_hidden_TopUsers = &v
+}
+
+func (x *StatsResponse) HasUrls() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 0)
+}
+
+func (x *StatsResponse) HasUsers() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 1)
+}
+
+func (x *StatsResponse) HasActiveUrls() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 2)
+}
+
+func (x *StatsResponse) HasDeletedUrls() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 3)
+}
+
+func (x *StatsResponse) HasStorageBytes() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 4)
+}
+
+func (x *StatsResponse) HasFrom() bool {
+	if x == nil {
+		return false
+	}
+	return x.xxx_hidden_From != nil
+}
+
+func (x *StatsResponse) HasTo() bool {
+	if x == nil {
+		return false
+	}
+	return x.xxx_hidden_To != nil
+}
+
+func (x *StatsResponse) ClearUrls() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 0)
+	x.xxx_hidden_Urls = 0
+}
+
+func (x *StatsResponse) ClearUsers() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 1)
+	x.xxx_hidden_Users = 0
+}
+
+func (x *StatsResponse) ClearActiveUrls() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 2)
+	x.xxx_hidden_ActiveUrls = 0
+}
+
+func (x *StatsResponse) ClearDeletedUrls() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 3)
+	x.xxx_hidden_DeletedUrls = 0
+}
+
+func (x *StatsResponse) ClearStorageBytes() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 4)
+	x.xxx_hidden_StorageBytes = 0
+}
+
+func (x *StatsResponse) ClearFrom() {
+	x.xxx_hidden_From = nil
+}
+
+func (x *StatsResponse) ClearTo() {
+	x.xxx_hidden_To = nil
+}
+
+type StatsResponse_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	Urls         *int32
+	Users        *int32
+	ActiveUrls   *int32
+	DeletedUrls  *int32
+	StorageBytes *int64
+	From         *timestamppb.Timestamp
+	To           *timestamppb.Timestamp
+	PerDay       []*DailyStats
+	TopUrls      []*URLClicks
+	TopUsers     []*UserStats
+}
+
+func (b0 StatsResponse_builder) Build() *StatsResponse {
+	m0 := &StatsResponse{}
+	b, x := &b0, m0
+	_, _ = b, x
+	if b.Urls != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 0, 10)
+		x.xxx_hidden_Urls = *b.Urls
+	}
+	if b.Users != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 1, 10)
+		x.xxx_hidden_Users = *b.Users
+	}
+	if b.ActiveUrls != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 2, 10)
+		x.xxx_hidden_ActiveUrls = *b.ActiveUrls
+	}
+	if b.DeletedUrls != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 3, 10)
+		x.xxx_hidden_DeletedUrls = *b.DeletedUrls
+	}
+	if b.StorageBytes != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 4, 10)
+		x.xxx_hidden_StorageBytes = *b.StorageBytes
+	}
+	x.xxx_hidden_From = b.From
+	x.xxx_hidden_To = b.To
+	x.xxx_hidden_PerDay = &b.PerDay
+	x.xxx_hidden_TopUrls = &b.TopUrls
+	x.xxx_hidden_TopUsers = &b.TopUsers
+	return m0
+}
+
+var File_internal_proto_shortener_proto protoreflect.FileDescriptor
+
+const file_internal_proto_shortener_proto_rawDesc = "" +
 	"\n" +
-	"\x1einternal/proto/shortener.proto\x12\x05proto\x1a\x1bgoogle/protobuf/empty.proto\"%\n" +
+	"\x1einternal/proto/shortener.proto\x12\x05proto\x1a\x1fgoogle/protobuf/timestamp.proto\"\x82\x02\n" +
 	"\x11URLShortenRequest\x12\x10\n" +
-	"\x03url\x18\x01 \x01(\tR\x03url\",\n" +
+	"\x03url\x18\x01 \x01(\tR\x03url\x12\x12\n" +
+	"\x04tags\x18\x02 \x03(\tR\x04tags\x12\x16\n" +
+	"\x06folder\x18\x03 \x01(\tR\x06folder\x12\x14\n" +
+	"\x05title\x18\x04 \x01(\tR\x05title\x12 \n" +
+	"\vdescription\x18\x05 \x01(\tR\vdescription\x126\n" +
+	"\fdestinations\x18\x06 \x03(\v2\x12.proto.DestinationR\fdestinations\x12'\n" +
+	"\x05rules\x18\a \x03(\v2\x11.proto.TargetRuleR\x05rules\x12\x16\n" +
+	"\x06domain\x18\b \x01(\tR\x06domain\",\n" +
 	"\x12URLShortenResponse\x12\x16\n" +
-	"\x06result\x18\x01 \x01(\tR\x06result\"\"\n" +
+	"\x06result\x18\x01 \x01(\tR\x06result\":\n" +
 	"\x10URLExpandRequest\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\tR\x02id\"+\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x16\n" +
+	"\x06domain\x18\x02 \x01(\tR\x06domain\"+\n" +
 	"\x11URLExpandResponse\x12\x16\n" +
-	"\x06result\x18\x01 \x01(\tR\x06result\"4\n" +
+	"\x06result\x18\x01 \x01(\tR\x06result\"?\n" +
+	"\x13ListUserURLsRequest\x12\x10\n" +
+	"\x03tag\x18\x01 \x01(\tR\x03tag\x12\x16\n" +
+	"\x06folder\x18\x02 \x01(\tR\x06folder\"\x81\x01\n" +
+	"\x15StreamUserURLsRequest\x12\x10\n" +
+	"\x03tag\x18\x01 \x01(\tR\x03tag\x12\x16\n" +
+	"\x06folder\x18\x02 \x01(\tR\x06folder\x12\x1b\n" +
+	"\tpage_size\x18\x03 \x01(\x05R\bpageSize\x12!\n" +
+	"\fresume_token\x18\x04 \x01(\tR\vresumeToken\"T\n" +
+	"\rUserURLsChunk\x12 \n" +
+	"\x03url\x18\x01 \x03(\v2\x0e.proto.URLDataR\x03url\x12!\n" +
+	"\fresume_token\x18\x02 \x01(\tR\vresumeToken\"W\n" +
+	"\x15SearchUserURLsRequest\x12\x12\n" +
+	"\x04mode\x18\x01 \x01(\tR\x04mode\x12\x14\n" +
+	"\x05query\x18\x02 \x01(\tR\x05query\x12\x14\n" +
+	"\x05limit\x18\x03 \x01(\x05R\x05limit\"4\n" +
 	"\x10UserURLsResponse\x12 \n" +
-	"\x03url\x18\x01 \x03(\v2\x0e.proto.URLDataR\x03url\"I\n" +
+	"\x03url\x18\x01 \x03(\v2\x0e.proto.URLDataR\x03url\"\x8e\x02\n" +
 	"\aURLData\x12\x1b\n" +
 	"\tshort_url\x18\x01 \x01(\tR\bshortUrl\x12!\n" +
-	"\foriginal_url\x18\x02 \x01(\tR\voriginalUrl2\xd6\x01\n" +
+	"\foriginal_url\x18\x02 \x01(\tR\voriginalUrl\x12\x12\n" +
+	"\x04tags\x18\x03 \x03(\tR\x04tags\x12\x16\n" +
+	"\x06folder\x18\x04 \x01(\tR\x06folder\x12\x14\n" +
+	"\x05title\x18\x05 \x01(\tR\x05title\x12 \n" +
+	"\vdescription\x18\x06 \x01(\tR\vdescription\x126\n" +
+	"\fdestinations\x18\a \x03(\v2\x12.proto.DestinationR\fdestinations\x12'\n" +
+	"\x05rules\x18\b \x03(\v2\x11.proto.TargetRuleR\x05rules\"O\n" +
+	"\vDestination\x12\x10\n" +
+	"\x03url\x18\x01 \x01(\tR\x03url\x12\x16\n" +
+	"\x06weight\x18\x02 \x01(\x05R\x06weight\x12\x16\n" +
+	"\x06clicks\x18\x03 \x01(\x03R\x06clicks\"V\n" +
+	"\n" +
+	"TargetRule\x12\x1a\n" +
+	"\bplatform\x18\x01 \x01(\tR\bplatform\x12\x1a\n" +
+	"\blanguage\x18\x02 \x01(\tR\blanguage\x12\x10\n" +
+	"\x03url\x18\x03 \x01(\tR\x03url\"\xd1\x01\n" +
+	"\tBatchItem\x12%\n" +
+	"\x0ecorrelation_id\x18\x01 \x01(\tR\rcorrelationId\x12!\n" +
+	"\foriginal_url\x18\x02 \x01(\tR\voriginalUrl\x12\x12\n" +
+	"\x04tags\x18\x03 \x03(\tR\x04tags\x12\x16\n" +
+	"\x06folder\x18\x04 \x01(\tR\x06folder\x12\x14\n" +
+	"\x05title\x18\x05 \x01(\tR\x05title\x12 \n" +
+	"\vdescription\x18\x06 \x01(\tR\vdescription\x12\x16\n" +
+	"\x06domain\x18\a \x01(\tR\x06domain\"=\n" +
+	"\x13ShortenBatchRequest\x12&\n" +
+	"\x05items\x18\x01 \x03(\v2\x10.proto.BatchItemR\x05items\"Q\n" +
+	"\vBatchResult\x12%\n" +
+	"\x0ecorrelation_id\x18\x01 \x01(\tR\rcorrelationId\x12\x1b\n" +
+	"\tshort_url\x18\x02 \x01(\tR\bshortUrl\"D\n" +
+	"\x14ShortenBatchResponse\x12,\n" +
+	"\aresults\x18\x01 \x03(\v2\x12.proto.BatchResultR\aresults\"q\n" +
+	"\x13ShortenStreamResult\x12%\n" +
+	"\x0ecorrelation_id\x18\x01 \x01(\tR\rcorrelationId\x12\x1b\n" +
+	"\tshort_url\x18\x02 \x01(\tR\bshortUrl\x12\x16\n" +
+	"\x06status\x18\x03 \x01(\tR\x06status\"N\n" +
+	"\x15DeleteUserURLsRequest\x12\x1d\n" +
+	"\n" +
+	"short_urls\x18\x01 \x03(\tR\tshortUrls\x12\x16\n" +
+	"\x06domain\x18\x02 \x01(\tR\x06domain\"\x18\n" +
+	"\x16DeleteUserURLsResponse\"\r\n" +
+	"\vPingRequest\"\x0e\n" +
+	"\fPingResponse\"|\n" +
+	"\fStatsRequest\x12.\n" +
+	"\x04from\x18\x01 \x01(\v2\x1a.google.protobuf.TimestampR\x04from\x12*\n" +
+	"\x02to\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\x02to\x12\x10\n" +
+	"\x03top\x18\x03 \x01(\x05R\x03top\"6\n" +
+	"\n" +
+	"DailyStats\x12\x12\n" +
+	"\x04date\x18\x01 \x01(\tR\x04date\x12\x14\n" +
+	"\x05count\x18\x02 \x01(\x05R\x05count\"c\n" +
+	"\tURLClicks\x12\x1b\n" +
+	"\tshort_url\x18\x01 \x01(\tR\bshortUrl\x12!\n" +
+	"\foriginal_url\x18\x02 \x01(\tR\voriginalUrl\x12\x16\n" +
+	"\x06clicks\x18\x03 \x01(\x03R\x06clicks\"8\n" +
+	"\tUserStats\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x12\n" +
+	"\x04urls\x18\x02 \x01(\x05R\x04urls\"\x86\x03\n" +
+	"\rStatsResponse\x12\x12\n" +
+	"\x04urls\x18\x01 \x01(\x05R\x04urls\x12\x14\n" +
+	"\x05users\x18\x02 \x01(\x05R\x05users\x12\x1f\n" +
+	"\vactive_urls\x18\x03 \x01(\x05R\n" +
+	"activeUrls\x12!\n" +
+	"\fdeleted_urls\x18\x04 \x01(\x05R\vdeletedUrls\x12#\n" +
+	"\rstorage_bytes\x18\x05 \x01(\x03R\fstorageBytes\x12.\n" +
+	"\x04from\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\x04from\x12*\n" +
+	"\x02to\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\x02to\x12*\n" +
+	"\aper_day\x18\b \x03(\v2\x11.proto.DailyStatsR\x06perDay\x12+\n" +
+	"\btop_urls\x18\t \x03(\v2\x10.proto.URLClicksR\atopUrls\x12-\n" +
+	"\ttop_users\x18\n" +
+	" \x03(\v2\x10.proto.UserStatsR\btopUsers2\xae\x05\n" +
 	"\x10ShortenerService\x12A\n" +
 	"\n" +
 	"ShortenURL\x12\x18.proto.URLShortenRequest\x1a\x19.proto.URLShortenResponse\x12>\n" +
-	"\tExpandURL\x12\x17.proto.URLExpandRequest\x1a\x18.proto.URLExpandResponse\x12?\n" +
-	"\fListUserURLs\x12\x16.google.protobuf.Empty\x1a\x17.proto.UserURLsResponseB'Z%github.com/Di-nis/shortener-url/protob\beditionsp\xe8\a"
-
-var file_internal_proto_shortener_proto_msgTypes = make([]protoimpl.MessageInfo, 6)
+	"\tExpandURL\x12\x17.proto.URLExpandRequest\x1a\x18.proto.URLExpandResponse\x12C\n" +
+	"\fListUserURLs\x12\x1a.proto.ListUserURLsRequest\x1a\x17.proto.UserURLsResponse\x12F\n" +
+	"\x0eStreamUserURLs\x12\x1c.proto.StreamUserURLsRequest\x1a\x14.proto.UserURLsChunk0\x01\x12G\n" +
+	"\x0eSearchUserURLs\x12\x1c.proto.SearchUserURLsRequest\x1a\x17.proto.UserURLsResponse\x12G\n" +
+	"\fShortenBatch\x12\x1a.proto.ShortenBatchRequest\x1a\x1b.proto.ShortenBatchResponse\x12A\n" +
+	"\rShortenStream\x12\x10.proto.BatchItem\x1a\x1a.proto.ShortenStreamResult(\x010\x01\x12M\n" +
+	"\x0eDeleteUserURLs\x12\x1c.proto.DeleteUserURLsRequest\x1a\x1d.proto.DeleteUserURLsResponse\x12/\n" +
+	"\x04Ping\x12\x12.proto.PingRequest\x1a\x13.proto.PingResponse\x125\n" +
+	"\bGetStats\x12\x13.proto.StatsRequest\x1a\x14.proto.StatsResponseB'Z%github.com/Di-nis/shortener-url/protob\beditionsp\xe8\a"
+
+var file_internal_proto_shortener_proto_msgTypes = make([]protoimpl.MessageInfo, 26)
 var file_internal_proto_shortener_proto_goTypes = []any{
-	(*URLShortenRequest)(nil),  // 0: proto.URLShortenRequest
-	(*URLShortenResponse)(nil), // 1: proto.URLShortenResponse
-	(*URLExpandRequest)(nil),   // 2: proto.URLExpandRequest
-	(*URLExpandResponse)(nil),  // 3: proto.URLExpandResponse
-	(*UserURLsResponse)(nil),   // 4: proto.UserURLsResponse
-	(*URLData)(nil),            // 5: proto.URLData
-	(*emptypb.Empty)(nil),      // 6: google.protobuf.Empty
+	(*URLShortenRequest)(nil),      // 0: proto.URLShortenRequest
+	(*URLShortenResponse)(nil),     // 1: proto.URLShortenResponse
+	(*URLExpandRequest)(nil),       // 2: proto.URLExpandRequest
+	(*URLExpandResponse)(nil),      // 3: proto.URLExpandResponse
+	(*ListUserURLsRequest)(nil),    // 4: proto.ListUserURLsRequest
+	(*StreamUserURLsRequest)(nil),  // 5: proto.StreamUserURLsRequest
+	(*UserURLsChunk)(nil),          // 6: proto.UserURLsChunk
+	(*SearchUserURLsRequest)(nil),  // 7: proto.SearchUserURLsRequest
+	(*UserURLsResponse)(nil),       // 8: proto.UserURLsResponse
+	(*URLData)(nil),                // 9: proto.URLData
+	(*Destination)(nil),            // 10: proto.Destination
+	(*TargetRule)(nil),             // 11: proto.TargetRule
+	(*BatchItem)(nil),              // 12: proto.BatchItem
+	(*ShortenBatchRequest)(nil),    // 13: proto.ShortenBatchRequest
+	(*BatchResult)(nil),            // 14: proto.BatchResult
+	(*ShortenBatchResponse)(nil),   // 15: proto.ShortenBatchResponse
+	(*ShortenStreamResult)(nil),    // 16: proto.ShortenStreamResult
+	(*DeleteUserURLsRequest)(nil),  // 17: proto.DeleteUserURLsRequest
+	(*DeleteUserURLsResponse)(nil), // 18: proto.DeleteUserURLsResponse
+	(*PingRequest)(nil),            // 19: proto.PingRequest
+	(*PingResponse)(nil),           // 20: proto.PingResponse
+	(*StatsRequest)(nil),           // 21: proto.StatsRequest
+	(*DailyStats)(nil),             // 22: proto.DailyStats
+	(*URLClicks)(nil),              // 23: proto.URLClicks
+	(*UserStats)(nil),              // 24: proto.UserStats
+	(*StatsResponse)(nil),          // 25: proto.StatsResponse
+	(*timestamppb.Timestamp)(nil),  // 26: google.protobuf.Timestamp
 }
 var file_internal_proto_shortener_proto_depIdxs = []int32{
-	5, // 0: proto.UserURLsResponse.url:type_name -> proto.URLData
-	0, // 1: proto.ShortenerService.ShortenURL:input_type -> proto.URLShortenRequest
-	2, // 2: proto.ShortenerService.ExpandURL:input_type -> proto.URLExpandRequest
-	6, // 3: proto.ShortenerService.ListUserURLs:input_type -> google.protobuf.Empty
-	1, // 4: proto.ShortenerService.ShortenURL:output_type -> proto.URLShortenResponse
-	3, // 5: proto.ShortenerService.ExpandURL:output_type -> proto.URLExpandResponse
-	4, // 6: proto.ShortenerService.ListUserURLs:output_type -> proto.UserURLsResponse
-	4, // [4:7] is the sub-list for method output_type
-	1, // [1:4] is the sub-list for method input_type
-	1, // [1:1] is the sub-list for extension type_name
-	1, // [1:1] is the sub-list for extension extendee
-	0, // [0:1] is the sub-list for field type_name
+	10, // 0: proto.URLShortenRequest.destinations:type_name -> proto.Destination
+	11, // 1: proto.URLShortenRequest.rules:type_name -> proto.TargetRule
+	9,  // 2: proto.UserURLsChunk.url:type_name -> proto.URLData
+	9,  // 3: proto.UserURLsResponse.url:type_name -> proto.URLData
+	10, // 4: proto.URLData.destinations:type_name -> proto.Destination
+	11, // 5: proto.URLData.rules:type_name -> proto.TargetRule
+	12, // 6: proto.ShortenBatchRequest.items:type_name -> proto.BatchItem
+	14, // 7: proto.ShortenBatchResponse.results:type_name -> proto.BatchResult
+	26, // 8: proto.StatsRequest.from:type_name -> google.protobuf.Timestamp
+	26, // 9: proto.StatsRequest.to:type_name -> google.protobuf.Timestamp
+	26, // 10: proto.StatsResponse.from:type_name -> google.protobuf.Timestamp
+	26, // 11: proto.StatsResponse.to:type_name -> google.protobuf.Timestamp
+	22, // 12: proto.StatsResponse.per_day:type_name -> proto.DailyStats
+	23, // 13: proto.StatsResponse.top_urls:type_name -> proto.URLClicks
+	24, // 14: proto.StatsResponse.top_users:type_name -> proto.UserStats
+	0,  // 15: proto.ShortenerService.ShortenURL:input_type -> proto.URLShortenRequest
+	2,  // 16: proto.ShortenerService.ExpandURL:input_type -> proto.URLExpandRequest
+	4,  // 17: proto.ShortenerService.ListUserURLs:input_type -> proto.ListUserURLsRequest
+	5,  // 18: proto.ShortenerService.StreamUserURLs:input_type -> proto.StreamUserURLsRequest
+	7,  // 19: proto.ShortenerService.SearchUserURLs:input_type -> proto.SearchUserURLsRequest
+	13, // 20: proto.ShortenerService.ShortenBatch:input_type -> proto.ShortenBatchRequest
+	12, // 21: proto.ShortenerService.ShortenStream:input_type -> proto.BatchItem
+	17, // 22: proto.ShortenerService.DeleteUserURLs:input_type -> proto.DeleteUserURLsRequest
+	19, // 23: proto.ShortenerService.Ping:input_type -> proto.PingRequest
+	21, // 24: proto.ShortenerService.GetStats:input_type -> proto.StatsRequest
+	1,  // 25: proto.ShortenerService.ShortenURL:output_type -> proto.URLShortenResponse
+	3,  // 26: proto.ShortenerService.ExpandURL:output_type -> proto.URLExpandResponse
+	8,  // 27: proto.ShortenerService.ListUserURLs:output_type -> proto.UserURLsResponse
+	6,  // 28: proto.ShortenerService.StreamUserURLs:output_type -> proto.UserURLsChunk
+	8,  // 29: proto.ShortenerService.SearchUserURLs:output_type -> proto.UserURLsResponse
+	15, // 30: proto.ShortenerService.ShortenBatch:output_type -> proto.ShortenBatchResponse
+	16, // 31: proto.ShortenerService.ShortenStream:output_type -> proto.ShortenStreamResult
+	18, // 32: proto.ShortenerService.DeleteUserURLs:output_type -> proto.DeleteUserURLsResponse
+	20, // 33: proto.ShortenerService.Ping:output_type -> proto.PingResponse
+	25, // 34: proto.ShortenerService.GetStats:output_type -> proto.StatsResponse
+	25, // [25:35] is the sub-list for method output_type
+	15, // [15:25] is the sub-list for method input_type
+	15, // [15:15] is the sub-list for extension type_name
+	15, // [15:15] is the sub-list for extension extendee
+	0,  // [0:15] is the sub-list for field type_name
 }
 
 func init() { file_internal_proto_shortener_proto_init() }
@@ -564,7 +3507,7 @@ func file_internal_proto_shortener_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_internal_proto_shortener_proto_rawDesc), len(file_internal_proto_shortener_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   6,
+			NumMessages:   26,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -12,7 +12,6 @@ import (
 	grpc "google.golang.org/grpc"
 	codes "google.golang.org/grpc/codes"
 	status "google.golang.org/grpc/status"
-	emptypb "google.golang.org/protobuf/types/known/emptypb"
 )
 
 // This is a compile-time assertion to ensure that this generated file
@@ -21,9 +20,16 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	ShortenerService_ShortenURL_FullMethodName   = "/proto.ShortenerService/ShortenURL"
-	ShortenerService_ExpandURL_FullMethodName    = "/proto.ShortenerService/ExpandURL"
-	ShortenerService_ListUserURLs_FullMethodName = "/proto.ShortenerService/ListUserURLs"
+	ShortenerService_ShortenURL_FullMethodName     = "/proto.ShortenerService/ShortenURL"
+	ShortenerService_ExpandURL_FullMethodName      = "/proto.ShortenerService/ExpandURL"
+	ShortenerService_ListUserURLs_FullMethodName   = "/proto.ShortenerService/ListUserURLs"
+	ShortenerService_StreamUserURLs_FullMethodName = "/proto.ShortenerService/StreamUserURLs"
+	ShortenerService_SearchUserURLs_FullMethodName = "/proto.ShortenerService/SearchUserURLs"
+	ShortenerService_ShortenBatch_FullMethodName   = "/proto.ShortenerService/ShortenBatch"
+	ShortenerService_ShortenStream_FullMethodName  = "/proto.ShortenerService/ShortenStream"
+	ShortenerService_DeleteUserURLs_FullMethodName = "/proto.ShortenerService/DeleteUserURLs"
+	ShortenerService_Ping_FullMethodName           = "/proto.ShortenerService/Ping"
+	ShortenerService_GetStats_FullMethodName       = "/proto.ShortenerService/GetStats"
 )
 
 // ShortenerServiceClient is the client API for ShortenerService service.
@@ -32,7 +38,14 @@ const (
 type ShortenerServiceClient interface {
 	ShortenURL(ctx context.Context, in *URLShortenRequest, opts ...grpc.CallOption) (*URLShortenResponse, error)
 	ExpandURL(ctx context.Context, in *URLExpandRequest, opts ...grpc.CallOption) (*URLExpandResponse, error)
-	ListUserURLs(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*UserURLsResponse, error)
+	ListUserURLs(ctx context.Context, in *ListUserURLsRequest, opts ...grpc.CallOption) (*UserURLsResponse, error)
+	StreamUserURLs(ctx context.Context, in *StreamUserURLsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[UserURLsChunk], error)
+	SearchUserURLs(ctx context.Context, in *SearchUserURLsRequest, opts ...grpc.CallOption) (*UserURLsResponse, error)
+	ShortenBatch(ctx context.Context, in *ShortenBatchRequest, opts ...grpc.CallOption) (*ShortenBatchResponse, error)
+	ShortenStream(ctx context.Context, opts ...grpc.CallOption) (grpc.BidiStreamingClient[BatchItem, ShortenStreamResult], error)
+	DeleteUserURLs(ctx context.Context, in *DeleteUserURLsRequest, opts ...grpc.CallOption) (*DeleteUserURLsResponse, error)
+	Ping(ctx context.Context, in *PingRequest, opts ...grpc.CallOption) (*PingResponse, error)
+	GetStats(ctx context.Context, in *StatsRequest, opts ...grpc.CallOption) (*StatsResponse, error)
 }
 
 type shortenerServiceClient struct {
@@ -63,7 +76,7 @@ func (c *shortenerServiceClient) ExpandURL(ctx context.Context, in *URLExpandReq
 	return out, nil
 }
 
-func (c *shortenerServiceClient) ListUserURLs(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*UserURLsResponse, error) {
+func (c *shortenerServiceClient) ListUserURLs(ctx context.Context, in *ListUserURLsRequest, opts ...grpc.CallOption) (*UserURLsResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(UserURLsResponse)
 	err := c.cc.Invoke(ctx, ShortenerService_ListUserURLs_FullMethodName, in, out, cOpts...)
@@ -73,13 +86,102 @@ func (c *shortenerServiceClient) ListUserURLs(ctx context.Context, in *emptypb.E
 	return out, nil
 }
 
+func (c *shortenerServiceClient) StreamUserURLs(ctx context.Context, in *StreamUserURLsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[UserURLsChunk], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &ShortenerService_ServiceDesc.Streams[0], ShortenerService_StreamUserURLs_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[StreamUserURLsRequest, UserURLsChunk]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type ShortenerService_StreamUserURLsClient = grpc.ServerStreamingClient[UserURLsChunk]
+
+func (c *shortenerServiceClient) SearchUserURLs(ctx context.Context, in *SearchUserURLsRequest, opts ...grpc.CallOption) (*UserURLsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UserURLsResponse)
+	err := c.cc.Invoke(ctx, ShortenerService_SearchUserURLs_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *shortenerServiceClient) ShortenBatch(ctx context.Context, in *ShortenBatchRequest, opts ...grpc.CallOption) (*ShortenBatchResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ShortenBatchResponse)
+	err := c.cc.Invoke(ctx, ShortenerService_ShortenBatch_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *shortenerServiceClient) ShortenStream(ctx context.Context, opts ...grpc.CallOption) (grpc.BidiStreamingClient[BatchItem, ShortenStreamResult], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &ShortenerService_ServiceDesc.Streams[1], ShortenerService_ShortenStream_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[BatchItem, ShortenStreamResult]{ClientStream: stream}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type ShortenerService_ShortenStreamClient = grpc.BidiStreamingClient[BatchItem, ShortenStreamResult]
+
+func (c *shortenerServiceClient) DeleteUserURLs(ctx context.Context, in *DeleteUserURLsRequest, opts ...grpc.CallOption) (*DeleteUserURLsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeleteUserURLsResponse)
+	err := c.cc.Invoke(ctx, ShortenerService_DeleteUserURLs_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *shortenerServiceClient) Ping(ctx context.Context, in *PingRequest, opts ...grpc.CallOption) (*PingResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PingResponse)
+	err := c.cc.Invoke(ctx, ShortenerService_Ping_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *shortenerServiceClient) GetStats(ctx context.Context, in *StatsRequest, opts ...grpc.CallOption) (*StatsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(StatsResponse)
+	err := c.cc.Invoke(ctx, ShortenerService_GetStats_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // ShortenerServiceServer is the server API for ShortenerService service.
 // All implementations must embed UnimplementedShortenerServiceServer
 // for forward compatibility.
 type ShortenerServiceServer interface {
 	ShortenURL(context.Context, *URLShortenRequest) (*URLShortenResponse, error)
 	ExpandURL(context.Context, *URLExpandRequest) (*URLExpandResponse, error)
-	ListUserURLs(context.Context, *emptypb.Empty) (*UserURLsResponse, error)
+	ListUserURLs(context.Context, *ListUserURLsRequest) (*UserURLsResponse, error)
+	StreamUserURLs(*StreamUserURLsRequest, grpc.ServerStreamingServer[UserURLsChunk]) error
+	SearchUserURLs(context.Context, *SearchUserURLsRequest) (*UserURLsResponse, error)
+	ShortenBatch(context.Context, *ShortenBatchRequest) (*ShortenBatchResponse, error)
+	ShortenStream(grpc.BidiStreamingServer[BatchItem, ShortenStreamResult]) error
+	DeleteUserURLs(context.Context, *DeleteUserURLsRequest) (*DeleteUserURLsResponse, error)
+	Ping(context.Context, *PingRequest) (*PingResponse, error)
+	GetStats(context.Context, *StatsRequest) (*StatsResponse, error)
 	mustEmbedUnimplementedShortenerServiceServer()
 }
 
@@ -96,9 +198,30 @@ func (UnimplementedShortenerServiceServer) ShortenURL(context.Context, *URLShort
 func (UnimplementedShortenerServiceServer) ExpandURL(context.Context, *URLExpandRequest) (*URLExpandResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method ExpandURL not implemented")
 }
-func (UnimplementedShortenerServiceServer) ListUserURLs(context.Context, *emptypb.Empty) (*UserURLsResponse, error) {
+func (UnimplementedShortenerServiceServer) ListUserURLs(context.Context, *ListUserURLsRequest) (*UserURLsResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method ListUserURLs not implemented")
 }
+func (UnimplementedShortenerServiceServer) StreamUserURLs(*StreamUserURLsRequest, grpc.ServerStreamingServer[UserURLsChunk]) error {
+	return status.Error(codes.Unimplemented, "method StreamUserURLs not implemented")
+}
+func (UnimplementedShortenerServiceServer) SearchUserURLs(context.Context, *SearchUserURLsRequest) (*UserURLsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method SearchUserURLs not implemented")
+}
+func (UnimplementedShortenerServiceServer) ShortenBatch(context.Context, *ShortenBatchRequest) (*ShortenBatchResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ShortenBatch not implemented")
+}
+func (UnimplementedShortenerServiceServer) ShortenStream(grpc.BidiStreamingServer[BatchItem, ShortenStreamResult]) error {
+	return status.Error(codes.Unimplemented, "method ShortenStream not implemented")
+}
+func (UnimplementedShortenerServiceServer) DeleteUserURLs(context.Context, *DeleteUserURLsRequest) (*DeleteUserURLsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method DeleteUserURLs not implemented")
+}
+func (UnimplementedShortenerServiceServer) Ping(context.Context, *PingRequest) (*PingResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method Ping not implemented")
+}
+func (UnimplementedShortenerServiceServer) GetStats(context.Context, *StatsRequest) (*StatsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetStats not implemented")
+}
 func (UnimplementedShortenerServiceServer) mustEmbedUnimplementedShortenerServiceServer() {}
 func (UnimplementedShortenerServiceServer) testEmbeddedByValue()                          {}
 
@@ -157,7 +280,7 @@ func _ShortenerService_ExpandURL_Handler(srv interface{}, ctx context.Context, d
 }
 
 func _ShortenerService_ListUserURLs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(emptypb.Empty)
+	in := new(ListUserURLsRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
@@ -169,7 +292,115 @@ func _ShortenerService_ListUserURLs_Handler(srv interface{}, ctx context.Context
 		FullMethod: ShortenerService_ListUserURLs_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ShortenerServiceServer).ListUserURLs(ctx, req.(*emptypb.Empty))
+		return srv.(ShortenerServiceServer).ListUserURLs(ctx, req.(*ListUserURLsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ShortenerService_StreamUserURLs_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(StreamUserURLsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ShortenerServiceServer).StreamUserURLs(m, &grpc.GenericServerStream[StreamUserURLsRequest, UserURLsChunk]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type ShortenerService_StreamUserURLsServer = grpc.ServerStreamingServer[UserURLsChunk]
+
+func _ShortenerService_SearchUserURLs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SearchUserURLsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ShortenerServiceServer).SearchUserURLs(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ShortenerService_SearchUserURLs_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ShortenerServiceServer).SearchUserURLs(ctx, req.(*SearchUserURLsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ShortenerService_ShortenBatch_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ShortenBatchRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ShortenerServiceServer).ShortenBatch(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ShortenerService_ShortenBatch_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ShortenerServiceServer).ShortenBatch(ctx, req.(*ShortenBatchRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ShortenerService_ShortenStream_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(ShortenerServiceServer).ShortenStream(&grpc.GenericServerStream[BatchItem, ShortenStreamResult]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type ShortenerService_ShortenStreamServer = grpc.BidiStreamingServer[BatchItem, ShortenStreamResult]
+
+func _ShortenerService_DeleteUserURLs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteUserURLsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ShortenerServiceServer).DeleteUserURLs(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ShortenerService_DeleteUserURLs_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ShortenerServiceServer).DeleteUserURLs(ctx, req.(*DeleteUserURLsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ShortenerService_Ping_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PingRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ShortenerServiceServer).Ping(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ShortenerService_Ping_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ShortenerServiceServer).Ping(ctx, req.(*PingRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ShortenerService_GetStats_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(StatsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ShortenerServiceServer).GetStats(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ShortenerService_GetStats_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ShortenerServiceServer).GetStats(ctx, req.(*StatsRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
@@ -193,7 +424,39 @@ var ShortenerService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "ListUserURLs",
 			Handler:    _ShortenerService_ListUserURLs_Handler,
 		},
+		{
+			MethodName: "SearchUserURLs",
+			Handler:    _ShortenerService_SearchUserURLs_Handler,
+		},
+		{
+			MethodName: "ShortenBatch",
+			Handler:    _ShortenerService_ShortenBatch_Handler,
+		},
+		{
+			MethodName: "DeleteUserURLs",
+			Handler:    _ShortenerService_DeleteUserURLs_Handler,
+		},
+		{
+			MethodName: "Ping",
+			Handler:    _ShortenerService_Ping_Handler,
+		},
+		{
+			MethodName: "GetStats",
+			Handler:    _ShortenerService_GetStats_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "StreamUserURLs",
+			Handler:       _ShortenerService_StreamUserURLs_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "ShortenStream",
+			Handler:       _ShortenerService_ShortenStream_Handler,
+			ServerStreams: true,
+			ClientStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "internal/proto/shortener.proto",
 }
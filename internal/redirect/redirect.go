@@ -0,0 +1,183 @@
+// Package redirect реализует построение адреса перенаправления: выбор адреса
+// назначения по весам, перенос параметров запроса и дополнительных сегментов пути.
+package redirect
+
+import (
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"math/rand/v2"
+	"net/url"
+	"path"
+	"strings"
+
+	"github.com/Di-nis/shortener-url/internal/models"
+)
+
+// Правила объединения повторяющихся параметров запроса.
+const (
+	// Параметр оригинального URL сохраняется, входящий отбрасывается
+	ConflictKeep = "keep"
+	// Входящий параметр заменяет параметр оригинального URL
+	ConflictOverride = "override"
+	// Сохраняются оба значения: сначала оригинальное, затем входящее
+	ConflictAppend = "append"
+)
+
+// ErrPathNotAllowed - перенос сегментов пути для ссылки не разрешен.
+var ErrPathNotAllowed = errors.New("path pass-through is not allowed")
+
+// Policy - правила построения адреса перенаправления.
+type Policy struct {
+	PassQuery     bool
+	PassPath      bool
+	QueryConflict string
+}
+
+// queryParam - параметр запроса в исходном (закодированном) виде.
+type queryParam struct {
+	key string
+	raw string
+}
+
+// BuildURL - построение адреса перенаправления по оригинальному URL,
+// строке входящего запроса и дополнительным сегментам пути.
+func BuildURL(original string, policy Policy, rawQuery, extraPath string) (string, error) {
+	if extraPath != "" && !policy.PassPath {
+		return "", ErrPathNotAllowed
+	}
+	if (rawQuery == "" || !policy.PassQuery) && extraPath == "" {
+		return original, nil
+	}
+
+	target, err := url.Parse(original)
+	if err != nil {
+		return "", fmt.Errorf("path: internal/redirect/redirect.go, func BuildURL(), failed to parse url: %w", err)
+	}
+
+	if extraPath != "" {
+		joinPath(target, extraPath)
+	}
+
+	if policy.PassQuery && rawQuery != "" {
+		target.RawQuery = mergeQuery(target.RawQuery, rawQuery, policy.QueryConflict)
+	}
+
+	return target.String(), nil
+}
+
+// joinPath - добавление сегментов пути к оригинальному URL.
+// Сегменты нормализуются, поэтому выйти за пределы пути оригинального URL нельзя.
+func joinPath(target *url.URL, extraPath string) {
+	cleaned := path.Clean("/" + extraPath)
+	if strings.HasSuffix(extraPath, "/") && cleaned != "/" {
+		cleaned += "/"
+	}
+
+	base := strings.TrimSuffix(target.EscapedPath(), "/")
+	escaped := base + (&url.URL{Path: cleaned}).EscapedPath()
+
+	if unescaped, err := url.PathUnescape(escaped); err == nil {
+		target.Path = unescaped
+		target.RawPath = escaped
+	}
+}
+
+// mergeQuery - объединение параметров оригинального URL и входящего запроса.
+// Порядок и кодирование параметров сохраняются.
+func mergeQuery(originalQuery, incomingQuery, conflict string) string {
+	original := splitQuery(originalQuery)
+	incoming := splitQuery(incomingQuery)
+
+	originalKeys := make(map[string]struct{}, len(original))
+	for _, param := range original {
+		originalKeys[param.key] = struct{}{}
+	}
+	incomingKeys := make(map[string]struct{}, len(incoming))
+	for _, param := range incoming {
+		incomingKeys[param.key] = struct{}{}
+	}
+
+	merged := make([]string, 0, len(original)+len(incoming))
+	switch conflict {
+	case ConflictOverride:
+		for _, param := range original {
+			if _, ok := incomingKeys[param.key]; !ok {
+				merged = append(merged, param.raw)
+			}
+		}
+		for _, param := range incoming {
+			merged = append(merged, param.raw)
+		}
+	case ConflictAppend:
+		for _, param := range original {
+			merged = append(merged, param.raw)
+		}
+		for _, param := range incoming {
+			merged = append(merged, param.raw)
+		}
+	default:
+		for _, param := range original {
+			merged = append(merged, param.raw)
+		}
+		for _, param := range incoming {
+			if _, ok := originalKeys[param.key]; !ok {
+				merged = append(merged, param.raw)
+			}
+		}
+	}
+
+	return strings.Join(merged, "&")
+}
+
+// splitQuery - разбиение строки запроса на параметры.
+func splitQuery(rawQuery string) []queryParam {
+	var params []queryParam
+	for _, raw := range strings.Split(rawQuery, "&") {
+		if raw == "" {
+			continue
+		}
+
+		key, _, _ := strings.Cut(raw, "=")
+		if unescaped, err := url.QueryUnescape(key); err == nil {
+			key = unescaped
+		}
+		params = append(params, queryParam{key: key, raw: raw})
+	}
+	return params
+}
+
+// PickDestination - выбор адреса назначения с учетом весов.
+// Для одного и того же ключа (посетитель и короткий URL) всегда выбирается
+// один и тот же адрес, при пустом ключе адрес выбирается случайно.
+func PickDestination(destinations []models.Destination, key string) int {
+	var total uint64
+	for _, destination := range destinations {
+		if destination.Weight > 0 {
+			total += uint64(destination.Weight)
+		}
+	}
+	if total == 0 {
+		return 0
+	}
+
+	var point uint64
+	if key == "" {
+		point = rand.Uint64N(total)
+	} else {
+		hash := fnv.New64a()
+		hash.Write([]byte(key))
+		point = hash.Sum64() % total
+	}
+
+	for idx, destination := range destinations {
+		if destination.Weight <= 0 {
+			continue
+		}
+		if point < uint64(destination.Weight) {
+			return idx
+		}
+		point -= uint64(destination.Weight)
+	}
+	return len(destinations) - 1
+}
@@ -0,0 +1,202 @@
+package redirect
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/Di-nis/shortener-url/internal/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBuildURL(t *testing.T) {
+	tests := []struct {
+		name      string
+		original  string
+		policy    Policy
+		rawQuery  string
+		extraPath string
+		want      string
+		wantErr   error
+	}{
+		{
+			name:     "перенос параметров отключен",
+			original: "https://www.khl.ru/",
+			policy:   Policy{},
+			rawQuery: "utm_source=telegram",
+			want:     "https://www.khl.ru/",
+		},
+		{
+			name:     "перенос параметров в URL без параметров",
+			original: "https://www.khl.ru/",
+			policy:   Policy{PassQuery: true},
+			rawQuery: "utm_source=telegram&utm_medium=social",
+			want:     "https://www.khl.ru/?utm_source=telegram&utm_medium=social",
+		},
+		{
+			name:     "повторяющийся параметр, сохраняется оригинальный",
+			original: "https://www.khl.ru/news?ref=partner&page=2#top",
+			policy:   Policy{PassQuery: true, QueryConflict: ConflictKeep},
+			rawQuery: "ref=spam&utm_source=telegram",
+			want:     "https://www.khl.ru/news?ref=partner&page=2&utm_source=telegram#top",
+		},
+		{
+			name:     "повторяющийся параметр, сохраняется входящий",
+			original: "https://www.khl.ru/news?ref=partner&page=2",
+			policy:   Policy{PassQuery: true, QueryConflict: ConflictOverride},
+			rawQuery: "ref=telegram&ref=vk",
+			want:     "https://www.khl.ru/news?page=2&ref=telegram&ref=vk",
+		},
+		{
+			name:     "повторяющийся параметр, сохраняются оба",
+			original: "https://www.khl.ru/news?tag=hockey",
+			policy:   Policy{PassQuery: true, QueryConflict: ConflictAppend},
+			rawQuery: "tag=khl",
+			want:     "https://www.khl.ru/news?tag=hockey&tag=khl",
+		},
+		{
+			name:     "закодированные параметры сохраняются без изменений",
+			original: "https://www.khl.ru/search?q=%D1%85%D0%BE%D0%BA%D0%BA%D0%B5%D0%B9",
+			policy:   Policy{PassQuery: true},
+			rawQuery: "q=spam&utm%5Fsource=a%20b",
+			want:     "https://www.khl.ru/search?q=%D1%85%D0%BE%D0%BA%D0%BA%D0%B5%D0%B9&utm%5Fsource=a%20b",
+		},
+		{
+			name:      "перенос сегментов пути",
+			original:  "https://www.khl.ru/news/",
+			policy:    Policy{PassPath: true},
+			extraPath: "2024/match 1/",
+			want:      "https://www.khl.ru/news/2024/match%201/",
+		},
+		{
+			name:      "сегменты пути не выходят за пределы оригинального пути",
+			original:  "https://www.khl.ru/news",
+			policy:    Policy{PassPath: true, PassQuery: true},
+			rawQuery:  "utm_source=telegram",
+			extraPath: "../../admin",
+			want:      "https://www.khl.ru/news/admin?utm_source=telegram",
+		},
+		{
+			name:      "перенос сегментов пути не разрешен",
+			original:  "https://www.khl.ru/news",
+			policy:    Policy{PassQuery: true},
+			extraPath: "2024",
+			wantErr:   ErrPathNotAllowed,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, gotErr := BuildURL(tt.original, tt.policy, tt.rawQuery, tt.extraPath)
+
+			assert.Equal(t, tt.want, got)
+			assert.ErrorIs(t, gotErr, tt.wantErr)
+		})
+	}
+}
+
+func TestPickDestination(t *testing.T) {
+	destinations := []models.Destination{
+		{URL: "https://www.khl.ru/landing-a", Weight: 80},
+		{URL: "https://www.khl.ru/landing-b", Weight: 20},
+	}
+
+	t.Run("один посетитель получает один и тот же адрес", func(t *testing.T) {
+		first := PickDestination(destinations, "visitor-1:lJJpJV7h")
+		for range 10 {
+			assert.Equal(t, first, PickDestination(destinations, "visitor-1:lJJpJV7h"))
+		}
+	})
+
+	t.Run("распределение соответствует весам", func(t *testing.T) {
+		const visitors = 10000
+		counts := make([]int, len(destinations))
+		for i := range visitors {
+			counts[PickDestination(destinations, fmt.Sprintf("visitor-%d:lJJpJV7h", i))]++
+		}
+		assert.InDelta(t, 0.8, float64(counts[0])/visitors, 0.03)
+		assert.InDelta(t, 0.2, float64(counts[1])/visitors, 0.03)
+	})
+
+	t.Run("адрес с нулевым весом не выбирается", func(t *testing.T) {
+		weighted := []models.Destination{
+			{URL: "https://www.khl.ru/landing-a", Weight: 0},
+			{URL: "https://www.khl.ru/landing-b", Weight: 1},
+		}
+		for i := range 100 {
+			assert.Equal(t, 1, PickDestination(weighted, fmt.Sprintf("visitor-%d", i)))
+		}
+	})
+}
+
+func TestMatchRule(t *testing.T) {
+	const (
+		userAgentIPhone  = "Mozilla/5.0 (iPhone; CPU iPhone OS 17_5 like Mac OS X) AppleWebKit/605.1.15 Mobile/15E148"
+		userAgentAndroid = "Mozilla/5.0 (Linux; Android 14; Pixel 8) AppleWebKit/537.36 Mobile Safari/537.36"
+		userAgentWindows = "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 Chrome/126.0 Safari/537.36"
+	)
+
+	rules := []models.TargetRule{
+		{Platform: PlatformIOS, URL: "https://apps.apple.com/ru/app/khl/id1"},
+		{Platform: PlatformAndroid, Language: "en", URL: "https://play.google.com/store/apps/details?id=ru.khl&hl=en"},
+		{Platform: PlatformAndroid, URL: "https://play.google.com/store/apps/details?id=ru.khl"},
+		{Platform: PlatformDesktop, Language: "ru", URL: "https://www.khl.ru/"},
+		{Language: "en", URL: "https://en.khl.ru/"},
+	}
+
+	tests := []struct {
+		name           string
+		userAgent      string
+		acceptLanguage string
+		want           int
+		wantOk         bool
+	}{
+		{
+			name:      "iOS",
+			userAgent: userAgentIPhone,
+			want:      0,
+			wantOk:    true,
+		},
+		{
+			name:           "Android, английский язык",
+			userAgent:      userAgentAndroid,
+			acceptLanguage: "en-US,en;q=0.9,ru;q=0.8",
+			want:           1,
+			wantOk:         true,
+		},
+		{
+			name:           "Android, русский язык",
+			userAgent:      userAgentAndroid,
+			acceptLanguage: "ru-RU,ru;q=0.9,en;q=0.8",
+			want:           2,
+			wantOk:         true,
+		},
+		{
+			name:           "компьютер, язык выбирается по приоритету",
+			userAgent:      userAgentWindows,
+			acceptLanguage: "en;q=0.5,ru-RU",
+			want:           3,
+			wantOk:         true,
+		},
+		{
+			name:           "неизвестное устройство, правило только по языку",
+			userAgent:      "curl/8.5.0",
+			acceptLanguage: "en-GB",
+			want:           4,
+			wantOk:         true,
+		},
+		{
+			name:           "нет подходящего правила",
+			userAgent:      userAgentWindows,
+			acceptLanguage: "de-DE,en;q=0",
+			want:           0,
+			wantOk:         false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, gotOk := MatchRule(rules, tt.userAgent, tt.acceptLanguage)
+
+			assert.Equal(t, tt.wantOk, gotOk)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,141 @@
+package redirect
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/Di-nis/shortener-url/internal/models"
+)
+
+// Платформы, на которые могут быть нацелены правила перенаправления.
+const (
+	PlatformIOS     = "ios"
+	PlatformAndroid = "android"
+	PlatformWindows = "windows"
+	PlatformMacOS   = "macos"
+	PlatformLinux   = "linux"
+	// Любое мобильное устройство
+	PlatformMobile = "mobile"
+	// Любое устройство, кроме мобильного
+	PlatformDesktop = "desktop"
+)
+
+// Platforms - допустимые значения платформы в правилах перенаправления.
+var Platforms = []string{
+	PlatformIOS,
+	PlatformAndroid,
+	PlatformWindows,
+	PlatformMacOS,
+	PlatformLinux,
+	PlatformMobile,
+	PlatformDesktop,
+}
+
+// client - характеристики клиента, полученные из заголовков запроса.
+type client struct {
+	platform string
+	mobile   bool
+	language string
+}
+
+// detectPlatform - определение платформы и типа устройства по User-Agent.
+func detectPlatform(userAgent string) (string, bool) {
+	ua := strings.ToLower(userAgent)
+
+	switch {
+	case strings.Contains(ua, "iphone"), strings.Contains(ua, "ipad"), strings.Contains(ua, "ipod"):
+		return PlatformIOS, true
+	case strings.Contains(ua, "android"):
+		return PlatformAndroid, true
+	case strings.Contains(ua, "windows phone"):
+		return PlatformWindows, true
+	case strings.Contains(ua, "windows"):
+		return PlatformWindows, false
+	case strings.Contains(ua, "macintosh"), strings.Contains(ua, "mac os x"):
+		return PlatformMacOS, false
+	case strings.Contains(ua, "linux"), strings.Contains(ua, "x11"):
+		return PlatformLinux, false
+	}
+	return "", strings.Contains(ua, "mobile")
+}
+
+// preferredLanguage - получение наиболее предпочтительного языка из Accept-Language.
+func preferredLanguage(acceptLanguage string) string {
+	type language struct {
+		tag     string
+		quality float64
+	}
+
+	var languages []language
+	for _, part := range strings.Split(acceptLanguage, ",") {
+		tag, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		tag = strings.ToLower(strings.TrimSpace(tag))
+		if tag == "" || tag == "*" {
+			continue
+		}
+
+		quality := 1.0
+		if value, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			parsed, err := strconv.ParseFloat(value, 64)
+			if err != nil {
+				continue
+			}
+			quality = parsed
+		}
+		if quality > 0 {
+			languages = append(languages, language{tag: tag, quality: quality})
+		}
+	}
+	if len(languages) == 0 {
+		return ""
+	}
+
+	sort.SliceStable(languages, func(i, j int) bool {
+		return languages[i].quality > languages[j].quality
+	})
+	return languages[0].tag
+}
+
+// matchPlatform - проверка соответствия платформы клиента платформе правила.
+func (c client) matchPlatform(platform string) bool {
+	switch platform {
+	case "":
+		return true
+	case PlatformMobile:
+		return c.mobile
+	case PlatformDesktop:
+		return !c.mobile && c.platform != ""
+	default:
+		return c.platform == platform
+	}
+}
+
+// matchLanguage - проверка соответствия языка клиента языку правила.
+// Правило с основным тегом языка (ru) соответствует любому региональному варианту (ru-RU).
+func (c client) matchLanguage(language string) bool {
+	if language == "" {
+		return true
+	}
+	language = strings.ToLower(language)
+	return c.language == language || strings.HasPrefix(c.language, language+"-")
+}
+
+// MatchRule - поиск первого правила, которому соответствует клиент.
+// Правила проверяются в заданном порядке, в правиле должны совпасть все указанные условия.
+func MatchRule(rules []models.TargetRule, userAgent, acceptLanguage string) (int, bool) {
+	if len(rules) == 0 {
+		return 0, false
+	}
+
+	var c client
+	c.platform, c.mobile = detectPlatform(userAgent)
+	c.language = preferredLanguage(acceptLanguage)
+
+	for idx, rule := range rules {
+		if c.matchPlatform(rule.Platform) && c.matchLanguage(rule.Language) {
+			return idx, true
+		}
+	}
+	return 0, false
+}
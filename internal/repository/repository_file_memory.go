@@ -1,8 +1,11 @@
 package repository
 
 import (
+	"cmp"
 	"context"
 	"slices"
+	"sync"
+	"time"
 
 	"github.com/Di-nis/shortener-url/internal/constants"
 	"github.com/Di-nis/shortener-url/internal/models"
@@ -20,16 +23,50 @@ type ReadCloser interface {
 	Close() error
 }
 
+// Sizer - интерфейс для получения размера хранилища в байтах.
+type Sizer interface {
+	Size() (int64, error)
+}
+
+// Sequencer - интерфейс счетчика коротких URL, сохраняющего значения между перезапусками.
+type Sequencer interface {
+	Next() (uint64, error)
+}
+
+// BlockList - интерфейс списка заблокированных пользователей, сохраняющегося между перезапусками.
+type BlockList interface {
+	Users() []string
+	Save([]string) error
+}
+
 // Storage - структура для хранения файлов.
 type Storage struct {
 	Producer WriteCloser
 	Consumer ReadCloser
+	// Sequence - необязательный счетчик коротких URL, без него счетчик хранится в памяти
+	Sequence Sequencer
+	// Blocked - необязательный список заблокированных пользователей, без него список хранится в памяти
+	Blocked BlockList
 }
 
 // RepoFileMemory - структура базы данных.
 type RepoFileMemory struct {
 	URLs    []models.URLBase
 	Storage *Storage
+	// PerUserOriginals - режим, в котором каждый пользователь получает собственный короткий URL
+	// для оригинального URL, уже сокращенного другими пользователями.
+	PerUserOriginals bool
+
+	// mu - защита URLs, подписок, доставок и списка заблокированных пользователей от одновременного изменения
+	mu sync.RWMutex
+
+	webhooks     []models.Webhook
+	deliveries   []models.WebhookDelivery
+	blockedUsers map[string]struct{}
+	// lastID - последний номер счетчика коротких URL при отсутствии Storage.Sequence
+	lastID uint64
+	// lookup - индекс для поиска URL пользователей по оригинальному URL
+	lookup lookupIndex
 }
 
 // Close - закрытие файла.
@@ -44,10 +81,17 @@ func (repo *RepoFileMemory) Close() error {
 
 // NewRepoFileMemory - создание структуры RepoFileMemory.
 func NewRepoFileMemory(storage *Storage) *RepoFileMemory {
-	return &RepoFileMemory{
+	repo := &RepoFileMemory{
 		URLs:    make([]models.URLBase, 0),
 		Storage: storage,
 	}
+	if storage != nil && storage.Blocked != nil {
+		repo.blockedUsers = make(map[string]struct{})
+		for _, userID := range storage.Blocked.Users() {
+			repo.blockedUsers[userID] = struct{}{}
+		}
+	}
+	return repo
 }
 
 // Ping - проверка соединения с базой данных.
@@ -55,15 +99,63 @@ func (repo *RepoFileMemory) Ping(ctx context.Context) error {
 	return constants.ErrorMethodNotAllowed
 }
 
+// NextID - следующий номер счетчика коротких URL.
+func (repo *RepoFileMemory) NextID(ctx context.Context) (uint64, error) {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	if repo.Storage != nil && repo.Storage.Sequence != nil {
+		return repo.Storage.Sequence.Next()
+	}
+	repo.lastID++
+	return repo.lastID, nil
+}
+
+// sameOwner - проверка, относятся ли URL двух пользователей к одному владельцу оригинальных URL.
+// В режиме PerUserOriginals владелец - пользователь, иначе у всех URL общий владелец.
+func (repo *RepoFileMemory) sameOwner(userID1, userID2 string) bool {
+	return !repo.PerUserOriginals || userID1 == userID2
+}
+
+// conflict - проверка уникальности URL в пространстве имен домена среди URLs.
+// Возвращает ErrorURLAlreadyExist для повторного оригинального URL и ErrorShortCollision
+// для короткого URL, занятого другим оригинальным URL.
+func (repo *RepoFileMemory) conflict(url models.URLBase, urls []models.URLBase) error {
+	for _, urlDB := range urls {
+		if urlDB.Domain != url.Domain {
+			continue
+		}
+		// оригинальный URL удаленной записи можно сократить повторно
+		if urlDB.Original == url.Original && !urlDB.DeletedFlag && repo.sameOwner(urlDB.UUID, url.UUID) {
+			return constants.ErrorURLAlreadyExist
+		}
+		if urlDB.Short == url.Short {
+			return constants.ErrorShortCollision
+		}
+	}
+	return nil
+}
+
 // InsertBatch - сохранение нескольких URL в базу данных.
+// Пакет сохраняется только целиком: при конфликте любого URL возвращается BatchItemError с его позицией.
 func (repo *RepoFileMemory) InsertBatch(ctx context.Context, urls []models.URLBase) error {
-	for _, url := range urls {
-		for _, urlDB := range repo.URLs {
-			if urlDB.Original == url.Original {
-				return constants.ErrorURLAlreadyExist
-			}
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	for idx, url := range urls {
+		err := repo.conflict(url, repo.URLs)
+		if err == nil {
+			err = repo.conflict(url, urls[:idx])
 		}
+		if err != nil {
+			return &models.BatchItemError{Index: idx, Err: err}
+		}
+	}
 
+	for _, url := range urls {
+		if url.CreatedAt.IsZero() {
+			url.CreatedAt = time.Now().UTC()
+		}
 		repo.URLs = append(repo.URLs, url)
 
 		err := repo.Storage.Producer.Write(url)
@@ -76,12 +168,16 @@ func (repo *RepoFileMemory) InsertBatch(ctx context.Context, urls []models.URLBa
 
 // InsertOrdinary - сохранение ординарного URL в базу данных.
 func (repo *RepoFileMemory) InsertOrdinary(ctx context.Context, url models.URLBase) error {
-	for _, urlDB := range repo.URLs {
-		if urlDB.Original == url.Original {
-			return constants.ErrorURLAlreadyExist
-		}
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	if err := repo.conflict(url, repo.URLs); err != nil {
+		return err
 	}
 
+	if url.CreatedAt.IsZero() {
+		url.CreatedAt = time.Now().UTC()
+	}
 	repo.URLs = append(repo.URLs, url)
 
 	err := repo.Storage.Producer.Write(url)
@@ -92,68 +188,323 @@ func (repo *RepoFileMemory) InsertOrdinary(ctx context.Context, url models.URLBa
 
 }
 
-// SelectOriginal - получение оригинального URL из базы данных.
-func (repo *RepoFileMemory) SelectOriginal(ctx context.Context, shortURL string) (string, error) {
+// SelectOriginal - получение оригинального URL и правил перенаправления в пространстве имен домена.
+func (repo *RepoFileMemory) SelectOriginal(ctx context.Context, domain, shortURL string) (models.URLBase, error) {
+	repo.mu.RLock()
+	defer repo.mu.RUnlock()
+
 	for _, url := range repo.URLs {
+		if url.Domain != domain {
+			continue
+		}
 		if url.Short == shortURL && url.DeletedFlag {
-			return "", constants.ErrorURLAlreadyDeleted
+			return models.URLBase{}, constants.ErrorURLAlreadyDeleted
 		} else if url.Short == shortURL {
-			return url.Original, nil
+			url.Destinations = slices.Clone(url.Destinations)
+			return url, nil
 		}
 	}
-	return "", constants.ErrorURLNotExist
+	return models.URLBase{}, constants.ErrorURLNotExist
 }
 
-// SelectShort - получение оригинального URL из базы данных.
-func (repo *RepoFileMemory) SelectShort(ctx context.Context, originalURL string) (string, error) {
+// SelectShort - получение короткого URL по оригинальному в пространстве имен домена.
+// В режиме PerUserOriginals поиск ограничен URL пользователя.
+func (repo *RepoFileMemory) SelectShort(ctx context.Context, domain, userID, originalURL string) (string, error) {
+	repo.mu.RLock()
+	defer repo.mu.RUnlock()
+
 	for _, url := range repo.URLs {
-		if url.Original == originalURL {
+		if url.Domain == domain && url.Original == originalURL && !url.DeletedFlag && repo.sameOwner(url.UUID, userID) {
 			return url.Short, nil
 		}
 	}
 	return "", constants.ErrorURLNotExist
 }
 
+// matchFilter - проверка соответствия URL параметрам отбора.
+func matchFilter(url models.URLBase, filter models.URLFilter) bool {
+	if filter.Folder != "" && url.Folder != filter.Folder {
+		return false
+	}
+	if filter.Tag != "" && !slices.Contains(url.Tags, filter.Tag) {
+		return false
+	}
+	return true
+}
+
 // SelectAll - получение всех когда-либо сокращенных пользователем URL.
-func (repo *RepoFileMemory) SelectAll(ctx context.Context, userID string) ([]models.URLBase, error) {
+func (repo *RepoFileMemory) SelectAll(ctx context.Context, userID string, filter models.URLFilter) ([]models.URLBase, error) {
+	repo.mu.RLock()
+	defer repo.mu.RUnlock()
+
 	var urls []models.URLBase
 
 	for _, url := range repo.URLs {
-		if url.UUID == userID {
-			urls = append(urls, models.URLBase{Original: url.Original, Short: url.Short})
+		if url.UUID == userID && matchFilter(url, filter) {
+			urls = append(urls, userURL(url))
 		}
 	}
 	return urls, nil
 }
 
-// Delete - простановка флага удаления.
-func (repo *RepoFileMemory) Delete(ctx context.Context, urls []models.URLBase) error {
+// SelectPage - получение страницы URL пользователя после позиции cursor.After.
+// Позиция URL - его номер в списке, начиная с 1: URL не удаляются из списка, поэтому позиции не меняются.
+// Возвращает позицию последнего URL страницы, если за ней есть еще URL, иначе 0.
+func (repo *RepoFileMemory) SelectPage(ctx context.Context, userID string, cursor models.URLCursor) ([]models.URLBase, int64, error) {
+	repo.mu.RLock()
+	defer repo.mu.RUnlock()
+
+	var (
+		urls []models.URLBase
+		last int
+	)
+
+	for pos := int(min(cursor.After, int64(len(repo.URLs)))); pos < len(repo.URLs); pos++ {
+		url := repo.URLs[pos]
+		if url.UUID != userID || !matchFilter(url, cursor.Filter) {
+			continue
+		}
+		if len(urls) == cursor.Limit {
+			return urls, int64(last), nil
+		}
+		urls = append(urls, userURL(url))
+		last = pos + 1
+	}
+	return urls, 0, nil
+}
+
+// userURL - копия URL для выдачи пользователю.
+func userURL(url models.URLBase) models.URLBase {
+	return models.URLBase{
+		Original:    url.Original,
+		Short:       url.Short,
+		Domain:      url.Domain,
+		Tags:        url.Tags,
+		Folder:      url.Folder,
+		Title:       url.Title,
+		Description: url.Description,
+		PassQuery:   url.PassQuery,
+		PassPath:    url.PassPath,
+
+		Destinations: slices.Clone(url.Destinations),
+		Rules:        slices.Clone(url.Rules),
+		NotBefore:    url.NotBefore,
+		MaxClicks:    url.MaxClicks,
+		Health:       url.Health,
+		DisabledFlag: url.DisabledFlag,
+	}
+}
+
+// Update - изменение атрибутов URL пользователя.
+func (repo *RepoFileMemory) Update(ctx context.Context, update models.URLUpdate) error {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	for i, urlDB := range repo.URLs {
+		if urlDB.Short != update.Short || urlDB.UUID != update.UUID || urlDB.Domain != update.Domain || urlDB.DeletedFlag {
+			continue
+		}
+
+		if update.Folder != nil {
+			repo.URLs[i].Folder = *update.Folder
+		}
+		if update.Tags != nil {
+			repo.URLs[i].Tags = slices.Clone(*update.Tags)
+		}
+		if update.Title != nil {
+			repo.URLs[i].Title = *update.Title
+		}
+		if update.Description != nil {
+			repo.URLs[i].Description = *update.Description
+		}
+		if update.PassQuery != nil {
+			repo.URLs[i].PassQuery = update.PassQuery
+		}
+		if update.PassPath != nil {
+			repo.URLs[i].PassPath = update.PassPath
+		}
+		if update.Destinations != nil {
+			repo.URLs[i].Destinations = slices.Clone(*update.Destinations)
+		}
+		if update.Rules != nil {
+			repo.URLs[i].Rules = slices.Clone(*update.Rules)
+		}
+		if update.NotBefore != nil {
+			repo.URLs[i].NotBefore = update.NotBefore
+		}
+		if update.MaxClicks != nil {
+			maxClicks := *update.MaxClicks
+			repo.URLs[i].MaxClicks = &maxClicks
+		}
+
+		// файл-хранилище работает в режиме дозаписи, поэтому сохраняется
+		// актуальная версия записи, которая заменит предыдущую при загрузке
+		return repo.Storage.Producer.Write(repo.URLs[i])
+	}
+	return constants.ErrorURLNotExist
+}
+
+// RecordClick - учет перехода по URL и, если position неотрицательна, по адресу назначения.
+// Счетчики переходов хранятся в памяти и не записываются в файл-хранилище,
+// чтобы не дописывать запись на каждый переход.
+func (repo *RepoFileMemory) RecordClick(ctx context.Context, domain, shortURL string, position int) error {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	for i, url := range repo.URLs {
+		if url.Domain != domain || url.Short != shortURL {
+			continue
+		}
+		repo.URLs[i].Clicks++
+		if position >= 0 && position < len(url.Destinations) {
+			repo.URLs[i].Destinations[position].Clicks++
+		}
+		return nil
+	}
+	return constants.ErrorURLNotExist
+}
+
+// ConsumeClick - списание перехода по URL с ограниченным количеством переходов.
+// Оставшееся количество переходов записывается в файл-хранилище, чтобы
+// одноразовые ссылки не становились снова доступными после перезапуска.
+func (repo *RepoFileMemory) ConsumeClick(ctx context.Context, domain, shortURL string) error {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	for i, url := range repo.URLs {
+		if url.Domain != domain || url.Short != shortURL || url.DeletedFlag {
+			continue
+		}
+		if url.MaxClicks == nil {
+			return nil
+		}
+		if *url.MaxClicks <= 0 {
+			return constants.ErrorURLClicksExhausted
+		}
+
+		// значение копируется, так как указатель может разделяться с выданными ранее записями
+		maxClicks := *url.MaxClicks - 1
+		repo.URLs[i].MaxClicks = &maxClicks
+		return repo.Storage.Producer.Write(repo.URLs[i])
+	}
+	return constants.ErrorURLNotExist
+}
+
+// SelectForHealthCheck - получение всех неудаленных URL для проверки доступности.
+func (repo *RepoFileMemory) SelectForHealthCheck(ctx context.Context) ([]models.URLBase, error) {
+	repo.mu.RLock()
+	defer repo.mu.RUnlock()
+
+	urls := make([]models.URLBase, 0, len(repo.URLs))
+	for _, url := range repo.URLs {
+		if !url.DeletedFlag {
+			urls = append(urls, models.URLBase{Short: url.Short, Domain: url.Domain, Original: url.Original})
+		}
+	}
+	return urls, nil
+}
+
+// UpdateHealth - сохранение результата проверки доступности URL в пространстве имен домена.
+// Результат хранится в памяти и не записывается в файл-хранилище.
+func (repo *RepoFileMemory) UpdateHealth(ctx context.Context, domain, shortURL string, health models.URLHealth) error {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	for i, url := range repo.URLs {
+		if url.Domain == domain && url.Short == shortURL {
+			repo.URLs[i].Health = &health
+			return nil
+		}
+	}
+	return constants.ErrorURLNotExist
+}
+
+// Delete - простановка флага удаления URL пользователя в пространстве имен домена.
+// Оригинальный URL сохраняется: от него зависит порядок индекса поиска lookupIndex.
+// Возвращает записи, удаленные этим вызовом.
+func (repo *RepoFileMemory) Delete(ctx context.Context, urls []models.URLBase) ([]models.URLBase, error) {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	var deleted []models.URLBase
 	for _, url := range urls {
 		for i, urlDB := range repo.URLs {
-			if urlDB.Short == url.Short && urlDB.UUID == url.UUID && !urlDB.DeletedFlag {
-				repo.URLs[i].Original = ""
+			if urlDB.Domain == url.Domain && urlDB.Short == url.Short && urlDB.UUID == url.UUID && !urlDB.DeletedFlag {
 				repo.URLs[i].DeletedFlag = true
+				urlDB.DeletedFlag = true
+				deleted = append(deleted, urlDB)
 			}
 		}
 	}
-	return nil
+	return deleted, nil
 }
 
-// GetCountURLs - получение количества записей.
-func (repo *RepoFileMemory) GetCountURLs(ctx context.Context) (int, error) {
-	return len(repo.URLs), nil
-}
+// GetStats - расчет статистики по записям и пользователям.
+func (repo *RepoFileMemory) GetStats(ctx context.Context, filter models.StatsFilter) (*models.Stats, error) {
+	repo.mu.RLock()
+	defer repo.mu.RUnlock()
 
-// GetCountUsers - получение количества уникальных пользователей.
-func (repo *RepoFileMemory) GetCountUsers(ctx context.Context) (int, error) {
-	idx := 0
-	users := make([]string, len(repo.URLs))
+	stats := &models.Stats{
+		From:     filter.From,
+		To:       filter.To,
+		CountURL: len(repo.URLs),
+		PerDay:   make([]models.DailyStats, 0),
+		TopURLs:  make([]models.URLClicks, 0),
+		TopUsers: make([]models.UserStats, 0),
+	}
 
+	users := make(map[string]struct{})
+	perDay := make(map[string]int)
+	perUser := make(map[string]int)
 	for _, url := range repo.URLs {
-		if !slices.Contains(users, url.UUID) {
-			users[idx] = url.UUID
-			idx++
+		users[url.UUID] = struct{}{}
+		if url.DeletedFlag {
+			stats.CountDeleted++
+		} else {
+			stats.CountActive++
+		}
+
+		if url.CreatedAt.Before(filter.From) || !url.CreatedAt.Before(filter.To) {
+			continue
+		}
+		perDay[url.CreatedAt.UTC().Format(time.DateOnly)]++
+		perUser[url.UUID]++
+		if !url.DeletedFlag && url.Clicks > 0 {
+			stats.TopURLs = append(stats.TopURLs, models.URLClicks{Short: url.Short, Original: url.Original, Clicks: url.Clicks})
+		}
+	}
+	stats.CountUsers = len(users)
+
+	for day, count := range perDay {
+		stats.PerDay = append(stats.PerDay, models.DailyStats{Date: day, Count: count})
+	}
+	slices.SortFunc(stats.PerDay, func(a, b models.DailyStats) int {
+		return cmp.Compare(a.Date, b.Date)
+	})
+
+	// сортировка устойчивая, чтобы при равном количестве переходов сохранялся порядок создания
+	slices.SortStableFunc(stats.TopURLs, func(a, b models.URLClicks) int {
+		return cmp.Compare(b.Clicks, a.Clicks)
+	})
+	stats.TopURLs = stats.TopURLs[:min(len(stats.TopURLs), filter.Top)]
+
+	for userID, count := range perUser {
+		stats.TopUsers = append(stats.TopUsers, models.UserStats{UUID: userID, Count: count})
+	}
+	slices.SortFunc(stats.TopUsers, func(a, b models.UserStats) int {
+		return cmp.Or(cmp.Compare(b.Count, a.Count), cmp.Compare(a.UUID, b.UUID))
+	})
+	stats.TopUsers = stats.TopUsers[:min(len(stats.TopUsers), filter.Top)]
+
+	if repo.Storage == nil {
+		return stats, nil
+	}
+	if sizer, ok := repo.Storage.Producer.(Sizer); ok {
+		size, err := sizer.Size()
+		if err != nil {
+			return nil, err
 		}
+		stats.StorageBytes = size
 	}
-	return idx, nil
+	return stats, nil
 }
@@ -0,0 +1,128 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/Di-nis/shortener-url/internal/constants"
+	"github.com/Di-nis/shortener-url/internal/models"
+)
+
+// Список заблокированных пользователей сохраняется в Storage.Blocked, если он задан,
+// иначе хранится только в памяти приложения.
+
+// SearchURLs - поиск URL всех пользователей по идентификатору пользователя,
+// подстроке оригинального URL или короткому URL.
+func (repo *RepoFileMemory) SearchURLs(ctx context.Context, filter models.AdminFilter) ([]models.URLBase, error) {
+	repo.mu.RLock()
+	defer repo.mu.RUnlock()
+
+	query := strings.ToLower(filter.Query)
+
+	var (
+		urls    []models.URLBase
+		skipped int
+	)
+	for _, url := range repo.URLs {
+		if filter.UserID != "" && url.UUID != filter.UserID {
+			continue
+		}
+		if query != "" && url.Short != filter.Query && !strings.Contains(strings.ToLower(url.Original), query) {
+			continue
+		}
+		if skipped < filter.Offset {
+			skipped++
+			continue
+		}
+		if len(urls) >= filter.Limit {
+			break
+		}
+		urls = append(urls, models.URLBase{
+			UUID:         url.UUID,
+			Short:        url.Short,
+			Domain:       url.Domain,
+			Original:     url.Original,
+			DeletedFlag:  url.DeletedFlag,
+			DisabledFlag: url.DisabledFlag,
+		})
+	}
+	return urls, nil
+}
+
+// SetDisabled - отключение или включение URL в пространстве имен домена независимо от владельца.
+func (repo *RepoFileMemory) SetDisabled(ctx context.Context, domain, short string, disabled bool) error {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	for i, url := range repo.URLs {
+		if url.Domain == domain && url.Short == short {
+			repo.URLs[i].DisabledFlag = disabled
+			return repo.Storage.Producer.Write(repo.URLs[i])
+		}
+	}
+	return constants.ErrorURLNotExist
+}
+
+// ForceDelete - удаление URL в пространстве имен домена независимо от владельца.
+// Оригинальный URL сохраняется: записи отбираются по флагу удаления.
+func (repo *RepoFileMemory) ForceDelete(ctx context.Context, domain, short string) error {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	for i, url := range repo.URLs {
+		if url.Domain == domain && url.Short == short {
+			repo.URLs[i].DeletedFlag = true
+			return repo.Storage.Producer.Write(repo.URLs[i])
+		}
+	}
+	return constants.ErrorURLNotExist
+}
+
+// BlockUser - запрет пользователю создавать URL.
+func (repo *RepoFileMemory) BlockUser(ctx context.Context, userID string) error {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	if repo.blockedUsers == nil {
+		repo.blockedUsers = make(map[string]struct{})
+	}
+	repo.blockedUsers[userID] = struct{}{}
+	return repo.saveBlockedUsers()
+}
+
+// UnblockUser - снятие запрета на создание URL.
+func (repo *RepoFileMemory) UnblockUser(ctx context.Context, userID string) error {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	delete(repo.blockedUsers, userID)
+	return repo.saveBlockedUsers()
+}
+
+// saveBlockedUsers - сохранение списка заблокированных пользователей, если он хранится вне памяти.
+func (repo *RepoFileMemory) saveBlockedUsers() error {
+	if repo.Storage == nil || repo.Storage.Blocked == nil {
+		return nil
+	}
+
+	users := make([]string, 0, len(repo.blockedUsers))
+	for userID := range repo.blockedUsers {
+		users = append(users, userID)
+	}
+	slices.Sort(users)
+	if err := repo.Storage.Blocked.Save(users); err != nil {
+		return fmt.Errorf("path: internal/repository/repository_file_memory_admin.go, func saveBlockedUsers(), failed to save blocked users: %w", err)
+	}
+	return nil
+}
+
+// IsUserBlocked - проверка запрета пользователю создавать URL.
+func (repo *RepoFileMemory) IsUserBlocked(ctx context.Context, userID string) (bool, error) {
+	repo.mu.RLock()
+	defer repo.mu.RUnlock()
+
+	_, blocked := repo.blockedUsers[userID]
+	return blocked, nil
+}
@@ -0,0 +1,200 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"github.com/Di-nis/shortener-url/internal/constants"
+	"github.com/Di-nis/shortener-url/internal/mocks"
+	"github.com/Di-nis/shortener-url/internal/models"
+	"github.com/Di-nis/shortener-url/internal/storage"
+	"github.com/golang/mock/gomock"
+)
+
+func TestRepoFileMemory_SearchURLs(t *testing.T) {
+	tests := []struct {
+		name   string
+		filter models.AdminFilter
+		want   []string
+	}{
+		{
+			name:   "все URL",
+			filter: models.AdminFilter{Limit: 10},
+			want:   []string{urlAlias1, urlAlias2, urlAlias4},
+		},
+		{
+			name:   "подстрока оригинального URL без учета регистра",
+			filter: models.AdminFilter{Query: "DYNAMO", Limit: 10},
+			want:   []string{urlAlias2},
+		},
+		{
+			name:   "короткий URL",
+			filter: models.AdminFilter{Query: urlAlias1, Limit: 10},
+			want:   []string{urlAlias1},
+		},
+		{
+			name:   "другой пользователь",
+			filter: models.AdminFilter{UserID: "other", Limit: 10},
+			want:   nil,
+		},
+		{
+			name:   "смещение и ограничение",
+			filter: models.AdminFilter{UserID: UUID, Limit: 1, Offset: 1},
+			want:   []string{urlAlias2},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := setupRepoFileMemory(&Storage{})
+
+			got, err := repo.SearchURLs(context.Background(), tt.filter)
+			if err != nil {
+				t.Fatalf("TestRepoFileMemory_SearchURLs() = %v", err)
+			}
+			var gotShorts []string
+			for _, url := range got {
+				gotShorts = append(gotShorts, url.Short)
+			}
+			if len(gotShorts) != len(tt.want) {
+				t.Fatalf("TestRepoFileMemory_SearchURLs() = %v, want %v", gotShorts, tt.want)
+			}
+			for i := range tt.want {
+				if gotShorts[i] != tt.want[i] {
+					t.Errorf("TestRepoFileMemory_SearchURLs() = %v, want %v", gotShorts, tt.want)
+				}
+			}
+		})
+	}
+}
+
+func TestRepoFileMemory_Moderation(t *testing.T) {
+	ctx := context.Background()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockProducer := mocks.NewMockWriteCloser(ctrl)
+	gomock.InOrder(
+		mockProducer.EXPECT().Write(gomock.Any()).Return(nil),
+		// удаление сохраняется в файле-хранилище и восстанавливается после перезапуска
+		mockProducer.EXPECT().Write(gomock.Any()).DoAndReturn(func(url models.URLBase) error {
+			if url.Short != urlAlias2 || !url.DeletedFlag || url.Original != url2 {
+				t.Errorf("TestRepoFileMemory_ForceDelete() saved %v", url)
+			}
+			return nil
+		}),
+	)
+
+	repo := setupRepoFileMemory(&Storage{Producer: mockProducer})
+	// тот же короткий URL в другом домене
+	branded := models.URLBase{UUID: UUID, Original: url3, Short: urlAlias1, Domain: "go.brand-a.com"}
+	repo.URLs = append(repo.URLs, branded)
+
+	if err := repo.SetDisabled(ctx, "", urlAlias1, true); err != nil {
+		t.Fatalf("TestRepoFileMemory_SetDisabled() = %v", err)
+	}
+	url, _ := repo.SelectOriginal(ctx, "", urlAlias1)
+	if !url.DisabledFlag {
+		t.Errorf("TestRepoFileMemory_SetDisabled() URL is not disabled")
+	}
+	if url, _ = repo.SelectOriginal(ctx, branded.Domain, urlAlias1); url.DisabledFlag {
+		t.Errorf("TestRepoFileMemory_SetDisabled() URL in other domain is disabled")
+	}
+	if err := repo.SetDisabled(ctx, "", urlAlias3, true); !errors.Is(err, constants.ErrorURLNotExist) {
+		t.Errorf("TestRepoFileMemory_SetDisabled() = %v, want %v", err, constants.ErrorURLNotExist)
+	}
+
+	if err := repo.ForceDelete(ctx, branded.Domain, urlAlias2); !errors.Is(err, constants.ErrorURLNotExist) {
+		t.Errorf("TestRepoFileMemory_ForceDelete() = %v, want %v", err, constants.ErrorURLNotExist)
+	}
+	if err := repo.ForceDelete(ctx, "", urlAlias2); err != nil {
+		t.Fatalf("TestRepoFileMemory_ForceDelete() = %v", err)
+	}
+	if _, err := repo.SelectOriginal(ctx, "", urlAlias2); !errors.Is(err, constants.ErrorURLAlreadyDeleted) {
+		t.Errorf("TestRepoFileMemory_ForceDelete() = %v, want %v", err, constants.ErrorURLAlreadyDeleted)
+	}
+	// оригинальный URL удаленной записи можно сократить повторно
+	if _, err := repo.SelectShort(ctx, "", UUID, url2); !errors.Is(err, constants.ErrorURLNotExist) {
+		t.Errorf("TestRepoFileMemory_ForceDelete() SelectShort() = %v, want %v", err, constants.ErrorURLNotExist)
+	}
+
+	if blocked, _ := repo.IsUserBlocked(ctx, UUID); blocked {
+		t.Errorf("TestRepoFileMemory_IsUserBlocked() user is blocked before BlockUser()")
+	}
+	_ = repo.BlockUser(ctx, UUID)
+	if blocked, _ := repo.IsUserBlocked(ctx, UUID); !blocked {
+		t.Errorf("TestRepoFileMemory_BlockUser() user is not blocked")
+	}
+	_ = repo.UnblockUser(ctx, UUID)
+	if blocked, _ := repo.IsUserBlocked(ctx, UUID); blocked {
+		t.Errorf("TestRepoFileMemory_UnblockUser() user is still blocked")
+	}
+}
+
+// openRepoFile - открытие репозитория с файлом-хранилищем path, как при запуске приложения.
+func openRepoFile(t *testing.T, path string) *RepoFileMemory {
+	t.Helper()
+
+	consumer, err := storage.NewConsumer(path)
+	if err != nil {
+		t.Fatalf("NewConsumer() error = %v", err)
+	}
+	producer, err := storage.NewProducer(path)
+	if err != nil {
+		t.Fatalf("NewProducer() error = %v", err)
+	}
+	blocked, err := storage.NewBlockedUsersFile(path + ".blocked")
+	if err != nil {
+		t.Fatalf("NewBlockedUsersFile() error = %v", err)
+	}
+
+	repo := NewRepoFileMemory(&Storage{Consumer: consumer, Producer: producer, Blocked: blocked})
+	if repo.URLs, err = consumer.Load(); err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	t.Cleanup(func() { _ = repo.Close() })
+	return repo
+}
+
+func TestRepoFileMemory_ModerationAfterRestart(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "urls.json")
+
+	repo := openRepoFile(t, path)
+	for _, url := range []models.URLBase{
+		{UUID: UUID, Original: url1, Short: urlAlias1},
+		{UUID: UUID, Original: url2, Short: urlAlias2},
+	} {
+		if err := repo.InsertOrdinary(ctx, url); err != nil {
+			t.Fatalf("InsertOrdinary() error = %v", err)
+		}
+	}
+	if err := repo.ForceDelete(ctx, "", urlAlias2); err != nil {
+		t.Fatalf("ForceDelete() error = %v", err)
+	}
+	if err := repo.BlockUser(ctx, UUID); err != nil {
+		t.Fatalf("BlockUser() error = %v", err)
+	}
+
+	// перезапуск: удаление и блокировка восстанавливаются из файлов
+	repo = openRepoFile(t, path)
+	if _, err := repo.SelectOriginal(ctx, "", urlAlias2); !errors.Is(err, constants.ErrorURLAlreadyDeleted) {
+		t.Errorf("SelectOriginal() error = %v, want %v", err, constants.ErrorURLAlreadyDeleted)
+	}
+	if url, err := repo.SelectOriginal(ctx, "", urlAlias1); err != nil || url.Original != url1 {
+		t.Errorf("SelectOriginal() = %v, %v, want %s", url.Original, err, url1)
+	}
+	if blocked, _ := repo.IsUserBlocked(ctx, UUID); !blocked {
+		t.Errorf("IsUserBlocked() user is not blocked after restart")
+	}
+	if err := repo.UnblockUser(ctx, UUID); err != nil {
+		t.Fatalf("UnblockUser() error = %v", err)
+	}
+
+	repo = openRepoFile(t, path)
+	if blocked, _ := repo.IsUserBlocked(ctx, UUID); blocked {
+		t.Errorf("IsUserBlocked() user is blocked after UnblockUser() and restart")
+	}
+}
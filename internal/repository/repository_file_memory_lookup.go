@@ -0,0 +1,135 @@
+package repository
+
+import (
+	"context"
+	"net/url"
+	"slices"
+	"strings"
+
+	"github.com/Di-nis/shortener-url/internal/models"
+)
+
+// lookupIndex - индекс URL пользователей по оригинальному URL и его хосту.
+// Хранит позиции в RepoFileMemory.URLs: URL не удаляются из списка, а владелец
+// и оригинальный URL не изменяются, поэтому индекс только дополняется.
+// Удаление только проставляет флаг: очистка оригинального URL нарушила бы
+// порядок sorted, на котором основан двоичный поиск.
+type lookupIndex struct {
+	// indexed - число проиндексированных URL с начала списка
+	indexed int
+	users   map[string]*userLookup
+}
+
+// userLookup - индекс URL одного пользователя.
+type userLookup struct {
+	// sorted - позиции URL, упорядоченные по оригинальному URL, для поиска точного значения и префикса
+	sorted []int
+	// hosts - позиции URL по хосту оригинального URL
+	hosts map[string][]int
+}
+
+// originalHost - хост оригинального URL в нижнем регистре.
+func originalHost(original string) string {
+	u, err := url.Parse(original)
+	if err != nil {
+		return ""
+	}
+	return strings.ToLower(u.Hostname())
+}
+
+// update - индексирование URL, добавленных после предыдущего обновления.
+func (index *lookupIndex) update(urls []models.URLBase) {
+	if index.users == nil {
+		index.users = make(map[string]*userLookup)
+	}
+
+	for pos := index.indexed; pos < len(urls); pos++ {
+		user, ok := index.users[urls[pos].UUID]
+		if !ok {
+			user = &userLookup{hosts: make(map[string][]int)}
+			index.users[urls[pos].UUID] = user
+		}
+
+		idx, _ := slices.BinarySearchFunc(user.sorted, urls[pos].Original, func(p int, original string) int {
+			return strings.Compare(urls[p].Original, original)
+		})
+		user.sorted = slices.Insert(user.sorted, idx, pos)
+
+		host := originalHost(urls[pos].Original)
+		user.hosts[host] = append(user.hosts[host], pos)
+	}
+	index.indexed = len(urls)
+}
+
+// search - позиции URL пользователя, подходящих под условие поиска, в порядке добавления.
+func (index *lookupIndex) search(urls []models.URLBase, userID string, lookup models.URLLookup) []int {
+	user, ok := index.users[userID]
+	if !ok {
+		return nil
+	}
+
+	var positions []int
+	switch lookup.Mode {
+	case models.LookupExact, models.LookupPrefix:
+		idx, _ := slices.BinarySearchFunc(user.sorted, lookup.Query, func(p int, query string) int {
+			return strings.Compare(urls[p].Original, query)
+		})
+		for _, pos := range user.sorted[idx:] {
+			match := urls[pos].Original == lookup.Query
+			if lookup.Mode == models.LookupPrefix {
+				match = strings.HasPrefix(urls[pos].Original, lookup.Query)
+			}
+			if !match {
+				break
+			}
+			positions = append(positions, pos)
+		}
+	case models.LookupHost:
+		for host, hostPositions := range user.hosts {
+			if host == lookup.Query || strings.HasSuffix(host, "."+lookup.Query) {
+				positions = append(positions, hostPositions...)
+			}
+		}
+	case models.LookupContains:
+		query := strings.ToLower(lookup.Query)
+		for _, pos := range user.sorted {
+			if strings.Contains(strings.ToLower(urls[pos].Original), query) {
+				positions = append(positions, pos)
+			}
+		}
+	}
+
+	slices.Sort(positions)
+	return positions
+}
+
+// LookupURLs - поиск URL пользователя по оригинальному URL, хосту, префиксу или подстроке.
+// Удаленные URL не возвращаются.
+func (repo *RepoFileMemory) LookupURLs(ctx context.Context, userID string, lookup models.URLLookup) ([]models.URLBase, error) {
+	// индекс дополняется при поиске, поэтому требуется блокировка на запись
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	repo.lookup.update(repo.URLs)
+
+	var urls []models.URLBase
+	for _, pos := range repo.lookup.search(repo.URLs, userID, lookup) {
+		urlDB := repo.URLs[pos]
+		if urlDB.DeletedFlag {
+			continue
+		}
+		if len(urls) >= lookup.Limit {
+			break
+		}
+		urls = append(urls, models.URLBase{
+			Original:    urlDB.Original,
+			Short:       urlDB.Short,
+			Domain:      urlDB.Domain,
+			Tags:        urlDB.Tags,
+			Folder:      urlDB.Folder,
+			Title:       urlDB.Title,
+			Description: urlDB.Description,
+		})
+	}
+	return urls, nil
+}
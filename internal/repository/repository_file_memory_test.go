@@ -2,8 +2,13 @@ package repository
 
 import (
 	"context"
+	"errors"
 	"reflect"
+	"slices"
+	"sync"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/Di-nis/shortener-url/internal/constants"
 	"github.com/Di-nis/shortener-url/internal/mocks"
@@ -15,6 +20,10 @@ import (
 func setupRepoFileMemory(storage *Storage) *RepoFileMemory {
 	repo := NewRepoFileMemory(storage)
 	repo.URLs = append(repo.URLs, testURLsFull...)
+	// счетчики переходов изменяются в репозитории, поэтому тестовые данные копируются
+	for i := range repo.URLs {
+		repo.URLs[i].Destinations = slices.Clone(repo.URLs[i].Destinations)
+	}
 	return repo
 }
 
@@ -49,6 +58,53 @@ func TestRepoFileMemory_Ping(t *testing.T) {
 	}
 }
 
+// sequenceStub - счетчик хранилища в памяти.
+type sequenceStub struct {
+	last uint64
+}
+
+// Next - следующее значение счетчика.
+func (s *sequenceStub) Next() (uint64, error) {
+	s.last += 10
+	return s.last, nil
+}
+
+func TestRepoFileMemory_NextID(t *testing.T) {
+	tests := []struct {
+		name    string
+		storage *Storage
+		want    []uint64
+	}{
+		{
+			name:    "счетчик в памяти",
+			storage: &Storage{},
+			want:    []uint64{1, 2, 3},
+		},
+		{
+			name:    "счетчик хранилища",
+			storage: &Storage{Sequence: &sequenceStub{last: 100}},
+			want:    []uint64{110, 120, 130},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := NewRepoFileMemory(tt.storage)
+
+			got := make([]uint64, 0, len(tt.want))
+			for range tt.want {
+				id, err := repo.NextID(context.Background())
+				if err != nil {
+					t.Fatalf("TestRepoFileMemory_NextID() error = %v", err)
+				}
+				got = append(got, id)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("TestRepoFileMemory_NextID() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
 func TestRepoFileMemory_InsertBatch(t *testing.T) {
 	tests := []struct {
 		name string
@@ -70,6 +126,24 @@ func TestRepoFileMemory_InsertBatch(t *testing.T) {
 			},
 			want: nil,
 		},
+		{
+			name: "коллизия с сохраненным коротким URL, пакет не сохраняется",
+			urls: []models.URLBase{
+				testURLFull3,
+				{UUID: testURLFull3.UUID, Original: "https://collision.example/", Short: testURLFull1.Short},
+			},
+			mock: func(producer *mocks.MockWriteCloser) {},
+			want: constants.ErrorShortCollision,
+		},
+		{
+			name: "коллизия коротких URL внутри пакета",
+			urls: []models.URLBase{
+				testURLFull3,
+				{UUID: testURLFull3.UUID, Original: "https://collision.example/", Short: testURLFull3.Short},
+			},
+			mock: func(producer *mocks.MockWriteCloser) {},
+			want: constants.ErrorShortCollision,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -87,9 +161,16 @@ func TestRepoFileMemory_InsertBatch(t *testing.T) {
 			}
 
 			repo := setupRepoFileMemory(storage)
-			if got := repo.InsertBatch(context.Background(), tt.urls); got != tt.want {
+			count := len(repo.URLs)
+			got := repo.InsertBatch(context.Background(), tt.urls)
+			if !errors.Is(got, tt.want) {
 				t.Errorf("TestRepoFileMemory_InsertBatch() = %v, want %v", got, tt.want)
 			}
+
+			var itemErr *models.BatchItemError
+			if errors.As(got, &itemErr) && len(repo.URLs) != count {
+				t.Errorf("TestRepoFileMemory_InsertBatch() saved %d URLs of conflicting batch", len(repo.URLs)-count)
+			}
 		})
 	}
 }
@@ -107,6 +188,12 @@ func TestRepoFileMemory_InsertOrdinary(t *testing.T) {
 			want: constants.ErrorURLAlreadyExist,
 			mock: func(producer *mocks.MockWriteCloser) {},
 		},
+		{
+			name: "коллизия короткого URL",
+			url:  models.URLBase{UUID: testURLFull3.UUID, Original: "https://collision.example/", Short: testURLFull1.Short},
+			want: constants.ErrorShortCollision,
+			mock: func(producer *mocks.MockWriteCloser) {},
+		},
 		{
 			name: "тест 2",
 			url:  testURLFull3,
@@ -139,6 +226,47 @@ func TestRepoFileMemory_InsertOrdinary(t *testing.T) {
 	}
 }
 
+func TestRepoFileMemory_Domains(t *testing.T) {
+	ctx := context.Background()
+	repo := setupRepoFileMemory(&Storage{Producer: producerSizer{}})
+
+	// тот же оригинальный и короткий URL в пространстве имен другого домена
+	branded := testURLFull1
+	branded.Domain = "go.brand-a.com"
+	branded.Rules = nil
+	clicks := 1
+	branded.MaxClicks = &clicks
+	if err := repo.InsertOrdinary(ctx, branded); err != nil {
+		t.Fatalf("TestRepoFileMemory_Domains() insert = %v", err)
+	}
+	if err := repo.InsertOrdinary(ctx, branded); !errors.Is(err, constants.ErrorURLAlreadyExist) {
+		t.Errorf("TestRepoFileMemory_Domains() duplicate insert = %v, want %v", err, constants.ErrorURLAlreadyExist)
+	}
+
+	got, err := repo.SelectOriginal(ctx, "go.brand-a.com", urlAlias1)
+	if err != nil || got.Domain != "go.brand-a.com" || got.Rules != nil {
+		t.Errorf("TestRepoFileMemory_Domains() = %+v, %v", got, err)
+	}
+	got, err = repo.SelectOriginal(ctx, "", urlAlias1)
+	if err != nil || got.Domain != "" || got.Rules == nil {
+		t.Errorf("TestRepoFileMemory_Domains() = %+v, %v", got, err)
+	}
+	if _, err = repo.SelectOriginal(ctx, "brand-b.io", urlAlias1); !errors.Is(err, constants.ErrorURLNotExist) {
+		t.Errorf("TestRepoFileMemory_Domains() unknown domain = %v, want %v", err, constants.ErrorURLNotExist)
+	}
+
+	// списание перехода затрагивает только ссылку своего домена
+	if err = repo.ConsumeClick(ctx, "go.brand-a.com", urlAlias1); err != nil {
+		t.Errorf("TestRepoFileMemory_Domains() consume = %v", err)
+	}
+	if err = repo.ConsumeClick(ctx, "go.brand-a.com", urlAlias1); !errors.Is(err, constants.ErrorURLClicksExhausted) {
+		t.Errorf("TestRepoFileMemory_Domains() consume = %v, want %v", err, constants.ErrorURLClicksExhausted)
+	}
+	if err = repo.ConsumeClick(ctx, "", urlAlias1); err != nil {
+		t.Errorf("TestRepoFileMemory_Domains() consume default domain = %v", err)
+	}
+}
+
 func TestRepoFileMemory_SelectOriginal(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -179,9 +307,9 @@ func TestRepoFileMemory_SelectOriginal(t *testing.T) {
 			}
 
 			repo := setupRepoFileMemory(storage)
-			got, gotErr := repo.SelectOriginal(context.Background(), tt.shortURL)
-			if got != tt.want || gotErr != tt.wantErr {
-				t.Errorf("TestRepoFileMemory_SelectOriginal() = %v, want %v", got, tt.wantErr)
+			got, gotErr := repo.SelectOriginal(context.Background(), "", tt.shortURL)
+			if got.Original != tt.want || gotErr != tt.wantErr {
+				t.Errorf("TestRepoFileMemory_SelectOriginal() = %v, want %v", got.Original, tt.wantErr)
 			}
 		})
 	}
@@ -221,7 +349,7 @@ func TestRepoFileMemory_SelectShort(t *testing.T) {
 			}
 
 			repo := setupRepoFileMemory(storage)
-			got, gotErr := repo.SelectShort(context.Background(), tt.originalURL)
+			got, gotErr := repo.SelectShort(context.Background(), "", UUID, tt.originalURL)
 			if got != tt.want || gotErr != tt.wantErr {
 				t.Errorf("TestRepoFileMemory_SelectShort() = %v, want %v", got, tt.wantErr)
 			}
@@ -229,10 +357,64 @@ func TestRepoFileMemory_SelectShort(t *testing.T) {
 	}
 }
 
+func TestRepoFileMemory_PerUserOriginals(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockProducer := mocks.NewMockWriteCloser(ctrl)
+	mockProducer.EXPECT().Write(gomock.Any()).Return(nil).Times(1)
+
+	repo := setupRepoFileMemory(&Storage{Producer: mockProducer})
+	otherUser := "01KA3YRQCWTNAJEGR5Z30PH6VX"
+	urlOther := models.URLBase{UUID: otherUser, Original: url1, Short: "other001"}
+
+	// общий режим: оригинальный URL уже сокращен другим пользователем
+	err := repo.InsertOrdinary(context.Background(), urlOther)
+	if !errors.Is(err, constants.ErrorURLAlreadyExist) {
+		t.Fatalf("InsertOrdinary() in shared mode = %v, want %v", err, constants.ErrorURLAlreadyExist)
+	}
+
+	// режим PerUserOriginals: пользователь получает собственный короткий URL
+	repo.PerUserOriginals = true
+	if err = repo.InsertOrdinary(context.Background(), urlOther); err != nil {
+		t.Fatalf("InsertOrdinary() in per-user mode = %v", err)
+	}
+	err = repo.InsertOrdinary(context.Background(), models.URLBase{UUID: otherUser, Original: url1, Short: "other002"})
+	if !errors.Is(err, constants.ErrorURLAlreadyExist) {
+		t.Fatalf("repeated InsertOrdinary() in per-user mode = %v, want %v", err, constants.ErrorURLAlreadyExist)
+	}
+
+	tests := []struct {
+		name   string
+		userID string
+		want   string
+	}{
+		{
+			name:   "URL первого пользователя",
+			userID: UUID,
+			want:   urlAlias1,
+		},
+		{
+			name:   "URL второго пользователя",
+			userID: otherUser,
+			want:   "other001",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := repo.SelectShort(context.Background(), "", tt.userID, url1)
+			if err != nil || got != tt.want {
+				t.Errorf("SelectShort() = %v, %v, want %v", got, err, tt.want)
+			}
+		})
+	}
+}
+
 func TestRepoFileMemory_SelectAll(t *testing.T) {
 	tests := []struct {
 		name    string
 		userID  string
+		filter  models.URLFilter
 		want    []models.URLBase
 		wantErr error
 	}{
@@ -242,6 +424,27 @@ func TestRepoFileMemory_SelectAll(t *testing.T) {
 			want:    testURLsShort,
 			wantErr: nil,
 		},
+		{
+			name:    "тест 2, отбор по тегу",
+			userID:  UUID,
+			filter:  models.URLFilter{Tag: "football"},
+			want:    []models.URLBase{testURLShort2},
+			wantErr: nil,
+		},
+		{
+			name:    "тест 3, отбор по папке и тегу",
+			userID:  UUID,
+			filter:  models.URLFilter{Tag: "hockey", Folder: "sport"},
+			want:    []models.URLBase{testURLShort1, testURLShort2},
+			wantErr: nil,
+		},
+		{
+			name:    "тест 4, отбор по отсутствующему тегу",
+			userID:  UUID,
+			filter:  models.URLFilter{Tag: "tennis"},
+			want:    nil,
+			wantErr: nil,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -257,7 +460,7 @@ func TestRepoFileMemory_SelectAll(t *testing.T) {
 			}
 
 			repo := setupRepoFileMemory(storage)
-			got, gotErr := repo.SelectAll(context.Background(), tt.userID)
+			got, gotErr := repo.SelectAll(context.Background(), tt.userID, tt.filter)
 			if !reflect.DeepEqual(got, tt.want) {
 				t.Errorf("TestRepoFileMemory_SelectAll() = %v, want %v", got, tt.want)
 			}
@@ -269,17 +472,138 @@ func TestRepoFileMemory_SelectAll(t *testing.T) {
 	}
 }
 
-func TestRepoFileMemory_Delete(t *testing.T) {
+func TestRepoFileMemory_SelectPage(t *testing.T) {
 	tests := []struct {
-		name string
-		urls []models.URLBase
-		want error
+		name     string
+		userID   string
+		cursor   models.URLCursor
+		want     []models.URLBase
+		wantNext int64
+	}{
+		{
+			name:     "первая страница",
+			userID:   UUID,
+			cursor:   models.URLCursor{Limit: 2},
+			want:     []models.URLBase{testURLShort1, testURLShort2},
+			wantNext: 2,
+		},
+		{
+			name:     "последняя страница",
+			userID:   UUID,
+			cursor:   models.URLCursor{After: 2, Limit: 2},
+			want:     []models.URLBase{testURLShort4},
+			wantNext: 0,
+		},
+		{
+			name:     "страница ровно до конца списка",
+			userID:   UUID,
+			cursor:   models.URLCursor{After: 1, Limit: 2},
+			want:     []models.URLBase{testURLShort2, testURLShort4},
+			wantNext: 0,
+		},
+		{
+			name:     "отбор по папке",
+			userID:   UUID,
+			cursor:   models.URLCursor{Filter: models.URLFilter{Folder: "sport"}, After: 1, Limit: 1},
+			want:     []models.URLBase{testURLShort2},
+			wantNext: 0,
+		},
+		{
+			name:     "позиция за концом списка",
+			userID:   UUID,
+			cursor:   models.URLCursor{After: 100, Limit: 2},
+			want:     nil,
+			wantNext: 0,
+		},
+		{
+			name:     "URL другого пользователя",
+			userID:   "01KA3YRQCWTNAJEGR5Z30PH6VX",
+			cursor:   models.URLCursor{Limit: 2},
+			want:     nil,
+			wantNext: 0,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := setupRepoFileMemory(&Storage{})
+
+			got, gotNext, gotErr := repo.SelectPage(context.Background(), tt.userID, tt.cursor)
+			if gotErr != nil {
+				t.Fatalf("TestRepoFileMemory_SelectPage() error = %v", gotErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("TestRepoFileMemory_SelectPage() = %v, want %v", got, tt.want)
+			}
+			if gotNext != tt.wantNext {
+				t.Errorf("TestRepoFileMemory_SelectPage() next = %v, want %v", gotNext, tt.wantNext)
+			}
+		})
+	}
+}
+
+func TestRepoFileMemory_Update(t *testing.T) {
+	folder := "hockey"
+	tags := []string{"khl"}
+
+	updatedURL := testURLFull1
+	updatedURL.Folder = folder
+	updatedURL.Tags = tags
+
+	rules := []models.TargetRule{{Platform: "desktop", URL: url1 + "tv"}}
+	updatedRulesURL := testURLFull1
+	updatedRulesURL.Rules = rules
+
+	tests := []struct {
+		name   string
+		update models.URLUpdate
+		mock   func(producer *mocks.MockWriteCloser)
+		want   error
 	}{
 		{
 			name: "тест 1",
-			urls: []models.URLBase{testURLFull1},
+			update: models.URLUpdate{
+				UUID:   UUID,
+				Short:  urlAlias1,
+				Tags:   &tags,
+				Folder: &folder,
+			},
+			mock: func(producer *mocks.MockWriteCloser) {
+				producer.EXPECT().Write(updatedURL).Return(nil)
+			},
 			want: nil,
 		},
+		{
+			name: "тест 1, замена правил перенаправления",
+			update: models.URLUpdate{
+				UUID:  UUID,
+				Short: urlAlias1,
+				Rules: &rules,
+			},
+			mock: func(producer *mocks.MockWriteCloser) {
+				producer.EXPECT().Write(updatedRulesURL).Return(nil)
+			},
+			want: nil,
+		},
+		{
+			name: "тест 2, URL удален",
+			update: models.URLUpdate{
+				UUID:  UUID,
+				Short: urlAlias4,
+				Tags:  &tags,
+			},
+			mock: func(producer *mocks.MockWriteCloser) {},
+			want: constants.ErrorURLNotExist,
+		},
+		{
+			name: "тест 3, URL принадлежит другому пользователю",
+			update: models.URLUpdate{
+				UUID:  "01KA3YRQCWTNAJEGR5Z30PH6VA",
+				Short: urlAlias1,
+				Tags:  &tags,
+			},
+			mock: func(producer *mocks.MockWriteCloser) {},
+			want: constants.ErrorURLNotExist,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -289,15 +613,473 @@ func TestRepoFileMemory_Delete(t *testing.T) {
 			mockConsumer := mocks.NewMockReadCloser(ctrl)
 			mockProducer := mocks.NewMockWriteCloser(ctrl)
 
+			tt.mock(mockProducer)
+
 			storage := &Storage{
 				Consumer: mockConsumer,
 				Producer: mockProducer,
 			}
 
 			repo := setupRepoFileMemory(storage)
-			if got := repo.Delete(context.Background(), tt.urls); got != tt.want {
+			if got := repo.Update(context.Background(), tt.update); got != tt.want {
+				t.Errorf("TestRepoFileMemory_Update() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRepoFileMemory_RecordClick(t *testing.T) {
+	tests := []struct {
+		name          string
+		shortURL      string
+		position      int
+		wantClicks    []int64
+		wantURLClicks int64
+		wantErr       error
+	}{
+		{
+			name:          "тест 1",
+			shortURL:      urlAlias2,
+			position:      1,
+			wantClicks:    []int64{3, 2},
+			wantURLClicks: 1,
+			wantErr:       nil,
+		},
+		{
+			name:          "тест 2, несуществующий адрес назначения",
+			shortURL:      urlAlias2,
+			position:      2,
+			wantClicks:    []int64{3, 1},
+			wantURLClicks: 1,
+			wantErr:       nil,
+		},
+		{
+			name:          "тест 3, переход без выбора адреса назначения",
+			shortURL:      urlAlias2,
+			position:      -1,
+			wantClicks:    []int64{3, 1},
+			wantURLClicks: 1,
+			wantErr:       nil,
+		},
+		{
+			name:          "тест 4, несуществующий URL",
+			shortURL:      "notExist",
+			position:      0,
+			wantClicks:    []int64{3, 1},
+			wantURLClicks: 0,
+			wantErr:       constants.ErrorURLNotExist,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := setupRepoFileMemory(&Storage{})
+
+			gotErr := repo.RecordClick(context.Background(), "", tt.shortURL, tt.position)
+			if !errors.Is(gotErr, tt.wantErr) {
+				t.Errorf("TestRepoFileMemory_RecordClick() = %v, wantErr %v", gotErr, tt.wantErr)
+			}
+
+			url, _ := repo.SelectOriginal(context.Background(), "", urlAlias2)
+			gotClicks := make([]int64, 0, len(url.Destinations))
+			for _, destination := range url.Destinations {
+				gotClicks = append(gotClicks, destination.Clicks)
+			}
+			if !reflect.DeepEqual(gotClicks, tt.wantClicks) {
+				t.Errorf("TestRepoFileMemory_RecordClick() = %v, want %v", gotClicks, tt.wantClicks)
+			}
+			if url.Clicks != tt.wantURLClicks {
+				t.Errorf("TestRepoFileMemory_RecordClick() clicks = %v, want %v", url.Clicks, tt.wantURLClicks)
+			}
+		})
+	}
+}
+
+func TestRepoFileMemory_Delete(t *testing.T) {
+	deletedURL1 := testURLFull1
+	deletedURL1.DeletedFlag = true
+
+	tests := []struct {
+		name    string
+		urls    []models.URLBase
+		want    []models.URLBase
+		wantErr error
+	}{
+		{
+			name: "тест 1, удаление собственного URL",
+			urls: []models.URLBase{{UUID: UUID, Short: urlAlias1}},
+			want: []models.URLBase{deletedURL1},
+		},
+		{
+			name: "тест 2, URL другого пользователя не удаляется",
+			urls: []models.URLBase{{UUID: "other", Short: urlAlias1}},
+		},
+		{
+			name: "тест 3, URL с тем же кодом в другом домене не удаляется",
+			urls: []models.URLBase{{UUID: UUID, Short: urlAlias1, Domain: "go.brand-a.com"}},
+		},
+		{
+			name: "тест 4, ранее удаленный и несуществующий URL не возвращаются",
+			urls: []models.URLBase{{UUID: UUID, Short: urlAlias4}, {UUID: UUID, Short: "missing"}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockConsumer := mocks.NewMockReadCloser(ctrl)
+			mockProducer := mocks.NewMockWriteCloser(ctrl)
+
+			storage := &Storage{
+				Consumer: mockConsumer,
+				Producer: mockProducer,
+			}
+
+			repo := setupRepoFileMemory(storage)
+			got, gotErr := repo.Delete(context.Background(), tt.urls)
+			if gotErr != tt.wantErr {
+				t.Errorf("TestRepoFileMemory_Delete() error = %v, want %v", gotErr, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
 				t.Errorf("TestRepoFileMemory_Delete() = %v, want %v", got, tt.want)
 			}
 		})
 	}
 }
+
+func TestRepoFileMemory_ConsumeClick(t *testing.T) {
+	const (
+		maxClicks = 3
+		visitors  = 20
+	)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockProducer := mocks.NewMockWriteCloser(ctrl)
+	mockProducer.EXPECT().Write(gomock.Any()).Return(nil).Times(maxClicks)
+
+	repo := setupRepoFileMemory(&Storage{Producer: mockProducer})
+	limit := maxClicks
+	repo.URLs[0].MaxClicks = &limit
+
+	var (
+		wg        sync.WaitGroup
+		consumed  atomic.Int32
+		exhausted atomic.Int32
+	)
+	for range visitors {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			err := repo.ConsumeClick(context.Background(), "", urlAlias1)
+			switch {
+			case err == nil:
+				consumed.Add(1)
+			case errors.Is(err, constants.ErrorURLClicksExhausted):
+				exhausted.Add(1)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if consumed.Load() != maxClicks || exhausted.Load() != visitors-maxClicks {
+		t.Errorf("TestRepoFileMemory_ConsumeClick() consumed = %d, exhausted = %d", consumed.Load(), exhausted.Load())
+	}
+	if limit != maxClicks {
+		t.Errorf("TestRepoFileMemory_ConsumeClick() changed shared value = %d", limit)
+	}
+
+	if err := repo.ConsumeClick(context.Background(), "", urlAlias2); err != nil {
+		t.Errorf("TestRepoFileMemory_ConsumeClick() URL without limit = %v", err)
+	}
+}
+
+func TestRepoFileMemory_UpdateHealth(t *testing.T) {
+	health := models.URLHealth{Status: 404, Broken: true}
+
+	repo := setupRepoFileMemory(&Storage{})
+	if err := repo.UpdateHealth(context.Background(), "", urlAlias1, health); err != nil {
+		t.Fatalf("TestRepoFileMemory_UpdateHealth() = %v", err)
+	}
+	if err := repo.UpdateHealth(context.Background(), "", urlAlias3, health); err != constants.ErrorURLNotExist {
+		t.Errorf("TestRepoFileMemory_UpdateHealth() = %v, want %v", err, constants.ErrorURLNotExist)
+	}
+	if err := repo.UpdateHealth(context.Background(), "go.brand-a.com", urlAlias1, health); err != constants.ErrorURLNotExist {
+		t.Errorf("TestRepoFileMemory_UpdateHealth() = %v, want %v", err, constants.ErrorURLNotExist)
+	}
+
+	urls, _ := repo.SelectAll(context.Background(), UUID, models.URLFilter{})
+	if urls[0].Health == nil || *urls[0].Health != health {
+		t.Errorf("TestRepoFileMemory_UpdateHealth() = %v, want %v", urls[0].Health, health)
+	}
+
+	checked, _ := repo.SelectForHealthCheck(context.Background())
+	want := []models.URLBase{{Short: urlAlias1, Original: url1}, {Short: urlAlias2, Original: url2}}
+	if !reflect.DeepEqual(checked, want) {
+		t.Errorf("TestRepoFileMemory_SelectForHealthCheck() = %v, want %v", checked, want)
+	}
+}
+
+// producerSizer - файл-хранилище известного размера для тестов.
+type producerSizer struct {
+	size int64
+}
+
+func (p producerSizer) Write(models.URLBase) error { return nil }
+func (p producerSizer) Close() error               { return nil }
+func (p producerSizer) Size() (int64, error)       { return p.size, nil }
+
+func TestRepoFileMemory_GetStats(t *testing.T) {
+	from := time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2026, 10, 19, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name   string
+		filter models.StatsFilter
+		want   *models.Stats
+	}{
+		{
+			name:   "тест 1",
+			filter: models.StatsFilter{From: from, To: to, Top: 10},
+			want: &models.Stats{
+				CountURL:     4,
+				CountUsers:   2,
+				CountActive:  3,
+				CountDeleted: 1,
+				StorageBytes: 4096,
+				From:         from,
+				To:           to,
+				PerDay:       []models.DailyStats{{Date: "2026-10-02", Count: 2}, {Date: "2026-10-05", Count: 1}},
+				TopURLs: []models.URLClicks{
+					{Short: urlAlias2, Original: url2, Clicks: 12},
+					{Short: urlAlias1, Original: url1, Clicks: 5},
+				},
+				TopUsers: []models.UserStats{{UUID: UUID, Count: 3}},
+			},
+		},
+		{
+			name:   "тест 2, рейтинги ограничены",
+			filter: models.StatsFilter{From: from, To: to, Top: 1},
+			want: &models.Stats{
+				CountURL:     4,
+				CountUsers:   2,
+				CountActive:  3,
+				CountDeleted: 1,
+				StorageBytes: 4096,
+				From:         from,
+				To:           to,
+				PerDay:       []models.DailyStats{{Date: "2026-10-02", Count: 2}, {Date: "2026-10-05", Count: 1}},
+				TopURLs:      []models.URLClicks{{Short: urlAlias2, Original: url2, Clicks: 12}},
+				TopUsers:     []models.UserStats{{UUID: UUID, Count: 3}},
+			},
+		},
+		{
+			name:   "тест 3, ссылки вне периода не учитываются",
+			filter: models.StatsFilter{From: from.AddDate(0, -1, 0), To: from, Top: 10},
+			want: &models.Stats{
+				CountURL:     4,
+				CountUsers:   2,
+				CountActive:  3,
+				CountDeleted: 1,
+				StorageBytes: 4096,
+				From:         from.AddDate(0, -1, 0),
+				To:           from,
+				PerDay:       []models.DailyStats{{Date: "2026-09-01", Count: 1}},
+				TopURLs:      []models.URLClicks{{Short: urlAlias3, Original: url3, Clicks: 1}},
+				TopUsers:     []models.UserStats{{UUID: "01KA3YRQCWTNAJEGR5Z30PH6VX", Count: 1}},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := setupRepoFileMemory(&Storage{Producer: producerSizer{size: 4096}})
+			repo.URLs[0].CreatedAt = time.Date(2026, 10, 2, 10, 0, 0, 0, time.UTC)
+			repo.URLs[0].Clicks = 5
+			repo.URLs[1].CreatedAt = time.Date(2026, 10, 2, 20, 0, 0, 0, time.UTC)
+			repo.URLs[1].Clicks = 12
+			repo.URLs[2].CreatedAt = time.Date(2026, 10, 5, 8, 0, 0, 0, time.UTC)
+			repo.URLs[2].Clicks = 7
+			repo.URLs = append(repo.URLs, models.URLBase{
+				UUID:      "01KA3YRQCWTNAJEGR5Z30PH6VX",
+				Original:  url3,
+				Short:     urlAlias3,
+				CreatedAt: time.Date(2026, 9, 1, 12, 0, 0, 0, time.UTC),
+				Clicks:    1,
+			})
+
+			got, err := repo.GetStats(context.Background(), tt.filter)
+			if err != nil {
+				t.Fatalf("TestRepoFileMemory_GetStats() error = %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("TestRepoFileMemory_GetStats() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRepoFileMemory_LookupURLs(t *testing.T) {
+	tests := []struct {
+		name   string
+		userID string
+		lookup models.URLLookup
+		want   []string
+	}{
+		{
+			name:   "точное совпадение",
+			userID: UUID,
+			lookup: models.URLLookup{Mode: models.LookupExact, Query: url1, Limit: 10},
+			want:   []string{urlAlias1},
+		},
+		{
+			name:   "префикс",
+			userID: UUID,
+			lookup: models.URLLookup{Mode: models.LookupPrefix, Query: "https://www.", Limit: 10},
+			want:   []string{urlAlias1, urlAlias2, "shop0001"},
+		},
+		{
+			name:   "хост с поддоменами",
+			userID: UUID,
+			lookup: models.URLLookup{Mode: models.LookupHost, Query: "dynamo.ru", Limit: 10},
+			want:   []string{urlAlias2, "shop0001", "shop0002"},
+		},
+		{
+			name:   "подстрока без учета регистра",
+			userID: UUID,
+			lookup: models.URLLookup{Mode: models.LookupContains, Query: "DYNAMO", Limit: 10},
+			want:   []string{urlAlias2, "shop0001", "shop0002"},
+		},
+		{
+			name:   "ограничение числа URL",
+			userID: UUID,
+			lookup: models.URLLookup{Mode: models.LookupContains, Query: "dynamo", Limit: 2},
+			want:   []string{urlAlias2, "shop0001"},
+		},
+		{
+			name:   "удаленный URL не возвращается",
+			userID: UUID,
+			lookup: models.URLLookup{Mode: models.LookupExact, Query: url4, Limit: 10},
+			want:   nil,
+		},
+		{
+			name:   "URL другого пользователя не возвращаются",
+			userID: "01KA3YRQCWTNAJEGR5Z30PH6VX",
+			lookup: models.URLLookup{Mode: models.LookupExact, Query: url1, Limit: 10},
+			want:   nil,
+		},
+	}
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockProducer := mocks.NewMockWriteCloser(ctrl)
+	mockProducer.EXPECT().Write(gomock.Any()).Return(nil).AnyTimes()
+
+	repo := setupRepoFileMemory(&Storage{Producer: mockProducer})
+	// первый поиск индексирует загруженные URL, следующие URL индексируются при очередном поиске
+	if _, err := repo.LookupURLs(context.Background(), UUID, models.URLLookup{Mode: models.LookupExact, Query: url1, Limit: 1}); err != nil {
+		t.Fatalf("LookupURLs() error = %v", err)
+	}
+	err := repo.InsertBatch(context.Background(), []models.URLBase{
+		{UUID: UUID, Original: "https://www.shop.dynamo.ru/", Short: "shop0001"},
+		{UUID: UUID, Original: "http://tickets.Dynamo.ru/", Short: "shop0002"},
+	})
+	if err != nil {
+		t.Fatalf("InsertBatch() error = %v", err)
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			urls, err := repo.LookupURLs(context.Background(), tt.userID, tt.lookup)
+			if err != nil {
+				t.Fatalf("LookupURLs() error = %v", err)
+			}
+
+			var got []string
+			for _, url := range urls {
+				got = append(got, url.Short)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("LookupURLs() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRepoFileMemory_LookupURLsAfterDelete(t *testing.T) {
+	ctx := context.Background()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockProducer := mocks.NewMockWriteCloser(ctrl)
+	mockProducer.EXPECT().Write(gomock.Any()).Return(nil).AnyTimes()
+
+	repo := NewRepoFileMemory(&Storage{Producer: mockProducer})
+
+	originals := make(map[string]string)
+	for _, name := range []string{"a", "b", "c", "d", "e", "f", "g"} {
+		original := "https://" + name + ".example.com/"
+		originals[name] = original
+		if err := repo.InsertOrdinary(ctx, models.URLBase{UUID: UUID, Original: original, Short: "short-" + name}); err != nil {
+			t.Fatalf("InsertOrdinary() error = %v", err)
+		}
+	}
+
+	lookup := func(original string) []models.URLBase {
+		urls, err := repo.LookupURLs(ctx, UUID, models.URLLookup{Mode: models.LookupExact, Query: original, Limit: 10})
+		if err != nil {
+			t.Fatalf("LookupURLs() error = %v", err)
+		}
+		return urls
+	}
+
+	// построение индекса до удаления
+	lookup(originals["a"])
+
+	// удаление из середины индекса пользователем и администратором
+	if _, err := repo.Delete(ctx, []models.URLBase{{UUID: UUID, Short: "short-d"}}); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+	if err := repo.ForceDelete(ctx, "", "short-f"); err != nil {
+		t.Fatalf("ForceDelete() error = %v", err)
+	}
+	checkRemaining := func() {
+		t.Helper()
+		for name, original := range originals {
+			urls := lookup(original)
+			if name == "d" || name == "f" {
+				if len(urls) != 0 {
+					t.Errorf("LookupURLs(%s) = %v, want no urls", original, urls)
+				}
+				continue
+			}
+			if len(urls) != 1 || urls[0].Short != "short-"+name {
+				t.Errorf("LookupURLs(%s) = %v, want short-%s", original, urls, name)
+			}
+		}
+	}
+	checkRemaining()
+
+	// URL, добавленный после удаления, занимает место в середине индекса
+	if err := repo.InsertOrdinary(ctx, models.URLBase{UUID: UUID, Original: "https://cc.example.com/", Short: "short-cc"}); err != nil {
+		t.Fatalf("InsertOrdinary() error = %v", err)
+	}
+	checkRemaining()
+	if urls := lookup("https://cc.example.com/"); len(urls) != 1 || urls[0].Short != "short-cc" {
+		t.Errorf("LookupURLs() = %v, want short-cc", urls)
+	}
+
+	// повторное сокращение: оставшийся URL обнаруживается, удаленный сокращается заново
+	err := repo.InsertOrdinary(ctx, models.URLBase{UUID: UUID, Original: originals["b"], Short: "short-b2"})
+	if !errors.Is(err, constants.ErrorURLAlreadyExist) {
+		t.Errorf("InsertOrdinary() error = %v, want %v", err, constants.ErrorURLAlreadyExist)
+	}
+	if err = repo.InsertOrdinary(ctx, models.URLBase{UUID: UUID, Original: originals["d"], Short: "short-d2"}); err != nil {
+		t.Errorf("InsertOrdinary() error = %v, want nil", err)
+	}
+	if urls := lookup(originals["d"]); len(urls) != 1 || urls[0].Short != "short-d2" {
+		t.Errorf("LookupURLs() = %v, want short-d2", urls)
+	}
+}
@@ -0,0 +1,183 @@
+package repository
+
+import (
+	"context"
+	"slices"
+	"sort"
+	"time"
+
+	"github.com/Di-nis/shortener-url/internal/constants"
+	"github.com/Di-nis/shortener-url/internal/models"
+)
+
+// Подписки на события и очередь доставок хранятся только в памяти приложения:
+// файл-хранилище содержит записи URL, поэтому после перезапуска они не восстанавливаются.
+// По этой причине при файле-хранилище доставка событий не включается (см. app.checkWebhookStorage).
+
+// finishedDeliveriesLimit - число завершенных доставок подписки, сохраняемых в журнале.
+// Более старые доставленные и неуспешные доставки удаляются, чтобы журнал в памяти не рос без ограничений.
+const finishedDeliveriesLimit = 100
+
+// InsertWebhook - добавление подписки на события.
+func (repo *RepoFileMemory) InsertWebhook(ctx context.Context, webhook models.Webhook) error {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	webhook.Events = slices.Clone(webhook.Events)
+	repo.webhooks = append(repo.webhooks, webhook)
+	return nil
+}
+
+// SelectWebhooks - получение подписок пользователя без секретов.
+func (repo *RepoFileMemory) SelectWebhooks(ctx context.Context, userID string) ([]models.Webhook, error) {
+	repo.mu.RLock()
+	defer repo.mu.RUnlock()
+
+	var webhooks []models.Webhook
+	for _, webhook := range repo.webhooks {
+		if webhook.UUID == userID {
+			webhook.Events = slices.Clone(webhook.Events)
+			webhook.Secret = ""
+			webhooks = append(webhooks, webhook)
+		}
+	}
+	return webhooks, nil
+}
+
+// DeleteWebhook - удаление подписки пользователя вместе с журналом доставок.
+func (repo *RepoFileMemory) DeleteWebhook(ctx context.Context, userID, webhookID string) error {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	idx := slices.IndexFunc(repo.webhooks, func(webhook models.Webhook) bool {
+		return webhook.ID == webhookID && webhook.UUID == userID
+	})
+	if idx < 0 {
+		return constants.ErrorNotFound
+	}
+
+	repo.webhooks = slices.Delete(repo.webhooks, idx, idx+1)
+	repo.deliveries = slices.DeleteFunc(repo.deliveries, func(delivery models.WebhookDelivery) bool {
+		return delivery.WebhookID == webhookID
+	})
+	return nil
+}
+
+// InsertDeliveries - постановка доставок событий в очередь.
+func (repo *RepoFileMemory) InsertDeliveries(ctx context.Context, deliveries []models.WebhookDelivery) error {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	for _, delivery := range deliveries {
+		delivery.UpdatedAt = delivery.CreatedAt
+		repo.deliveries = append(repo.deliveries, delivery)
+	}
+	return nil
+}
+
+// ClaimDueDeliveries - выборка доставок, время отправки которых наступило.
+// Время следующей попытки выбранных доставок переносится на leaseUntil.
+func (repo *RepoFileMemory) ClaimDueDeliveries(ctx context.Context, now, leaseUntil time.Time, limit int) ([]models.WebhookDelivery, error) {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	var due []int
+	for i, delivery := range repo.deliveries {
+		if delivery.Status == models.DeliveryPending && !delivery.NextAttemptAt.After(now) {
+			due = append(due, i)
+		}
+	}
+	sort.SliceStable(due, func(i, j int) bool {
+		return repo.deliveries[due[i]].NextAttemptAt.Before(repo.deliveries[due[j]].NextAttemptAt)
+	})
+	if len(due) > limit {
+		due = due[:limit]
+	}
+
+	deliveries := make([]models.WebhookDelivery, 0, len(due))
+	for _, i := range due {
+		repo.deliveries[i].NextAttemptAt = leaseUntil
+
+		delivery := repo.deliveries[i]
+		for _, webhook := range repo.webhooks {
+			if webhook.ID == delivery.WebhookID {
+				delivery.URL = webhook.URL
+				delivery.Secret = webhook.Secret
+			}
+		}
+		deliveries = append(deliveries, delivery)
+	}
+	return deliveries, nil
+}
+
+// UpdateDelivery - сохранение результата попытки доставки.
+func (repo *RepoFileMemory) UpdateDelivery(ctx context.Context, delivery models.WebhookDelivery) error {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	for i, deliveryDB := range repo.deliveries {
+		if deliveryDB.ID != delivery.ID {
+			continue
+		}
+		repo.deliveries[i].Status = delivery.Status
+		repo.deliveries[i].Attempts = delivery.Attempts
+		repo.deliveries[i].LastStatusCode = delivery.LastStatusCode
+		repo.deliveries[i].LastError = delivery.LastError
+		repo.deliveries[i].NextAttemptAt = delivery.NextAttemptAt
+		repo.deliveries[i].UpdatedAt = delivery.UpdatedAt
+		if delivery.Status != models.DeliveryPending {
+			repo.pruneDeliveries(deliveryDB.WebhookID)
+		}
+		return nil
+	}
+	// подписка могла быть удалена во время отправки
+	return nil
+}
+
+// pruneDeliveries - удаление старых завершенных доставок подписки сверх finishedDeliveriesLimit.
+// Доставки, ожидающие отправки, не удаляются.
+func (repo *RepoFileMemory) pruneDeliveries(webhookID string) {
+	finished := func(delivery models.WebhookDelivery) bool {
+		return delivery.WebhookID == webhookID && delivery.Status != models.DeliveryPending
+	}
+
+	var count int
+	for _, delivery := range repo.deliveries {
+		if finished(delivery) {
+			count++
+		}
+	}
+
+	// доставки хранятся в порядке постановки в очередь, поэтому удаляются первые из завершенных
+	excess := count - finishedDeliveriesLimit
+	repo.deliveries = slices.DeleteFunc(repo.deliveries, func(delivery models.WebhookDelivery) bool {
+		if excess > 0 && finished(delivery) {
+			excess--
+			return true
+		}
+		return false
+	})
+}
+
+// SelectDeliveries - получение журнала доставок подписки пользователя, начиная с последних.
+func (repo *RepoFileMemory) SelectDeliveries(ctx context.Context, userID, webhookID string, limit int) ([]models.WebhookDelivery, error) {
+	repo.mu.RLock()
+	defer repo.mu.RUnlock()
+
+	owned := slices.ContainsFunc(repo.webhooks, func(webhook models.Webhook) bool {
+		return webhook.ID == webhookID && webhook.UUID == userID
+	})
+	if !owned {
+		return nil, nil
+	}
+
+	var deliveries []models.WebhookDelivery
+	for i := len(repo.deliveries) - 1; i >= 0 && len(deliveries) < limit; i-- {
+		delivery := repo.deliveries[i]
+		if delivery.WebhookID == webhookID {
+			delivery.Payload = nil
+			deliveries = append(deliveries, delivery)
+		}
+	}
+	return deliveries, nil
+}
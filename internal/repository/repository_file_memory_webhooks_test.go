@@ -0,0 +1,139 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/Di-nis/shortener-url/internal/constants"
+	"github.com/Di-nis/shortener-url/internal/models"
+)
+
+// webhookID - идентификатор подписки для тестов.
+const webhookID = "6f9619ff-8b86-d011-b42d-00cf4fc964ff"
+
+func TestRepoFileMemory_Webhooks(t *testing.T) {
+	ctx := context.Background()
+	repo := setupRepoFileMemory(&Storage{})
+
+	webhook := models.Webhook{ID: webhookID, UUID: UUID, URL: "https://hooks.example.com", Secret: "secret"}
+	if err := repo.InsertWebhook(ctx, webhook); err != nil {
+		t.Fatalf("TestRepoFileMemory_InsertWebhook() = %v", err)
+	}
+
+	webhooks, _ := repo.SelectWebhooks(ctx, UUID)
+	if len(webhooks) != 1 || webhooks[0].ID != webhookID || webhooks[0].Secret != "" {
+		t.Errorf("TestRepoFileMemory_SelectWebhooks() = %v", webhooks)
+	}
+	if webhooks, _ = repo.SelectWebhooks(ctx, "other"); len(webhooks) != 0 {
+		t.Errorf("TestRepoFileMemory_SelectWebhooks() other user = %v", webhooks)
+	}
+
+	if err := repo.DeleteWebhook(ctx, "other", webhookID); !errors.Is(err, constants.ErrorNotFound) {
+		t.Errorf("TestRepoFileMemory_DeleteWebhook() other user = %v, want %v", err, constants.ErrorNotFound)
+	}
+	if err := repo.DeleteWebhook(ctx, UUID, webhookID); err != nil {
+		t.Errorf("TestRepoFileMemory_DeleteWebhook() = %v", err)
+	}
+	if webhooks, _ = repo.SelectWebhooks(ctx, UUID); len(webhooks) != 0 {
+		t.Errorf("TestRepoFileMemory_DeleteWebhook() webhooks left = %v", webhooks)
+	}
+}
+
+func TestRepoFileMemory_Deliveries(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now().UTC()
+	repo := setupRepoFileMemory(&Storage{})
+
+	webhook := models.Webhook{ID: webhookID, UUID: UUID, URL: "https://hooks.example.com", Secret: "secret"}
+	if err := repo.InsertWebhook(ctx, webhook); err != nil {
+		t.Fatalf("TestRepoFileMemory_InsertWebhook() = %v", err)
+	}
+
+	err := repo.InsertDeliveries(ctx, []models.WebhookDelivery{
+		{ID: "1", WebhookID: webhookID, Status: models.DeliveryPending, NextAttemptAt: now.Add(-time.Second), CreatedAt: now},
+		{ID: "2", WebhookID: webhookID, Status: models.DeliveryPending, NextAttemptAt: now.Add(time.Hour), CreatedAt: now},
+	})
+	if err != nil {
+		t.Fatalf("TestRepoFileMemory_InsertDeliveries() = %v", err)
+	}
+
+	lease := now.Add(time.Minute)
+	due, _ := repo.ClaimDueDeliveries(ctx, now, lease, 10)
+	if len(due) != 1 || due[0].ID != "1" || due[0].URL != webhook.URL || due[0].Secret != webhook.Secret {
+		t.Fatalf("TestRepoFileMemory_ClaimDueDeliveries() = %v", due)
+	}
+	// выбранная доставка не выбирается повторно до истечения аренды
+	if due, _ = repo.ClaimDueDeliveries(ctx, now, lease, 10); len(due) != 0 {
+		t.Errorf("TestRepoFileMemory_ClaimDueDeliveries() repeated = %v", due)
+	}
+
+	delivered := models.WebhookDelivery{ID: "1", Status: models.DeliveryDelivered, Attempts: 1, LastStatusCode: 200, UpdatedAt: now}
+	if err = repo.UpdateDelivery(ctx, delivered); err != nil {
+		t.Errorf("TestRepoFileMemory_UpdateDelivery() = %v", err)
+	}
+
+	deliveries, _ := repo.SelectDeliveries(ctx, UUID, webhookID, 10)
+	if len(deliveries) != 2 || deliveries[0].ID != "2" || deliveries[1].Status != models.DeliveryDelivered {
+		t.Errorf("TestRepoFileMemory_SelectDeliveries() = %v", deliveries)
+	}
+	if deliveries, _ = repo.SelectDeliveries(ctx, "other", webhookID, 10); len(deliveries) != 0 {
+		t.Errorf("TestRepoFileMemory_SelectDeliveries() other user = %v", deliveries)
+	}
+
+	// удаление подписки удаляет журнал доставок
+	if err = repo.DeleteWebhook(ctx, UUID, webhookID); err != nil {
+		t.Fatalf("TestRepoFileMemory_DeleteWebhook() = %v", err)
+	}
+	if len(repo.deliveries) != 0 {
+		t.Errorf("TestRepoFileMemory_DeleteWebhook() deliveries left = %v", repo.deliveries)
+	}
+}
+
+func TestRepoFileMemory_DeliveriesRetention(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now().UTC()
+	repo := setupRepoFileMemory(&Storage{})
+
+	webhook := models.Webhook{ID: webhookID, UUID: UUID, URL: "https://hooks.example.com", Secret: "secret"}
+	if err := repo.InsertWebhook(ctx, webhook); err != nil {
+		t.Fatalf("TestRepoFileMemory_InsertWebhook() = %v", err)
+	}
+
+	total := finishedDeliveriesLimit + 50
+	deliveries := make([]models.WebhookDelivery, 0, total+1)
+	for i := range total {
+		deliveries = append(deliveries, models.WebhookDelivery{
+			ID: fmt.Sprintf("%d", i), WebhookID: webhookID, Status: models.DeliveryPending, NextAttemptAt: now.Add(-time.Second), CreatedAt: now,
+		})
+	}
+	// доставка, ожидающая отправки, сохраняется независимо от числа завершенных
+	deliveries = append(deliveries, models.WebhookDelivery{
+		ID: "pending", WebhookID: webhookID, Status: models.DeliveryPending, NextAttemptAt: now.Add(time.Hour), CreatedAt: now,
+	})
+	if err := repo.InsertDeliveries(ctx, deliveries); err != nil {
+		t.Fatalf("TestRepoFileMemory_InsertDeliveries() = %v", err)
+	}
+
+	claimed, _ := repo.ClaimDueDeliveries(ctx, now, now.Add(time.Minute), total)
+	for i, delivery := range claimed {
+		delivery.Status = models.DeliveryDelivered
+		if i%2 == 1 {
+			delivery.Status = models.DeliveryFailed
+		}
+		if err := repo.UpdateDelivery(ctx, delivery); err != nil {
+			t.Fatalf("TestRepoFileMemory_UpdateDelivery() = %v", err)
+		}
+	}
+
+	got, _ := repo.SelectDeliveries(ctx, UUID, webhookID, total+1)
+	if len(got) != finishedDeliveriesLimit+1 {
+		t.Fatalf("TestRepoFileMemory_SelectDeliveries() = %d deliveries, want %d", len(got), finishedDeliveriesLimit+1)
+	}
+	// сохраняются последние завершенные доставки
+	if got[0].ID != "pending" || got[1].ID != fmt.Sprintf("%d", total-1) || got[len(got)-1].ID != fmt.Sprintf("%d", total-finishedDeliveriesLimit) {
+		t.Errorf("TestRepoFileMemory_SelectDeliveries() = %s, %s ... %s", got[0].ID, got[1].ID, got[len(got)-1].ID)
+	}
+}
@@ -6,6 +6,8 @@ package repository
 import (
 	"context"
 	"database/sql"
+	"database/sql/driver"
+	"encoding/json"
 	"errors"
 	"strings"
 	"time"
@@ -19,12 +21,15 @@ import (
 	_ "github.com/golang-migrate/migrate/v4/source/file"
 	"github.com/jackc/pgx/v5/pgconn"
 	_ "github.com/jackc/pgx/v5/stdlib"
-	_ "github.com/lib/pq"
+	"github.com/lib/pq"
 )
 
 // RepoPostgres - репозиторий для работы с БД Postgres.
 type RepoPostgres struct {
 	db *sql.DB
+	// PerUserOriginals - режим, в котором каждый пользователь получает собственный короткий URL
+	// для оригинального URL, уже сокращенного другими пользователями.
+	PerUserOriginals bool
 }
 
 // NewRepoPostgres - конструктор репозитория.
@@ -48,6 +53,15 @@ func (repo *RepoPostgres) Ping(ctx context.Context) error {
 	return repo.db.PingContext(ctx)
 }
 
+// NextID - следующий номер последовательности коротких URL.
+func (repo *RepoPostgres) NextID(ctx context.Context) (uint64, error) {
+	var id uint64
+	if err := repo.db.QueryRowContext(ctx, "SELECT nextval('urls_short_seq')").Scan(&id); err != nil {
+		return 0, fmt.Errorf("path: internal/repository/postgres_repository.go, func NextID(), failed to get next id: %w", err)
+	}
+	return id, nil
+}
+
 // Close - закрытие соединения с БД.
 func (repo *RepoPostgres) Close() error {
 	return repo.db.Close()
@@ -78,50 +92,210 @@ func (repo *RepoPostgres) Migrations() error {
 	return nil
 }
 
+// insertTags - добавление тегов URL.
+func insertTags(ctx context.Context, tx *sql.Tx, urlID int, tags []string) error {
+	if len(tags) == 0 {
+		return nil
+	}
+
+	query := "INSERT INTO url_tags (url_id, tag) SELECT $1, unnest($2::text[]) ON CONFLICT DO NOTHING"
+	_, err := tx.ExecContext(ctx, query, urlID, pq.Array(tags))
+	return err
+}
+
+// insertDestinations - добавление адресов назначения URL.
+func insertDestinations(ctx context.Context, tx *sql.Tx, urlID int, destinations []models.Destination) error {
+	if len(destinations) == 0 {
+		return nil
+	}
+
+	urls := make([]string, len(destinations))
+	weights := make([]int64, len(destinations))
+	for idx, destination := range destinations {
+		urls[idx] = destination.URL
+		weights[idx] = int64(destination.Weight)
+	}
+
+	query := `INSERT INTO url_destinations (url_id, position, url, weight)
+	SELECT $1, d.position - 1, d.url, d.weight
+	FROM unnest($2::text[], $3::int[]) WITH ORDINALITY AS d(url, weight, position)`
+	_, err := tx.ExecContext(ctx, query, urlID, pq.Array(urls), pq.Array(weights))
+	return err
+}
+
+// destinationsColumn - выражение для получения адресов назначения URL в формате JSON.
+const destinationsColumn = `COALESCE((SELECT json_agg(json_build_object('url', d.url, 'weight', d.weight, 'clicks', d.clicks) ORDER BY d.position)
+		FROM url_destinations d WHERE d.url_id = u.id), '[]')`
+
+// jsonScanner - реализация sql.Scanner для списков в формате JSON
+// (адреса назначения, правила перенаправления).
+type jsonScanner[T any] struct {
+	list *[]T
+}
+
+// Scan - разбор списка.
+func (s jsonScanner[T]) Scan(src any) error {
+	var data []byte
+	switch value := src.(type) {
+	case nil:
+		return nil
+	case []byte:
+		data = value
+	case string:
+		data = []byte(value)
+	default:
+		return fmt.Errorf("path: internal/repository/postgres_repository.go, func Scan(), unsupported type %T", src)
+	}
+
+	var list []T
+	if err := json.Unmarshal(data, &list); err != nil {
+		return err
+	}
+	if len(list) > 0 {
+		*s.list = list
+	}
+	return nil
+}
+
+// rulesValue - реализация driver.Valuer для правил перенаправления в формате JSON.
+type rulesValue []models.TargetRule
+
+// Value - сериализация правил перенаправления.
+func (rules rulesValue) Value() (driver.Value, error) {
+	if len(rules) == 0 {
+		return "[]", nil
+	}
+	data, err := json.Marshal([]models.TargetRule(rules))
+	if err != nil {
+		return nil, err
+	}
+	return string(data), nil
+}
+
+// healthScanner - результат проверки доступности URL, который может отсутствовать.
+type healthScanner struct {
+	status    sql.NullInt64
+	error     sql.NullString
+	checkedAt sql.NullTime
+	broken    sql.NullBool
+}
+
+// value - получение результата проверки, nil - URL еще не проверялся.
+func (h healthScanner) value() *models.URLHealth {
+	if !h.checkedAt.Valid {
+		return nil
+	}
+	return &models.URLHealth{
+		Status:    int(h.status.Int64),
+		Error:     h.error.String,
+		CheckedAt: h.checkedAt.Time,
+		Broken:    h.broken.Bool,
+	}
+}
+
+// shortUniqueIndex - уникальный индекс коротких URL в пространстве имен домена.
+const shortUniqueIndex = "idx_urls_domain_short"
+
+// insertConflict - ошибка нарушения уникальности при добавлении URL:
+// ErrorShortCollision для занятого короткого URL и ErrorURLAlreadyExist для повторного оригинального URL.
+// Для остальных ошибок возвращается nil.
+func insertConflict(err error) error {
+	var pgErr *pgconn.PgError
+	if !errors.As(err, &pgErr) || pgErr.Code != "23505" {
+		return nil
+	}
+	if pgErr.ConstraintName == shortUniqueIndex {
+		return constants.ErrorShortCollision
+	}
+	return constants.ErrorURLAlreadyExist
+}
+
+// insertURLQuery - запрос на добавление URL.
+const insertURLQuery = `INSERT INTO urls (original, short, user_id, folder, title, description, pass_query, pass_path, rules, not_before, max_clicks, domain, original_owner)
+	VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13) RETURNING id`
+
+// originalOwner - владелец оригинального URL, в пределах которого оригинальный URL уникален:
+// пользователь в режиме PerUserOriginals, иначе пустая строка, общая для всех пользователей.
+func (repo *RepoPostgres) originalOwner(userID string) string {
+	if repo.PerUserOriginals {
+		return userID
+	}
+	return ""
+}
+
 // InsertOrdinary - добавление ординарного URL в БД.
 func (repo *RepoPostgres) InsertOrdinary(ctx context.Context, url models.URLBase) error {
-	query := "INSERT INTO urls (original, short, user_id) VALUES ($1, $2, $3)"
-	_, err := repo.db.ExecContext(ctx, query, url.Original, url.Short, url.UUID)
+	tx, err := repo.db.BeginTx(ctx, nil)
 	if err != nil {
-		var pgErr *pgconn.PgError
-		if errors.As(err, &pgErr) && pgErr.Code == "23505" {
-			return fmt.Errorf("path: internal/repository/postgres_repository.go, func InsertOrdinary(), failed to insert url: %w", constants.ErrorURLAlreadyExist)
+		return fmt.Errorf("path: internal/repository/postgres_repository.go, func InsertOrdinary(), failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	var urlID int
+	err = tx.QueryRowContext(ctx, insertURLQuery,
+		url.Original, url.Short, url.UUID, url.Folder, url.Title, url.Description, url.PassQuery, url.PassPath,
+		rulesValue(url.Rules), url.NotBefore, url.MaxClicks, url.Domain, repo.originalOwner(url.UUID),
+	).Scan(&urlID)
+	if err != nil {
+		if conflict := insertConflict(err); conflict != nil {
+			return fmt.Errorf("path: internal/repository/postgres_repository.go, func InsertOrdinary(), failed to insert url: %w", conflict)
 		}
 		return fmt.Errorf("path: internal/repository/postgres_repository.go, func InsertOrdinary(), failed to insert url: %w", err)
 	}
-	return nil
+
+	if err = insertTags(ctx, tx, urlID, url.Tags); err != nil {
+		return fmt.Errorf("path: internal/repository/postgres_repository.go, func InsertOrdinary(), failed to insert tags: %w", err)
+	}
+	if err = insertDestinations(ctx, tx, urlID, url.Destinations); err != nil {
+		return fmt.Errorf("path: internal/repository/postgres_repository.go, func InsertOrdinary(), failed to insert destinations: %w", err)
+	}
+	return tx.Commit()
 }
 
 // InsertBatch - добавление нескольких URL в БД.
 func (repo *RepoPostgres) InsertBatch(ctx context.Context, urls []models.URLBase) error {
-	tx, err := repo.db.Begin()
+	tx, err := repo.db.BeginTx(ctx, nil)
 	if err != nil {
 		return err
 	}
 	defer tx.Rollback()
 
-	stmt, err := repo.db.PrepareContext(ctx, "INSERT INTO urls (original, short, user_id) VALUES ($1, $2, $3)")
+	stmt, err := tx.PrepareContext(ctx, insertURLQuery)
 	if err != nil {
 		return fmt.Errorf("path: internal/repository/postgres_repository.go, func InsertBatch(), failed to prepare statement: %w", err)
 	}
+	defer stmt.Close()
 
-	for _, url := range urls {
-		_, err = stmt.ExecContext(ctx, url.Original, url.Short, url.UUID)
-	}
-	if err != nil {
-		var pgErr *pgconn.PgError
-		if errors.As(err, &pgErr) && pgErr.Code == "23505" {
-			return fmt.Errorf("path: internal/repository/postgres_repository.go, func InsertBatch(), failed to insert urls: %w", constants.ErrorURLAlreadyExist)
+	for idx, url := range urls {
+		var urlID int
+		err = stmt.QueryRowContext(ctx,
+			url.Original, url.Short, url.UUID, url.Folder, url.Title, url.Description, url.PassQuery, url.PassPath,
+			rulesValue(url.Rules), url.NotBefore, url.MaxClicks, url.Domain, repo.originalOwner(url.UUID),
+		).Scan(&urlID)
+		if err != nil {
+			if conflict := insertConflict(err); conflict != nil {
+				return fmt.Errorf("path: internal/repository/postgres_repository.go, func InsertBatch(), failed to insert urls: %w",
+					&models.BatchItemError{Index: idx, Err: conflict})
+			}
+			return fmt.Errorf("path: internal/repository/postgres_repository.go, func InsertBatch(), failed to insert urls: %w", err)
+		}
+
+		if err = insertTags(ctx, tx, urlID, url.Tags); err != nil {
+			return fmt.Errorf("path: internal/repository/postgres_repository.go, func InsertBatch(), failed to insert tags: %w", err)
+		}
+		if err = insertDestinations(ctx, tx, urlID, url.Destinations); err != nil {
+			return fmt.Errorf("path: internal/repository/postgres_repository.go, func InsertBatch(), failed to insert destinations: %w", err)
 		}
-		return fmt.Errorf("path: internal/repository/postgres_repository.go, func InsertBatch(), failed to insert urls: %w", err)
 	}
 	return tx.Commit()
 }
 
-// SelectShort - получение короткого URL по оригинальному.
-func (repo *RepoPostgres) SelectShort(ctx context.Context, urlOriginal string) (string, error) {
-	query := "SELECT short FROM urls WHERE original = $1"
-	row := repo.db.QueryRowContext(ctx, query, urlOriginal)
+// SelectShort - получение короткого URL по оригинальному в пространстве имен домена.
+// В режиме PerUserOriginals поиск ограничен URL пользователя.
+// Удаленные URL не учитываются: их оригинальный URL сокращается повторно, как в RepoFileMemory.
+func (repo *RepoPostgres) SelectShort(ctx context.Context, domain, userID, urlOriginal string) (string, error) {
+	query := "SELECT short FROM urls WHERE domain = $1 AND original_owner = $2 AND original = $3 AND NOT is_deleted"
+	row := repo.db.QueryRowContext(ctx, query, domain, repo.originalOwner(userID), urlOriginal)
 
 	var urlShort string
 	err := row.Scan(&urlShort)
@@ -131,36 +305,51 @@ func (repo *RepoPostgres) SelectShort(ctx context.Context, urlOriginal string) (
 	return urlShort, nil
 }
 
-// SelectOriginal - получение оригинального URL по короткому.
-func (repo *RepoPostgres) SelectOriginal(ctx context.Context, urlShort string) (string, error) {
-	query := "SELECT original, is_deleted FROM urls WHERE short = $1"
-	row := repo.db.QueryRowContext(ctx, query, urlShort)
+// SelectOriginal - получение оригинального URL и правил перенаправления по короткому в пространстве имен домена.
+func (repo *RepoPostgres) SelectOriginal(ctx context.Context, domain, urlShort string) (models.URLBase, error) {
+	query := "SELECT u.original, u.user_id, u.is_deleted, u.is_disabled, u.pass_query, u.pass_path, u.rules, u.not_before, u.max_clicks, " + destinationsColumn + " FROM urls u WHERE u.domain = $1 AND u.short = $2"
+	row := repo.db.QueryRowContext(ctx, query, domain, urlShort)
 
-	var url models.URLBase
-	err := row.Scan(&url.Original, &url.DeletedFlag)
+	url := models.URLBase{Short: urlShort, Domain: domain}
+	err := row.Scan(&url.Original, &url.UUID, &url.DeletedFlag, &url.DisabledFlag, &url.PassQuery, &url.PassPath,
+		jsonScanner[models.TargetRule]{&url.Rules}, &url.NotBefore, &url.MaxClicks, jsonScanner[models.Destination]{&url.Destinations})
 
-	if url.DeletedFlag {
-		return "", fmt.Errorf("path: internal/repository/postgres_repository.go, func SelectOriginal(): %w", constants.ErrorURLAlreadyDeleted)
+	if errors.Is(err, sql.ErrNoRows) {
+		return models.URLBase{}, fmt.Errorf("path: internal/repository/postgres_repository.go, func SelectOriginal(): %w", constants.ErrorURLNotExist)
 	}
-	if err != nil && errors.Is(err, sql.ErrNoRows) {
-		return "", fmt.Errorf("path: internal/repository/postgres_repository.go, func SelectOriginal(): %w", constants.ErrorURLNotExist)
+	if err != nil {
+		return models.URLBase{}, fmt.Errorf("path: internal/repository/postgres_repository.go, func SelectOriginal(), failed to select url: %w", err)
+	}
+	if url.DeletedFlag {
+		return models.URLBase{}, fmt.Errorf("path: internal/repository/postgres_repository.go, func SelectOriginal(): %w", constants.ErrorURLAlreadyDeleted)
 	}
 
-	return url.Original, nil
+	return url, nil
 }
 
 // SelectAll - получение всех когда-либо сокращенных пользователем URL.
-func (repo *RepoPostgres) SelectAll(ctx context.Context, userID string) ([]models.URLBase, error) {
-	stmt, err := repo.db.PrepareContext(ctx, "SELECT original, short FROM urls WHERE user_id = $1")
+func (repo *RepoPostgres) SelectAll(ctx context.Context, userID string, filter models.URLFilter) ([]models.URLBase, error) {
+	stmt, err := repo.db.PrepareContext(ctx, `
+	SELECT u.original, u.short, u.domain, u.folder, u.title, u.description, u.pass_query, u.pass_path, u.rules, u.not_before, u.max_clicks,
+		u.is_disabled, h.status, h.error, h.checked_at, h.broken,
+		COALESCE(ARRAY(SELECT t.tag FROM url_tags t WHERE t.url_id = u.id ORDER BY t.tag), '{}') AS tags,
+		`+destinationsColumn+` AS destinations
+	FROM urls u
+	LEFT JOIN url_health h ON h.url_id = u.id
+	WHERE u.user_id = $1
+		AND ($2 = '' OR u.folder = $2)
+		AND ($3 = '' OR EXISTS (SELECT 1 FROM url_tags t WHERE t.url_id = u.id AND t.tag = $3))
+	ORDER BY u.id`)
 	if err != nil {
 		return nil, fmt.Errorf("path: internal/repository/postgres_repository.go, func SelectAll(), failed to prepare statement: %w", err)
 	}
 	defer stmt.Close()
 
-	rows, err := stmt.QueryContext(ctx, userID)
+	rows, err := stmt.QueryContext(ctx, userID, filter.Folder, filter.Tag)
 	if err != nil {
 		return nil, fmt.Errorf("path: internal/repository/postgres_repository.go, func SelectAll(), failed to get urls: %w", err)
 	}
+	defer rows.Close()
 	if err = rows.Err(); err != nil {
 		return nil, fmt.Errorf("path: internal/repository/postgres_repository.go, func SelectAll(), row iteration failed: %w", err)
 	}
@@ -168,11 +357,21 @@ func (repo *RepoPostgres) SelectAll(ctx context.Context, userID string) ([]model
 	urls := make([]models.URLBase, 0, 20)
 
 	for rows.Next() {
-		var url models.URLBase
-		err = rows.Scan(&url.Original, &url.Short)
+		var (
+			url    models.URLBase
+			health healthScanner
+		)
+		err = rows.Scan(&url.Original, &url.Short, &url.Domain, &url.Folder, &url.Title, &url.Description, &url.PassQuery, &url.PassPath,
+			jsonScanner[models.TargetRule]{&url.Rules}, &url.NotBefore, &url.MaxClicks,
+			&url.DisabledFlag, &health.status, &health.error, &health.checkedAt, &health.broken,
+			pq.Array(&url.Tags), jsonScanner[models.Destination]{&url.Destinations})
 		if err != nil {
 			return nil, fmt.Errorf("path: internal/repository/postgres_repository.go, func SelectAll(), failed to scan url: %w", err)
 		}
+		url.Health = health.value()
+		if len(url.Tags) == 0 {
+			url.Tags = nil
+		}
 
 		urls = append(urls, url)
 	}
@@ -180,59 +379,322 @@ func (repo *RepoPostgres) SelectAll(ctx context.Context, userID string) ([]model
 	return urls, nil
 }
 
-// Delete - удаление URL из БД.
-func (repo *RepoPostgres) Delete(ctx context.Context, urls []models.URLBase) error {
+// Update - изменение атрибутов URL пользователя.
+func (repo *RepoPostgres) Update(ctx context.Context, update models.URLUpdate) error {
+	tx, err := repo.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("path: internal/repository/postgres_repository.go, func Update(), failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	var urlID int
+	query := "SELECT id FROM urls WHERE short = $1 AND user_id = $2 AND domain = $3 AND NOT is_deleted FOR UPDATE"
+	err = tx.QueryRowContext(ctx, query, update.Short, update.UUID, update.Domain).Scan(&urlID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return fmt.Errorf("path: internal/repository/postgres_repository.go, func Update(): %w", constants.ErrorURLNotExist)
+	}
+	if err != nil {
+		return fmt.Errorf("path: internal/repository/postgres_repository.go, func Update(), failed to select url: %w", err)
+	}
+
+	if update.Folder != nil {
+		_, err = tx.ExecContext(ctx, "UPDATE urls SET folder = $1 WHERE id = $2", *update.Folder, urlID)
+		if err != nil {
+			return fmt.Errorf("path: internal/repository/postgres_repository.go, func Update(), failed to update folder: %w", err)
+		}
+	}
+
+	if update.Title != nil {
+		_, err = tx.ExecContext(ctx, "UPDATE urls SET title = $1 WHERE id = $2", *update.Title, urlID)
+		if err != nil {
+			return fmt.Errorf("path: internal/repository/postgres_repository.go, func Update(), failed to update title: %w", err)
+		}
+	}
+
+	if update.Description != nil {
+		_, err = tx.ExecContext(ctx, "UPDATE urls SET description = $1 WHERE id = $2", *update.Description, urlID)
+		if err != nil {
+			return fmt.Errorf("path: internal/repository/postgres_repository.go, func Update(), failed to update description: %w", err)
+		}
+	}
+
+	if update.PassQuery != nil {
+		_, err = tx.ExecContext(ctx, "UPDATE urls SET pass_query = $1 WHERE id = $2", *update.PassQuery, urlID)
+		if err != nil {
+			return fmt.Errorf("path: internal/repository/postgres_repository.go, func Update(), failed to update pass_query: %w", err)
+		}
+	}
+
+	if update.PassPath != nil {
+		_, err = tx.ExecContext(ctx, "UPDATE urls SET pass_path = $1 WHERE id = $2", *update.PassPath, urlID)
+		if err != nil {
+			return fmt.Errorf("path: internal/repository/postgres_repository.go, func Update(), failed to update pass_path: %w", err)
+		}
+	}
+
+	if update.Rules != nil {
+		_, err = tx.ExecContext(ctx, "UPDATE urls SET rules = $1 WHERE id = $2", rulesValue(*update.Rules), urlID)
+		if err != nil {
+			return fmt.Errorf("path: internal/repository/postgres_repository.go, func Update(), failed to update rules: %w", err)
+		}
+	}
+
+	if update.NotBefore != nil {
+		_, err = tx.ExecContext(ctx, "UPDATE urls SET not_before = $1 WHERE id = $2", *update.NotBefore, urlID)
+		if err != nil {
+			return fmt.Errorf("path: internal/repository/postgres_repository.go, func Update(), failed to update not_before: %w", err)
+		}
+	}
+
+	if update.MaxClicks != nil {
+		_, err = tx.ExecContext(ctx, "UPDATE urls SET max_clicks = $1 WHERE id = $2", *update.MaxClicks, urlID)
+		if err != nil {
+			return fmt.Errorf("path: internal/repository/postgres_repository.go, func Update(), failed to update max_clicks: %w", err)
+		}
+	}
+
+	if update.Tags != nil {
+		_, err = tx.ExecContext(ctx, "DELETE FROM url_tags WHERE url_id = $1", urlID)
+		if err != nil {
+			return fmt.Errorf("path: internal/repository/postgres_repository.go, func Update(), failed to delete tags: %w", err)
+		}
+		if err = insertTags(ctx, tx, urlID, *update.Tags); err != nil {
+			return fmt.Errorf("path: internal/repository/postgres_repository.go, func Update(), failed to insert tags: %w", err)
+		}
+	}
+
+	if update.Destinations != nil {
+		_, err = tx.ExecContext(ctx, "DELETE FROM url_destinations WHERE url_id = $1", urlID)
+		if err != nil {
+			return fmt.Errorf("path: internal/repository/postgres_repository.go, func Update(), failed to delete destinations: %w", err)
+		}
+		if err = insertDestinations(ctx, tx, urlID, *update.Destinations); err != nil {
+			return fmt.Errorf("path: internal/repository/postgres_repository.go, func Update(), failed to insert destinations: %w", err)
+		}
+	}
+
+	return tx.Commit()
+}
+
+// RecordClick - учет перехода по URL и, если position неотрицательна, по адресу назначения.
+func (repo *RepoPostgres) RecordClick(ctx context.Context, domain, urlShort string, position int) error {
+	query := `WITH url AS (UPDATE urls SET clicks = clicks + 1 WHERE domain = $1 AND short = $2 RETURNING id)
+	UPDATE url_destinations SET clicks = clicks + 1
+	WHERE position = $3 AND url_id = (SELECT id FROM url)`
+	_, err := repo.db.ExecContext(ctx, query, domain, urlShort, position)
+	if err != nil {
+		return fmt.Errorf("path: internal/repository/postgres_repository.go, func RecordClick(), failed to update clicks: %w", err)
+	}
+	return nil
+}
+
+// ConsumeClick - списание перехода по URL с ограниченным количеством переходов.
+// Условное обновление выполняется атомарно, поэтому при одновременных переходах
+// лимит не может быть превышен.
+func (repo *RepoPostgres) ConsumeClick(ctx context.Context, domain, urlShort string) error {
+	query := "UPDATE urls SET max_clicks = max_clicks - 1 WHERE domain = $1 AND short = $2 AND max_clicks > 0 AND NOT is_deleted"
+	result, err := repo.db.ExecContext(ctx, query, domain, urlShort)
+	if err != nil {
+		return fmt.Errorf("path: internal/repository/postgres_repository.go, func ConsumeClick(), failed to update max_clicks: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("path: internal/repository/postgres_repository.go, func ConsumeClick(), failed to get rows affected: %w", err)
+	}
+	if rows == 0 {
+		return fmt.Errorf("path: internal/repository/postgres_repository.go, func ConsumeClick(): %w", constants.ErrorURLClicksExhausted)
+	}
+	return nil
+}
+
+// SelectForHealthCheck - получение всех неудаленных URL для проверки доступности.
+func (repo *RepoPostgres) SelectForHealthCheck(ctx context.Context) ([]models.URLBase, error) {
+	rows, err := repo.db.QueryContext(ctx, "SELECT short, domain, original FROM urls WHERE NOT is_deleted ORDER BY id")
+	if err != nil {
+		return nil, fmt.Errorf("path: internal/repository/postgres_repository.go, func SelectForHealthCheck(), failed to get urls: %w", err)
+	}
+	defer rows.Close()
+
+	var urls []models.URLBase
+	for rows.Next() {
+		var url models.URLBase
+		if err = rows.Scan(&url.Short, &url.Domain, &url.Original); err != nil {
+			return nil, fmt.Errorf("path: internal/repository/postgres_repository.go, func SelectForHealthCheck(), failed to scan url: %w", err)
+		}
+		urls = append(urls, url)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("path: internal/repository/postgres_repository.go, func SelectForHealthCheck(), row iteration failed: %w", err)
+	}
+	return urls, nil
+}
+
+// UpdateHealth - сохранение результата проверки доступности URL в пространстве имен домена.
+func (repo *RepoPostgres) UpdateHealth(ctx context.Context, domain, urlShort string, health models.URLHealth) error {
+	query := `INSERT INTO url_health (url_id, status, error, checked_at, broken)
+	SELECT id, $3, $4, $5, $6 FROM urls WHERE domain = $1 AND short = $2
+	ON CONFLICT (url_id) DO UPDATE SET status = EXCLUDED.status, error = EXCLUDED.error,
+		checked_at = EXCLUDED.checked_at, broken = EXCLUDED.broken`
+
+	_, err := repo.db.ExecContext(ctx, query, domain, urlShort, health.Status, health.Error, health.CheckedAt, health.Broken)
+	if err != nil {
+		return fmt.Errorf("path: internal/repository/postgres_repository.go, func UpdateHealth(), failed to save health: %w", err)
+	}
+	return nil
+}
+
+// Delete - удаление URL пользователя в пространстве имен домена.
+// Возвращает записи, удаленные этим вызовом: ранее удаленные записи не возвращаются.
+func (repo *RepoPostgres) Delete(ctx context.Context, urls []models.URLBase) ([]models.URLBase, error) {
 	if len(urls) == 0 {
-		return constants.ErrorNoData
+		return nil, constants.ErrorNoData
 	}
 
 	var values []string
 	var args []any
 
 	for i, url := range urls {
-		base := i * 2
-		params := fmt.Sprintf("($%d, $%d)", base+1, base+2)
+		base := i * 3
+		params := fmt.Sprintf("($%d, $%d, $%d)", base+1, base+2, base+3)
 		values = append(values, params)
-		args = append(args, url.Short, url.UUID)
+		args = append(args, url.Domain, url.Short, url.UUID)
 	}
 
+	// подзапрос блокирует строки и возвращает состояние флага до обновления
 	query := `
-	UPDATE urls AS u SET is_deleted = true FROM (VALUES ` + strings.Join(values, ",") + `) AS v(short, user_id) WHERE u.short = v.short AND u.user_id = v.user_id;`
+	UPDATE urls AS u SET is_deleted = true FROM (SELECT d.id, d.is_deleted FROM urls AS d JOIN (VALUES ` + strings.Join(values, ",") + `) AS v(domain, short, user_id) ON d.domain = v.domain AND d.short = v.short AND d.user_id = v.user_id FOR UPDATE) AS m WHERE u.id = m.id
+	RETURNING u.user_id, u.short, u.domain, u.original, m.is_deleted;`
 
-	result, err := repo.db.ExecContext(ctx, query, args...)
+	rows, err := repo.db.QueryContext(ctx, query, args...)
 	if err != nil {
-		return fmt.Errorf("path: internal/repository/postgres_repository.go, func Delete(), failed to delete url: %w", err)
+		return nil, fmt.Errorf("path: internal/repository/postgres_repository.go, func Delete(), failed to delete url: %w", err)
+	}
+	defer rows.Close()
+
+	var (
+		count   int
+		deleted []models.URLBase
+	)
+	for rows.Next() {
+		var (
+			url        models.URLBase
+			wasDeleted bool
+		)
+		if err = rows.Scan(&url.UUID, &url.Short, &url.Domain, &url.Original, &wasDeleted); err != nil {
+			return nil, fmt.Errorf("path: internal/repository/postgres_repository.go, func Delete(), failed to scan row: %w", err)
+		}
+		count++
+		if !wasDeleted {
+			url.DeletedFlag = true
+			deleted = append(deleted, url)
+		}
+	}
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("path: internal/repository/postgres_repository.go, func Delete(), failed to iterate rows: %w", err)
 	}
 
-	count, _ := result.RowsAffected()
 	if count == 0 {
-		return fmt.Errorf("path: internal/repository/postgres_repository.go, func Delete(), url not found: %w", constants.ErrorNotFound)
+		return nil, fmt.Errorf("path: internal/repository/postgres_repository.go, func Delete(), url not found: %w", constants.ErrorNotFound)
 	}
-	return nil
+	return deleted, nil
 }
 
-// GetCountURLs - получение количества записей.
-func (repo *RepoPostgres) GetCountURLs(ctx context.Context) (int, error) {
-	query := `SELECT COUNT(*) FROM urls`
-	row := repo.db.QueryRowContext(ctx, query)
+// GetStats - расчет статистики по записям и пользователям.
+func (repo *RepoPostgres) GetStats(ctx context.Context, filter models.StatsFilter) (*models.Stats, error) {
+	stats := &models.Stats{From: filter.From, To: filter.To}
+
+	query := `SELECT COUNT(*), COUNT(*) FILTER (WHERE NOT is_deleted), COUNT(*) FILTER (WHERE is_deleted),
+	COUNT(DISTINCT user_id), pg_database_size(current_database()) FROM urls`
+	err := repo.db.QueryRowContext(ctx, query).Scan(&stats.CountURL, &stats.CountActive, &stats.CountDeleted,
+		&stats.CountUsers, &stats.StorageBytes)
+	if err != nil {
+		return nil, fmt.Errorf("path: internal/repository/postgres_repository.go, func GetStats(), failed to get counts: %w", err)
+	}
+
+	if stats.PerDay, err = repo.selectPerDay(ctx, filter); err != nil {
+		return nil, err
+	}
+	if stats.TopURLs, err = repo.selectTopURLs(ctx, filter); err != nil {
+		return nil, err
+	}
+	if stats.TopUsers, err = repo.selectTopUsers(ctx, filter); err != nil {
+		return nil, err
+	}
+	return stats, nil
+}
 
-	var count int
-	err := row.Scan(&count)
+// selectPerDay - получение количества ссылок, созданных за каждый день периода.
+func (repo *RepoPostgres) selectPerDay(ctx context.Context, filter models.StatsFilter) ([]models.DailyStats, error) {
+	query := `SELECT to_char(created_at AT TIME ZONE 'UTC', 'YYYY-MM-DD') AS day, COUNT(*) FROM urls
+	WHERE created_at >= $1 AND created_at < $2
+	GROUP BY day ORDER BY day`
+	rows, err := repo.db.QueryContext(ctx, query, filter.From, filter.To)
 	if err != nil {
-		return 0, fmt.Errorf("path: internal/repository/postgres_repository.go, func GetCountURLs(): %w", err)
+		return nil, fmt.Errorf("path: internal/repository/postgres_repository.go, func selectPerDay(), failed to get urls per day: %w", err)
 	}
-	return count, nil
+	defer rows.Close()
+
+	days := make([]models.DailyStats, 0)
+	for rows.Next() {
+		var day models.DailyStats
+		if err = rows.Scan(&day.Date, &day.Count); err != nil {
+			return nil, fmt.Errorf("path: internal/repository/postgres_repository.go, func selectPerDay(), failed to scan row: %w", err)
+		}
+		days = append(days, day)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("path: internal/repository/postgres_repository.go, func selectPerDay(): %w", err)
+	}
+	return days, nil
 }
 
-// GetCountUsers - получение количества уникальных пользователей.
-func (repo *RepoPostgres) GetCountUsers(ctx context.Context) (int, error) {
-	query := "SELECT COUNT(DISTINCT user_id) FROM urls"
-	row := repo.db.QueryRowContext(ctx, query)
+// selectTopURLs - получение самых посещаемых неудаленных ссылок периода.
+func (repo *RepoPostgres) selectTopURLs(ctx context.Context, filter models.StatsFilter) ([]models.URLClicks, error) {
+	query := `SELECT short, original, clicks FROM urls
+	WHERE NOT is_deleted AND clicks > 0 AND created_at >= $1 AND created_at < $2
+	ORDER BY clicks DESC, id LIMIT $3`
+	rows, err := repo.db.QueryContext(ctx, query, filter.From, filter.To, filter.Top)
+	if err != nil {
+		return nil, fmt.Errorf("path: internal/repository/postgres_repository.go, func selectTopURLs(), failed to get top urls: %w", err)
+	}
+	defer rows.Close()
 
-	var count int
-	err := row.Scan(&count)
+	urls := make([]models.URLClicks, 0)
+	for rows.Next() {
+		var url models.URLClicks
+		if err = rows.Scan(&url.Short, &url.Original, &url.Clicks); err != nil {
+			return nil, fmt.Errorf("path: internal/repository/postgres_repository.go, func selectTopURLs(), failed to scan row: %w", err)
+		}
+		urls = append(urls, url)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("path: internal/repository/postgres_repository.go, func selectTopURLs(): %w", err)
+	}
+	return urls, nil
+}
+
+// selectTopUsers - получение пользователей, создавших больше всего ссылок за период.
+func (repo *RepoPostgres) selectTopUsers(ctx context.Context, filter models.StatsFilter) ([]models.UserStats, error) {
+	query := `SELECT user_id, COUNT(*) AS count FROM urls
+	WHERE created_at >= $1 AND created_at < $2
+	GROUP BY user_id ORDER BY count DESC, user_id LIMIT $3`
+	rows, err := repo.db.QueryContext(ctx, query, filter.From, filter.To, filter.Top)
 	if err != nil {
-		return 0, fmt.Errorf("path: internal/repository/postgres_repository.go, func GetCountUsers(): %w", err)
+		return nil, fmt.Errorf("path: internal/repository/postgres_repository.go, func selectTopUsers(), failed to get top users: %w", err)
+	}
+	defer rows.Close()
+
+	users := make([]models.UserStats, 0)
+	for rows.Next() {
+		var user models.UserStats
+		if err = rows.Scan(&user.UUID, &user.Count); err != nil {
+			return nil, fmt.Errorf("path: internal/repository/postgres_repository.go, func selectTopUsers(), failed to scan row: %w", err)
+		}
+		users = append(users, user)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("path: internal/repository/postgres_repository.go, func selectTopUsers(): %w", err)
 	}
-	return count, nil
+	return users, nil
 }
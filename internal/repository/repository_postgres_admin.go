@@ -0,0 +1,99 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/Di-nis/shortener-url/internal/constants"
+	"github.com/Di-nis/shortener-url/internal/models"
+)
+
+// SearchURLs - поиск URL всех пользователей по идентификатору пользователя,
+// подстроке оригинального URL или короткому URL.
+func (repo *RepoPostgres) SearchURLs(ctx context.Context, filter models.AdminFilter) ([]models.URLBase, error) {
+	query := `
+	SELECT u.user_id, u.short, u.domain, u.original, u.is_deleted, u.is_disabled
+	FROM urls u
+	WHERE ($1 = '' OR u.user_id = $1)
+		AND ($2 = '' OR u.short = $2 OR strpos(lower(u.original), lower($2)) > 0)
+	ORDER BY u.id
+	LIMIT $3 OFFSET $4`
+
+	rows, err := repo.db.QueryContext(ctx, query, filter.UserID, filter.Query, filter.Limit, filter.Offset)
+	if err != nil {
+		return nil, fmt.Errorf("path: internal/repository/repository_postgres_admin.go, func SearchURLs(), failed to get urls: %w", err)
+	}
+	defer rows.Close()
+
+	var urls []models.URLBase
+	for rows.Next() {
+		var url models.URLBase
+		if err = rows.Scan(&url.UUID, &url.Short, &url.Domain, &url.Original, &url.DeletedFlag, &url.DisabledFlag); err != nil {
+			return nil, fmt.Errorf("path: internal/repository/repository_postgres_admin.go, func SearchURLs(), failed to scan url: %w", err)
+		}
+		urls = append(urls, url)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("path: internal/repository/repository_postgres_admin.go, func SearchURLs(), row iteration failed: %w", err)
+	}
+	return urls, nil
+}
+
+// SetDisabled - отключение или включение URL в пространстве имен домена независимо от владельца.
+func (repo *RepoPostgres) SetDisabled(ctx context.Context, domain, short string, disabled bool) error {
+	result, err := repo.db.ExecContext(ctx, "UPDATE urls SET is_disabled = $3 WHERE domain = $1 AND short = $2", domain, short, disabled)
+	if err != nil {
+		return fmt.Errorf("path: internal/repository/repository_postgres_admin.go, func SetDisabled(), failed to update url: %w", err)
+	}
+	return checkRowsAffected(result, "SetDisabled")
+}
+
+// ForceDelete - удаление URL в пространстве имен домена независимо от владельца.
+func (repo *RepoPostgres) ForceDelete(ctx context.Context, domain, short string) error {
+	result, err := repo.db.ExecContext(ctx, "UPDATE urls SET is_deleted = true WHERE domain = $1 AND short = $2", domain, short)
+	if err != nil {
+		return fmt.Errorf("path: internal/repository/repository_postgres_admin.go, func ForceDelete(), failed to delete url: %w", err)
+	}
+	return checkRowsAffected(result, "ForceDelete")
+}
+
+// checkRowsAffected - проверка, что запрос изменил хотя бы одну запись.
+func checkRowsAffected(result sql.Result, funcName string) error {
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("path: internal/repository/repository_postgres_admin.go, func %s(), failed to get rows affected: %w", funcName, err)
+	}
+	if rows == 0 {
+		return fmt.Errorf("path: internal/repository/repository_postgres_admin.go, func %s(): %w", funcName, constants.ErrorURLNotExist)
+	}
+	return nil
+}
+
+// BlockUser - запрет пользователю создавать URL.
+func (repo *RepoPostgres) BlockUser(ctx context.Context, userID string) error {
+	_, err := repo.db.ExecContext(ctx, "INSERT INTO blocked_users (user_id) VALUES ($1) ON CONFLICT (user_id) DO NOTHING", userID)
+	if err != nil {
+		return fmt.Errorf("path: internal/repository/repository_postgres_admin.go, func BlockUser(), failed to block user: %w", err)
+	}
+	return nil
+}
+
+// UnblockUser - снятие запрета на создание URL.
+func (repo *RepoPostgres) UnblockUser(ctx context.Context, userID string) error {
+	_, err := repo.db.ExecContext(ctx, "DELETE FROM blocked_users WHERE user_id = $1", userID)
+	if err != nil {
+		return fmt.Errorf("path: internal/repository/repository_postgres_admin.go, func UnblockUser(), failed to unblock user: %w", err)
+	}
+	return nil
+}
+
+// IsUserBlocked - проверка запрета пользователю создавать URL.
+func (repo *RepoPostgres) IsUserBlocked(ctx context.Context, userID string) (bool, error) {
+	var blocked bool
+	err := repo.db.QueryRowContext(ctx, "SELECT EXISTS (SELECT 1 FROM blocked_users WHERE user_id = $1)", userID).Scan(&blocked)
+	if err != nil {
+		return false, fmt.Errorf("path: internal/repository/repository_postgres_admin.go, func IsUserBlocked(), failed to check user: %w", err)
+	}
+	return blocked, nil
+}
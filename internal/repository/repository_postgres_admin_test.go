@@ -0,0 +1,120 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/Di-nis/shortener-url/internal/constants"
+	"github.com/Di-nis/shortener-url/internal/models"
+)
+
+func TestRepoPostgres_SearchURLs(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Skipf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	filter := models.AdminFilter{Query: "khl", Limit: 10, Offset: 0}
+
+	mock.ExpectQuery(`SELECT u\.user_id, u\.short, u\.domain, u\.original, u\.is_deleted, u\.is_disabled\s+FROM urls u`).
+		WithArgs(filter.UserID, filter.Query, filter.Limit, filter.Offset).
+		WillReturnRows(sqlmock.NewRows([]string{"user_id", "short", "domain", "original", "is_deleted", "is_disabled"}).
+			AddRow(UUID, urlAlias1, "go.brand-a.com", url1, false, true))
+
+	repo := RepoPostgres{db: db}
+
+	got, gotErr := repo.SearchURLs(context.Background(), filter)
+	want := models.URLBase{UUID: UUID, Short: urlAlias1, Domain: "go.brand-a.com", Original: url1, DisabledFlag: true}
+	if gotErr != nil || len(got) != 1 || got[0].Short != want.Short || got[0].UUID != want.UUID || !got[0].DisabledFlag {
+		t.Errorf("TestRepoPostgres_SearchURLs() = %v, %v, want: %v", got, gotErr, want)
+	}
+}
+
+func TestRepoPostgres_SetDisabled(t *testing.T) {
+	tests := []struct {
+		name         string
+		rowsAffected int64
+		dbErr        error
+		wantErr      error
+	}{
+		{
+			name:         "тест 1, URL отключен",
+			rowsAffected: 1,
+			wantErr:      nil,
+		},
+		{
+			name:         "тест 2, URL не найден",
+			rowsAffected: 0,
+			wantErr:      constants.ErrorURLNotExist,
+		},
+		{
+			name:    "тест 3, ошибка базы данных",
+			dbErr:   errDB,
+			wantErr: errDB,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Skipf("an error '%s' was not expected when opening a stub database connection", err)
+			}
+			defer db.Close()
+
+			mock.ExpectExec(`UPDATE urls SET is_disabled = \$3 WHERE domain = \$1 AND short = \$2`).
+				WithArgs("go.brand-a.com", urlAlias1, true).
+				WillReturnResult(sqlmock.NewResult(0, tt.rowsAffected)).
+				WillReturnError(tt.dbErr)
+
+			repo := RepoPostgres{db: db}
+
+			gotErr := repo.SetDisabled(context.Background(), "go.brand-a.com", urlAlias1, true)
+			if !errors.Is(gotErr, tt.wantErr) {
+				t.Errorf("TestRepoPostgres_SetDisabled() = %v, wantErr: %v", gotErr, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestRepoPostgres_ForceDelete(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Skipf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	mock.ExpectExec(`UPDATE urls SET is_deleted = true WHERE domain = \$1 AND short = \$2`).
+		WithArgs("go.brand-a.com", urlAlias1).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	repo := RepoPostgres{db: db}
+
+	if gotErr := repo.ForceDelete(context.Background(), "go.brand-a.com", urlAlias1); gotErr != nil {
+		t.Errorf("TestRepoPostgres_ForceDelete() = %v, wantErr: nil", gotErr)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("TestRepoPostgres_ForceDelete(), unfulfilled expectations: %v", err)
+	}
+}
+
+func TestRepoPostgres_IsUserBlocked(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Skipf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	mock.ExpectQuery(`SELECT EXISTS \(SELECT 1 FROM blocked_users WHERE user_id = \$1\)`).
+		WithArgs(UUID).
+		WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(true))
+
+	repo := RepoPostgres{db: db}
+
+	blocked, gotErr := repo.IsUserBlocked(context.Background(), UUID)
+	if !blocked || gotErr != nil {
+		t.Errorf("TestRepoPostgres_IsUserBlocked() = %v, %v, want: true", blocked, gotErr)
+	}
+}
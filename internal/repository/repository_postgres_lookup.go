@@ -0,0 +1,74 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/Di-nis/shortener-url/internal/constants"
+	"github.com/Di-nis/shortener-url/internal/models"
+	"github.com/lib/pq"
+)
+
+// lookupConditions - условия отбора по способу поиска; $2 - значение поиска.
+// Точное совпадение и префикс используют индекс idx_urls_user_original, хост - idx_urls_user_host,
+// подстрока - триграммный индекс idx_urls_original_trgm.
+var lookupConditions = map[string]string{
+	models.LookupExact:    "u.original = $2",
+	models.LookupPrefix:   "u.original LIKE $2",
+	models.LookupHost:     "(u.original_host = $2 OR right(u.original_host, length($2) + 1) = '.' || $2)",
+	models.LookupContains: "u.original ILIKE $2",
+}
+
+// escapeLike - экранирование специальных символов шаблона LIKE.
+func escapeLike(value string) string {
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(value)
+}
+
+// LookupURLs - поиск URL пользователя по оригинальному URL, хосту, префиксу или подстроке.
+// Удаленные URL не возвращаются.
+func (repo *RepoPostgres) LookupURLs(ctx context.Context, userID string, lookup models.URLLookup) ([]models.URLBase, error) {
+	condition, ok := lookupConditions[lookup.Mode]
+	if !ok {
+		return nil, fmt.Errorf("path: internal/repository/repository_postgres_lookup.go, func LookupURLs(), mode %q: %w", lookup.Mode, constants.ErrorInvalidLookup)
+	}
+
+	value := lookup.Query
+	switch lookup.Mode {
+	case models.LookupPrefix:
+		value = escapeLike(value) + "%"
+	case models.LookupContains:
+		value = "%" + escapeLike(value) + "%"
+	}
+
+	query := `
+	SELECT u.original, u.short, u.domain, u.folder, u.title, u.description,
+		COALESCE(ARRAY(SELECT t.tag FROM url_tags t WHERE t.url_id = u.id ORDER BY t.tag), '{}') AS tags
+	FROM urls u
+	WHERE u.user_id = $1 AND NOT u.is_deleted AND ` + condition + `
+	ORDER BY u.id
+	LIMIT $3`
+
+	rows, err := repo.db.QueryContext(ctx, query, userID, value, lookup.Limit)
+	if err != nil {
+		return nil, fmt.Errorf("path: internal/repository/repository_postgres_lookup.go, func LookupURLs(), failed to get urls: %w", err)
+	}
+	defer rows.Close()
+
+	var urls []models.URLBase
+	for rows.Next() {
+		var url models.URLBase
+		err = rows.Scan(&url.Original, &url.Short, &url.Domain, &url.Folder, &url.Title, &url.Description, pq.Array(&url.Tags))
+		if err != nil {
+			return nil, fmt.Errorf("path: internal/repository/repository_postgres_lookup.go, func LookupURLs(), failed to scan url: %w", err)
+		}
+		if len(url.Tags) == 0 {
+			url.Tags = nil
+		}
+		urls = append(urls, url)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("path: internal/repository/repository_postgres_lookup.go, func LookupURLs(), row iteration failed: %w", err)
+	}
+	return urls, nil
+}
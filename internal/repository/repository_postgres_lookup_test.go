@@ -0,0 +1,89 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/Di-nis/shortener-url/internal/constants"
+	"github.com/Di-nis/shortener-url/internal/models"
+)
+
+func TestRepoPostgres_LookupURLs(t *testing.T) {
+	tests := []struct {
+		name      string
+		lookup    models.URLLookup
+		condition string
+		value     string
+		dbErr     error
+		want      []models.URLBase
+		wantErr   error
+	}{
+		{
+			name:      "точное совпадение",
+			lookup:    models.URLLookup{Mode: models.LookupExact, Query: url1, Limit: 10},
+			condition: `u.original = \$2`,
+			value:     url1,
+			want:      []models.URLBase{{Original: url1, Short: urlAlias1, Folder: "sport", Tags: []string{"hockey"}}},
+		},
+		{
+			name:      "префикс с экранированием шаблона",
+			lookup:    models.URLLookup{Mode: models.LookupPrefix, Query: "https://a_b%", Limit: 10},
+			condition: `u.original LIKE \$2`,
+			value:     `https://a\_b\%%`,
+			want:      []models.URLBase{{Original: url1, Short: urlAlias1, Folder: "sport", Tags: []string{"hockey"}}},
+		},
+		{
+			name:      "хост",
+			lookup:    models.URLLookup{Mode: models.LookupHost, Query: "khl.ru", Limit: 10},
+			condition: `u.original_host = \$2 OR`,
+			value:     "khl.ru",
+			want:      []models.URLBase{{Original: url1, Short: urlAlias1, Folder: "sport", Tags: []string{"hockey"}}},
+		},
+		{
+			name:      "подстрока",
+			lookup:    models.URLLookup{Mode: models.LookupContains, Query: "khl", Limit: 10},
+			condition: `u.original ILIKE \$2`,
+			value:     "%khl%",
+			dbErr:     errDB,
+			wantErr:   errDB,
+		},
+		{
+			name:    "неизвестный способ поиска",
+			lookup:  models.URLLookup{Mode: "regexp", Query: "khl", Limit: 10},
+			wantErr: constants.ErrorInvalidLookup,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Skipf("an error '%s' was not expected when opening a stub database connection", err)
+			}
+			defer db.Close()
+
+			if tt.condition != "" {
+				mock.ExpectQuery(`WHERE u.user_id = \$1 AND NOT u.is_deleted AND \(?`+tt.condition).
+					WithArgs(UUID, tt.value, tt.lookup.Limit).
+					WillReturnRows(sqlmock.NewRows([]string{"original", "short", "domain", "folder", "title", "description", "tags"}).
+						AddRow(url1, urlAlias1, "", "sport", "", "", "{hockey}")).
+					WillReturnError(tt.dbErr)
+			}
+
+			repo := RepoPostgres{db: db}
+
+			got, gotErr := repo.LookupURLs(context.Background(), UUID, tt.lookup)
+			if !errors.Is(gotErr, tt.wantErr) {
+				t.Errorf("TestRepoPostgres_LookupURLs() = %v, wantErr: %v", gotErr, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("TestRepoPostgres_LookupURLs() = %v, want: %v", got, tt.want)
+			}
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("TestRepoPostgres_LookupURLs() unmet expectations: %v", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,67 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Di-nis/shortener-url/internal/models"
+	"github.com/lib/pq"
+)
+
+// SelectPage - получение страницы URL пользователя после позиции cursor.After.
+// Позиция URL - его идентификатор: страница выбирается по индексу (user_id, id) без OFFSET.
+// Возвращает позицию последнего URL страницы, если за ней есть еще URL, иначе 0.
+func (repo *RepoPostgres) SelectPage(ctx context.Context, userID string, cursor models.URLCursor) ([]models.URLBase, int64, error) {
+	query := `
+	SELECT u.id, u.original, u.short, u.domain, u.folder, u.title, u.description, u.pass_query, u.pass_path, u.rules, u.not_before, u.max_clicks,
+		u.is_disabled, h.status, h.error, h.checked_at, h.broken,
+		COALESCE(ARRAY(SELECT t.tag FROM url_tags t WHERE t.url_id = u.id ORDER BY t.tag), '{}') AS tags,
+		` + destinationsColumn + ` AS destinations
+	FROM urls u
+	LEFT JOIN url_health h ON h.url_id = u.id
+	WHERE u.user_id = $1 AND u.id > $2
+		AND ($3 = '' OR u.folder = $3)
+		AND ($4 = '' OR EXISTS (SELECT 1 FROM url_tags t WHERE t.url_id = u.id AND t.tag = $4))
+	ORDER BY u.id
+	LIMIT $5`
+
+	// лишняя строка показывает, есть ли URL после страницы
+	rows, err := repo.db.QueryContext(ctx, query, userID, cursor.After, cursor.Filter.Folder, cursor.Filter.Tag, cursor.Limit+1)
+	if err != nil {
+		return nil, 0, fmt.Errorf("path: internal/repository/repository_postgres_page.go, func SelectPage(), failed to get urls: %w", err)
+	}
+	defer rows.Close()
+
+	var (
+		urls []models.URLBase
+		last int64
+	)
+	for rows.Next() {
+		var (
+			id     int64
+			url    models.URLBase
+			health healthScanner
+		)
+		err = rows.Scan(&id, &url.Original, &url.Short, &url.Domain, &url.Folder, &url.Title, &url.Description, &url.PassQuery, &url.PassPath,
+			jsonScanner[models.TargetRule]{&url.Rules}, &url.NotBefore, &url.MaxClicks,
+			&url.DisabledFlag, &health.status, &health.error, &health.checkedAt, &health.broken,
+			pq.Array(&url.Tags), jsonScanner[models.Destination]{&url.Destinations})
+		if err != nil {
+			return nil, 0, fmt.Errorf("path: internal/repository/repository_postgres_page.go, func SelectPage(), failed to scan url: %w", err)
+		}
+		if len(urls) == cursor.Limit {
+			return urls, last, nil
+		}
+		url.Health = health.value()
+		if len(url.Tags) == 0 {
+			url.Tags = nil
+		}
+
+		urls = append(urls, url)
+		last = id
+	}
+	if err = rows.Err(); err != nil {
+		return nil, 0, fmt.Errorf("path: internal/repository/repository_postgres_page.go, func SelectPage(), row iteration failed: %w", err)
+	}
+	return urls, 0, nil
+}
@@ -0,0 +1,89 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/Di-nis/shortener-url/internal/models"
+	"github.com/lib/pq"
+)
+
+func TestRepoPostgres_SelectPage(t *testing.T) {
+	tests := []struct {
+		name     string
+		cursor   models.URLCursor
+		dbIDs    []int64
+		dbRows   []models.URLBase
+		dbErr    error
+		want     []models.URLBase
+		wantNext int64
+		wantErr  error
+	}{
+		{
+			name:     "за страницей есть еще URL",
+			cursor:   models.URLCursor{Limit: 2},
+			dbIDs:    []int64{3, 7, 9},
+			dbRows:   testURLsShort,
+			want:     []models.URLBase{testURLShort1, testURLShort2},
+			wantNext: 7,
+		},
+		{
+			name:     "последняя страница",
+			cursor:   models.URLCursor{Filter: models.URLFilter{Tag: "football", Folder: "sport"}, After: 7, Limit: 2},
+			dbIDs:    []int64{9},
+			dbRows:   []models.URLBase{testURLShort4},
+			want:     []models.URLBase{testURLShort4},
+			wantNext: 0,
+		},
+		{
+			name:    "ошибка БД",
+			cursor:  models.URLCursor{Limit: 2},
+			dbErr:   errDB,
+			want:    nil,
+			wantErr: errDB,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Skipf("an error '%s' was not expected when opening a stub database connection", err)
+			}
+			defer db.Close()
+
+			rows := sqlmock.NewRows([]string{"id", "original", "short", "domain", "folder", "title", "description", "pass_query", "pass_path", "rules", "not_before", "max_clicks",
+				"is_disabled", "health_status", "health_error", "checked_at", "broken", "tags", "destinations"})
+			for i, r := range tt.dbRows {
+				tags, _ := pq.Array(r.Tags).Value()
+				rules, _ := rulesValue(r.Rules).Value()
+				destinations, _ := json.Marshal(r.Destinations)
+				rows.AddRow(tt.dbIDs[i], r.Original, r.Short, r.Domain, r.Folder, r.Title, r.Description, nil, nil, rules, r.NotBefore, r.MaxClicks, r.DisabledFlag, nil, nil, nil, nil, tags, destinations)
+			}
+
+			mock.ExpectQuery(`WHERE u\.user_id = \$1 AND u\.id > \$2`).
+				WithArgs(UUID, tt.cursor.After, tt.cursor.Filter.Folder, tt.cursor.Filter.Tag, tt.cursor.Limit+1).
+				WillReturnRows(rows).
+				WillReturnError(tt.dbErr)
+
+			repo := RepoPostgres{db: db}
+
+			got, gotNext, gotErr := repo.SelectPage(context.Background(), UUID, tt.cursor)
+			if !errors.Is(gotErr, tt.wantErr) {
+				t.Errorf("TestRepoPostgres_SelectPage() = %v, wantErr: %v", gotErr, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("TestRepoPostgres_SelectPage() = %v, want: %v", got, tt.want)
+			}
+			if gotNext != tt.wantNext {
+				t.Errorf("TestRepoPostgres_SelectPage() next = %v, want: %v", gotNext, tt.wantNext)
+			}
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("TestRepoPostgres_SelectPage() unmet expectations: %v", err)
+			}
+		})
+	}
+}
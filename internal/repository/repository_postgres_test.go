@@ -2,16 +2,18 @@ package repository
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
-	"fmt"
 	"reflect"
 	"regexp"
-	"strings"
 	"testing"
+	"time"
 
 	"database/sql"
+	"database/sql/driver"
 
 	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/lib/pq"
 
 	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/Di-nis/shortener-url/internal/constants"
@@ -49,6 +51,48 @@ func TestRepoPostgres_Ping(t *testing.T) {
 	}
 }
 
+func TestRepoPostgres_NextID(t *testing.T) {
+	tests := []struct {
+		name    string
+		dbErr   error
+		want    uint64
+		wantErr error
+	}{
+		{
+			name: "следующий номер последовательности",
+			want: 42,
+		},
+		{
+			name:    "ошибка БД",
+			dbErr:   errDB,
+			wantErr: errDB,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Skipf("an error '%s' was not expected when opening a stub database connection", err)
+			}
+			defer db.Close()
+
+			mock.ExpectQuery(`SELECT nextval\('urls_short_seq'\)`).
+				WillReturnRows(sqlmock.NewRows([]string{"nextval"}).AddRow(tt.want)).
+				WillReturnError(tt.dbErr)
+
+			repo := RepoPostgres{db: db}
+
+			got, gotErr := repo.NextID(context.Background())
+			if !errors.Is(gotErr, tt.wantErr) {
+				t.Errorf("TestRepoPostgres_NextID() = %v, wantErr: %v", gotErr, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("TestRepoPostgres_NextID() = %v, want: %v", got, tt.want)
+			}
+		})
+	}
+}
+
 func TestRepoPostgres_InsertOrdinary(t *testing.T) {
 	tests := []struct {
 		name    string
@@ -62,6 +106,12 @@ func TestRepoPostgres_InsertOrdinary(t *testing.T) {
 			dbErr:   &pgconn.PgError{Code: "23505"},
 			wantErr: constants.ErrorURLAlreadyExist,
 		},
+		{
+			name:    "тест 1, коллизия короткого URL",
+			url:     testURLFull1,
+			dbErr:   &pgconn.PgError{Code: "23505", ConstraintName: "idx_urls_domain_short"},
+			wantErr: constants.ErrorShortCollision,
+		},
 		{
 			name:    "тест 2",
 			url:     testURLFull1,
@@ -74,6 +124,12 @@ func TestRepoPostgres_InsertOrdinary(t *testing.T) {
 			dbErr:   nil,
 			wantErr: nil,
 		},
+		{
+			name:    "тест 4, несколько адресов назначения",
+			url:     testURLFull2,
+			dbErr:   nil,
+			wantErr: nil,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -83,10 +139,22 @@ func TestRepoPostgres_InsertOrdinary(t *testing.T) {
 			}
 			defer db.Close()
 
-			mock.ExpectExec(`INSERT INTO urls \(original, short, user_id\) VALUES \(\$1, \$2, \$3\)`).
-				WithArgs(tt.url.Original, tt.url.Short, tt.url.UUID).
-				WillReturnResult(sqlmock.NewResult(1, 1)).
+			mock.ExpectBegin()
+			mock.ExpectQuery(`INSERT INTO urls \(original, short, user_id, folder, title, description, pass_query, pass_path, rules, not_before, max_clicks, domain, original_owner\)`).
+				WithArgs(tt.url.Original, tt.url.Short, tt.url.UUID, tt.url.Folder, tt.url.Title, tt.url.Description, tt.url.PassQuery, tt.url.PassPath, sqlmock.AnyArg(), tt.url.NotBefore, tt.url.MaxClicks, tt.url.Domain, "").
+				WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1)).
 				WillReturnError(tt.dbErr)
+			if tt.dbErr == nil && len(tt.url.Tags) > 0 {
+				mock.ExpectExec(`INSERT INTO url_tags \(url_id, tag\)`).
+					WithArgs(1, sqlmock.AnyArg()).
+					WillReturnResult(sqlmock.NewResult(0, int64(len(tt.url.Tags))))
+			}
+			if tt.dbErr == nil && len(tt.url.Destinations) > 0 {
+				mock.ExpectExec(`INSERT INTO url_destinations \(url_id, position, url, weight\)`).
+					WithArgs(1, sqlmock.AnyArg(), sqlmock.AnyArg()).
+					WillReturnResult(sqlmock.NewResult(0, int64(len(tt.url.Destinations))))
+			}
+			mock.ExpectCommit()
 
 			repo := RepoPostgres{db: db}
 
@@ -114,6 +182,13 @@ func TestRepoPostgres_InsertBatch(t *testing.T) {
 			dbErr:        &pgconn.PgError{Code: "23505"},
 			wantErr:      constants.ErrorURLAlreadyExist,
 		},
+		{
+			name:         "тест 1, коллизия короткого URL",
+			urls:         []models.URLBase{testURLFull1},
+			dbErrPrepare: nil,
+			dbErr:        &pgconn.PgError{Code: "23505", ConstraintName: "idx_urls_domain_short"},
+			wantErr:      constants.ErrorShortCollision,
+		},
 		{
 			name:         "тест 2",
 			urls:         []models.URLBase{testURLFull1},
@@ -146,16 +221,21 @@ func TestRepoPostgres_InsertBatch(t *testing.T) {
 
 			mock.ExpectBegin()
 
-			prep := mock.ExpectPrepare(`INSERT INTO urls \(original, short, user_id\) VALUES \(\$1, \$2, \$3\)`)
+			prep := mock.ExpectPrepare(`INSERT INTO urls \(original, short, user_id, folder, title, description, pass_query, pass_path, rules, not_before, max_clicks, domain, original_owner\)`)
 
 			if tt.dbErrPrepare != nil {
 				prep.WillReturnError(tt.dbErrPrepare)
 			} else {
-				for _, url := range tt.urls {
-					prep.ExpectExec().
-						WithArgs(url.Original, url.Short, url.UUID).
-						WillReturnResult(sqlmock.NewResult(0, 1)).
+				for i, url := range tt.urls {
+					prep.ExpectQuery().
+						WithArgs(url.Original, url.Short, url.UUID, url.Folder, url.Title, url.Description, url.PassQuery, url.PassPath, sqlmock.AnyArg(), url.NotBefore, url.MaxClicks, url.Domain, "").
+						WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(i + 1)).
 						WillReturnError(tt.dbErr)
+					if tt.dbErr == nil && len(url.Tags) > 0 {
+						mock.ExpectExec(`INSERT INTO url_tags \(url_id, tag\)`).
+							WithArgs(i+1, sqlmock.AnyArg()).
+							WillReturnResult(sqlmock.NewResult(0, int64(len(url.Tags))))
+					}
 				}
 			}
 
@@ -173,12 +253,14 @@ func TestRepoPostgres_InsertBatch(t *testing.T) {
 
 func TestRepoPostgres_SelectShort(t *testing.T) {
 	tests := []struct {
-		name        string
-		originalURL string
-		dbRow       string
-		dbErr       error
-		want        string
-		wantErr     error
+		name             string
+		originalURL      string
+		perUserOriginals bool
+		owner            string
+		dbRow            string
+		dbErr            error
+		want             string
+		wantErr          error
 	}{
 		{
 			name:        "тест 1",
@@ -188,6 +270,16 @@ func TestRepoPostgres_SelectShort(t *testing.T) {
 			want:        urlAlias1,
 			wantErr:     nil,
 		},
+		{
+			name:             "поиск среди URL пользователя",
+			originalURL:      url1,
+			perUserOriginals: true,
+			owner:            UUID,
+			dbRow:            urlAlias1,
+			dbErr:            nil,
+			want:             urlAlias1,
+			wantErr:          nil,
+		},
 		{
 			name:        "тест 2",
 			originalURL: url3,
@@ -205,14 +297,14 @@ func TestRepoPostgres_SelectShort(t *testing.T) {
 			}
 			defer db.Close()
 
-			mock.ExpectQuery(`SELECT short FROM urls WHERE original = \$1`).
-				WithArgs(tt.originalURL).
+			mock.ExpectQuery(`SELECT short FROM urls WHERE domain = \$1 AND original_owner = \$2 AND original = \$3 AND NOT is_deleted`).
+				WithArgs("", tt.owner, tt.originalURL).
 				WillReturnRows(sqlmock.NewRows([]string{"short"}).AddRow(tt.dbRow)).
 				WillReturnError(tt.dbErr)
 
-			repo := RepoPostgres{db: db}
+			repo := RepoPostgres{db: db, PerUserOriginals: tt.perUserOriginals}
 
-			got, gotErr := repo.SelectShort(context.Background(), tt.originalURL)
+			got, gotErr := repo.SelectShort(context.Background(), "", UUID, tt.originalURL)
 			if got != tt.want {
 				t.Errorf("TestRepoPostgres_SelectShort() = %v, want: %v", got, tt.want)
 			}
@@ -225,14 +317,20 @@ func TestRepoPostgres_SelectShort(t *testing.T) {
 }
 
 func TestRepoPostgres_SelectOriginal(t *testing.T) {
+	passQuery := true
+
 	tests := []struct {
 		name     string
 		shortURL string
 		dbRow1   string
 		dbRow2   bool
+		dbRow3   *bool
 		dbErr    error
-		want     string
-		wantErr  error
+		// cancelled - запрос выполняется с отмененным контекстом
+		cancelled bool
+		want      string
+		wantPass  *bool
+		wantErr   error
 	}{
 		{
 			name:     "тест 1",
@@ -252,6 +350,17 @@ func TestRepoPostgres_SelectOriginal(t *testing.T) {
 			want:     url1,
 			wantErr:  nil,
 		},
+		{
+			name:     "тест 4, правило переноса параметров",
+			shortURL: urlAlias1,
+			dbRow1:   url1,
+			dbRow2:   false,
+			dbRow3:   &passQuery,
+			dbErr:    nil,
+			want:     url1,
+			wantPass: &passQuery,
+			wantErr:  nil,
+		},
 		{
 			name:     "тест 3",
 			shortURL: urlAlias3,
@@ -259,6 +368,21 @@ func TestRepoPostgres_SelectOriginal(t *testing.T) {
 			want:     "",
 			wantErr:  constants.ErrorURLNotExist,
 		},
+		{
+			name:     "ошибка базы данных",
+			shortURL: urlAlias1,
+			dbErr:    context.DeadlineExceeded,
+			want:     "",
+			wantErr:  context.DeadlineExceeded,
+		},
+		{
+			name:      "контекст отменен",
+			shortURL:  urlAlias1,
+			dbRow1:    url1,
+			cancelled: true,
+			want:      "",
+			wantErr:   context.Canceled,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -268,21 +392,196 @@ func TestRepoPostgres_SelectOriginal(t *testing.T) {
 			}
 			defer db.Close()
 
-			mock.ExpectQuery(`SELECT original, is_deleted FROM urls WHERE short = \$1`).
-				WithArgs(tt.shortURL).
-				WillReturnRows(sqlmock.NewRows([]string{"original", "is_deleted"}).AddRow(tt.dbRow1, tt.dbRow2)).
+			var dbRow3 any
+			if tt.dbRow3 != nil {
+				dbRow3 = *tt.dbRow3
+			}
+
+			mock.ExpectQuery(`SELECT u\.original, u\.user_id, u\.is_deleted, u\.is_disabled, u\.pass_query, u\.pass_path, u\.rules, u\.not_before, u\.max_clicks, .+ FROM urls u WHERE u\.domain = \$1 AND u\.short = \$2`).
+				WithArgs("", tt.shortURL).
+				WillReturnRows(sqlmock.NewRows([]string{"original", "user_id", "is_deleted", "is_disabled", "pass_query", "pass_path", "rules", "not_before", "max_clicks", "destinations"}).
+					AddRow(tt.dbRow1, "", tt.dbRow2, false, dbRow3, nil, []byte("[]"), nil, nil, []byte("[]"))).
 				WillReturnError(tt.dbErr)
 
 			repo := RepoPostgres{db: db}
 
-			got, gotErr := repo.SelectOriginal(context.Background(), tt.shortURL)
-			if got != tt.want {
-				t.Errorf("TestRepoPostgres_SelectOriginal() = %v, want: %v", got, tt.want)
+			ctx, cancel := context.WithCancel(context.Background())
+			if tt.cancelled {
+				cancel()
+			}
+			defer cancel()
+
+			got, gotErr := repo.SelectOriginal(ctx, "", tt.shortURL)
+			if got.Original != tt.want {
+				t.Errorf("TestRepoPostgres_SelectOriginal() = %v, want: %v", got.Original, tt.want)
+			}
+			if !reflect.DeepEqual(got.PassQuery, tt.wantPass) {
+				t.Errorf("TestRepoPostgres_SelectOriginal() = %v, wantPass: %v", got.PassQuery, tt.wantPass)
 			}
 			if !errors.Is(gotErr, tt.wantErr) {
 				t.Errorf("TestRepoPostgres_SelectOriginal() = %v, wantErr: %v", gotErr, tt.wantErr)
 			}
+		})
+	}
+}
+
+func TestRepoPostgres_RecordClick(t *testing.T) {
+	tests := []struct {
+		name     string
+		shortURL string
+		position int
+		dbErr    error
+		wantErr  error
+	}{
+		{
+			name:     "тест 1",
+			shortURL: urlAlias2,
+			position: 1,
+			dbErr:    nil,
+			wantErr:  nil,
+		},
+		{
+			name:     "тест 2",
+			shortURL: urlAlias2,
+			position: 0,
+			dbErr:    errDB,
+			wantErr:  errDB,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Skipf("an error '%s' was not expected when opening a stub database connection", err)
+			}
+			defer db.Close()
+
+			mock.ExpectExec(`UPDATE urls SET clicks = clicks \+ 1 WHERE domain = \$1 AND short = \$2 RETURNING id\)\s+UPDATE url_destinations SET clicks = clicks \+ 1`).
+				WithArgs("", tt.shortURL, tt.position).
+				WillReturnResult(sqlmock.NewResult(0, 1)).
+				WillReturnError(tt.dbErr)
+
+			repo := RepoPostgres{db: db}
+
+			gotErr := repo.RecordClick(context.Background(), "", tt.shortURL, tt.position)
+			if !errors.Is(gotErr, tt.wantErr) {
+				t.Errorf("TestRepoPostgres_RecordClick() = %v, wantErr: %v", gotErr, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestRepoPostgres_ConsumeClick(t *testing.T) {
+	tests := []struct {
+		name         string
+		shortURL     string
+		rowsAffected int64
+		dbErr        error
+		wantErr      error
+	}{
+		{
+			name:         "тест 1, переход списан",
+			shortURL:     urlAlias1,
+			rowsAffected: 1,
+			dbErr:        nil,
+			wantErr:      nil,
+		},
+		{
+			name:         "тест 2, лимит переходов исчерпан",
+			shortURL:     urlAlias1,
+			rowsAffected: 0,
+			dbErr:        nil,
+			wantErr:      constants.ErrorURLClicksExhausted,
+		},
+		{
+			name:     "тест 3",
+			shortURL: urlAlias1,
+			dbErr:    errDB,
+			wantErr:  errDB,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Skipf("an error '%s' was not expected when opening a stub database connection", err)
+			}
+			defer db.Close()
+
+			mock.ExpectExec(`UPDATE urls SET max_clicks = max_clicks - 1 WHERE domain = \$1 AND short = \$2 AND max_clicks > 0`).
+				WithArgs("", tt.shortURL).
+				WillReturnResult(sqlmock.NewResult(0, tt.rowsAffected)).
+				WillReturnError(tt.dbErr)
+
+			repo := RepoPostgres{db: db}
 
+			gotErr := repo.ConsumeClick(context.Background(), "", tt.shortURL)
+			if !errors.Is(gotErr, tt.wantErr) {
+				t.Errorf("TestRepoPostgres_ConsumeClick() = %v, wantErr: %v", gotErr, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestRepoPostgres_SelectForHealthCheck(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Skipf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	mock.ExpectQuery(`SELECT short, domain, original FROM urls WHERE NOT is_deleted`).
+		WillReturnRows(sqlmock.NewRows([]string{"short", "domain", "original"}).
+			AddRow(urlAlias1, "", url1).
+			AddRow(urlAlias1, "go.brand-a.com", url2))
+
+	repo := RepoPostgres{db: db}
+
+	got, gotErr := repo.SelectForHealthCheck(context.Background())
+	want := []models.URLBase{{Short: urlAlias1, Original: url1}, {Short: urlAlias1, Domain: "go.brand-a.com", Original: url2}}
+	if !reflect.DeepEqual(got, want) || gotErr != nil {
+		t.Errorf("TestRepoPostgres_SelectForHealthCheck() = %v, %v, want: %v", got, gotErr, want)
+	}
+}
+
+func TestRepoPostgres_UpdateHealth(t *testing.T) {
+	health := models.URLHealth{Status: 404, CheckedAt: time.Now().UTC(), Broken: true}
+
+	tests := []struct {
+		name    string
+		dbErr   error
+		wantErr error
+	}{
+		{
+			name:    "тест 1",
+			dbErr:   nil,
+			wantErr: nil,
+		},
+		{
+			name:    "тест 2",
+			dbErr:   errDB,
+			wantErr: errDB,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Skipf("an error '%s' was not expected when opening a stub database connection", err)
+			}
+			defer db.Close()
+
+			mock.ExpectExec(`INSERT INTO url_health \(url_id, status, error, checked_at, broken\)`).
+				WithArgs("go.brand-a.com", urlAlias1, health.Status, health.Error, health.CheckedAt, health.Broken).
+				WillReturnResult(sqlmock.NewResult(0, 1)).
+				WillReturnError(tt.dbErr)
+
+			repo := RepoPostgres{db: db}
+
+			gotErr := repo.UpdateHealth(context.Background(), "go.brand-a.com", urlAlias1, health)
+			if !errors.Is(gotErr, tt.wantErr) {
+				t.Errorf("TestRepoPostgres_UpdateHealth() = %v, wantErr: %v", gotErr, tt.wantErr)
+			}
 		})
 	}
 }
@@ -291,6 +590,7 @@ func TestRepoPostgres_SelectAll(t *testing.T) {
 	tests := []struct {
 		name         string
 		userID       string
+		filter       models.URLFilter
 		dbRows       []models.URLBase
 		dbErrPrepare error
 		dbErr        error
@@ -324,6 +624,16 @@ func TestRepoPostgres_SelectAll(t *testing.T) {
 			want:         nil,
 			wantErr:      errDBPrepare,
 		},
+		{
+			name:         "тест 4, отбор по тегу и папке",
+			userID:       UUID,
+			filter:       models.URLFilter{Tag: "football", Folder: "sport"},
+			dbRows:       []models.URLBase{testURLShort2},
+			dbErrPrepare: nil,
+			dbErr:        nil,
+			want:         []models.URLBase{testURLShort2},
+			wantErr:      nil,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -333,25 +643,29 @@ func TestRepoPostgres_SelectAll(t *testing.T) {
 			}
 			defer db.Close()
 
-			row := sqlmock.NewRows([]string{"original", "short"})
+			row := sqlmock.NewRows([]string{"original", "short", "domain", "folder", "title", "description", "pass_query", "pass_path", "rules", "not_before", "max_clicks",
+				"is_disabled", "health_status", "health_error", "checked_at", "broken", "tags", "destinations"})
 			for _, r := range tt.dbRows {
-				row.AddRow(r.Original, r.Short)
+				tags, _ := pq.Array(r.Tags).Value()
+				rules, _ := rulesValue(r.Rules).Value()
+				destinations, _ := json.Marshal(r.Destinations)
+				row.AddRow(r.Original, r.Short, r.Domain, r.Folder, r.Title, r.Description, nil, nil, rules, r.NotBefore, r.MaxClicks, r.DisabledFlag, nil, nil, nil, nil, tags, destinations)
 			}
 
-			prep := mock.ExpectPrepare(`SELECT original, short FROM urls WHERE user_id = \$1`)
+			prep := mock.ExpectPrepare(`SELECT u\.original, u\.short, u\.domain, u\.folder`)
 
 			if tt.dbErrPrepare != nil {
 				prep.WillReturnError(tt.dbErrPrepare)
 			} else {
 				prep.ExpectQuery().
-					WithArgs(tt.userID).
+					WithArgs(tt.userID, tt.filter.Folder, tt.filter.Tag).
 					WillReturnRows(row).
 					WillReturnError(tt.dbErr)
 			}
 
 			repo := RepoPostgres{db: db}
 
-			got, gotErr := repo.SelectAll(context.Background(), tt.userID)
+			got, gotErr := repo.SelectAll(context.Background(), tt.userID, tt.filter)
 			if !reflect.DeepEqual(got, tt.want) {
 				t.Errorf("TestRepoPostgres_SelectAll() = %v, want: %v", got, tt.want)
 			}
@@ -363,41 +677,38 @@ func TestRepoPostgres_SelectAll(t *testing.T) {
 	}
 }
 
-func TestRepoPostgres_Delete(t *testing.T) {
+func TestRepoPostgres_Update(t *testing.T) {
+	folder := "hockey"
+	title := "Континентальная хоккейная лига"
+	tags := []string{"khl"}
+
 	tests := []struct {
-		name          string
-		urls          []models.URLBase
-		dbErr         error
-		dbRowAffected int64
-		wantErr       error
+		name    string
+		update  models.URLUpdate
+		dbErr   error
+		wantErr error
 	}{
 		{
-			name:          "тест 1",
-			urls:          []models.URLBase{testURLFull1},
-			dbErr:         nil,
-			dbRowAffected: 1,
-			wantErr:       nil,
-		},
-		{
-			name:          "тест 2",
-			urls:          []models.URLBase{},
-			dbErr:         nil,
-			dbRowAffected: 0,
-			wantErr:       constants.ErrorNoData,
-		},
-		{
-			name:          "тест 3",
-			urls:          []models.URLBase{testURLFull1},
-			dbErr:         errDB,
-			dbRowAffected: 0,
-			wantErr:       errDB,
+			name: "тест 1",
+			update: models.URLUpdate{
+				UUID:   UUID,
+				Short:  urlAlias1,
+				Tags:   &tags,
+				Folder: &folder,
+				Title:  &title,
+			},
+			dbErr:   nil,
+			wantErr: nil,
 		},
 		{
-			name:          "тест 4",
-			urls:          []models.URLBase{testURLFull1},
-			dbErr:         nil,
-			dbRowAffected: 0,
-			wantErr:       constants.ErrorNotFound,
+			name: "тест 2",
+			update: models.URLUpdate{
+				UUID:  UUID,
+				Short: urlAlias3,
+				Tags:  &tags,
+			},
+			dbErr:   sql.ErrNoRows,
+			wantErr: constants.ErrorURLNotExist,
 		},
 	}
 	for _, tt := range tests {
@@ -408,50 +719,89 @@ func TestRepoPostgres_Delete(t *testing.T) {
 			}
 			defer db.Close()
 
-			var values []string
-
-			for i := range tt.urls {
-				base := i * 2
-				params := fmt.Sprintf("($%d, $%d)", base+1, base+2)
-				values = append(values, params)
-			}
-
-			query := `
-			UPDATE urls AS u SET is_deleted = true FROM (VALUES ` + strings.Join(values, ",") + `) AS v(short, user_id) WHERE u.short = v.short AND u.user_id = v.user_id;`
-
-			expectedExec := mock.ExpectExec(regexp.QuoteMeta(query))
-
-			if tt.dbErr != nil {
-				expectedExec.WillReturnError(tt.wantErr)
+			mock.ExpectBegin()
+			mock.ExpectQuery(`SELECT id FROM urls WHERE short = \$1 AND user_id = \$2 AND domain = \$3`).
+				WithArgs(tt.update.Short, tt.update.UUID, tt.update.Domain).
+				WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1)).
+				WillReturnError(tt.dbErr)
+			if tt.dbErr == nil {
+				mock.ExpectExec(`UPDATE urls SET folder = \$1 WHERE id = \$2`).
+					WithArgs(*tt.update.Folder, 1).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec(`UPDATE urls SET title = \$1 WHERE id = \$2`).
+					WithArgs(*tt.update.Title, 1).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec(`DELETE FROM url_tags WHERE url_id = \$1`).
+					WithArgs(1).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec(`INSERT INTO url_tags \(url_id, tag\)`).
+					WithArgs(1, sqlmock.AnyArg()).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
 			} else {
-				expectedExec.WillReturnResult(sqlmock.NewResult(1, tt.dbRowAffected))
+				mock.ExpectRollback()
 			}
 
 			repo := RepoPostgres{db: db}
 
-			gotErr := repo.Delete(context.Background(), tt.urls)
+			gotErr := repo.Update(context.Background(), tt.update)
 			if !errors.Is(gotErr, tt.wantErr) {
-				t.Errorf("TestRepoPostgres_Delete() = %v, wantErr: %v", gotErr, tt.wantErr)
+				t.Errorf("TestRepoPostgres_Update() = %v, wantErr: %v", gotErr, tt.wantErr)
+			}
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("TestRepoPostgres_Update(), unfulfilled expectations: %v", err)
 			}
-
 		})
 	}
 }
 
-func TestRepoPostgres_GetCountURLs(t *testing.T) {
+// deleteArgs - ожидаемые аргументы запроса удаления: домен, короткий URL и пользователь каждого URL.
+func deleteArgs(urls []models.URLBase) []driver.Value {
+	args := make([]driver.Value, 0, len(urls)*3)
+	for _, url := range urls {
+		args = append(args, url.Domain, url.Short, url.UUID)
+	}
+	return args
+}
+
+func TestRepoPostgres_Delete(t *testing.T) {
+	deletedURL := models.URLBase{UUID: UUID, Short: urlAlias1, Original: url1, DeletedFlag: true}
+
 	tests := []struct {
 		name    string
-		dbRow   int
+		urls    []models.URLBase
 		dbErr   error
-		want    int
+		dbRows  [][]driver.Value
+		want    []models.URLBase
 		wantErr error
 	}{
 		{
-			name:    "тест 1",
-			dbRow:   100,
-			dbErr:   nil,
-			want:    100,
-			wantErr: nil,
+			name:   "тест 1",
+			urls:   []models.URLBase{testURLFull1},
+			dbRows: [][]driver.Value{{UUID, urlAlias1, "", url1, false}},
+			want:   []models.URLBase{deletedURL},
+		},
+		{
+			name:    "тест 2",
+			urls:    []models.URLBase{},
+			wantErr: constants.ErrorNoData,
+		},
+		{
+			name:    "тест 3",
+			urls:    []models.URLBase{testURLFull1},
+			dbErr:   errDB,
+			wantErr: errDB,
+		},
+		{
+			name:    "тест 4",
+			urls:    []models.URLBase{testURLFull1},
+			wantErr: constants.ErrorNotFound,
+		},
+		{
+			name:   "тест 5, ранее удаленный URL не возвращается",
+			urls:   []models.URLBase{testURLFull1, testURLFull4},
+			dbRows: [][]driver.Value{{UUID, urlAlias1, "", url1, false}, {UUID, urlAlias4, "", url4, true}},
+			want:   []models.URLBase{deletedURL},
 		},
 	}
 	for _, tt := range tests {
@@ -462,38 +812,106 @@ func TestRepoPostgres_GetCountURLs(t *testing.T) {
 			}
 			defer db.Close()
 
-			mock.ExpectQuery(regexp.QuoteMeta(`SELECT COUNT(*) FROM urls`)).
-				WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(tt.dbRow)).
-				WillReturnError(tt.dbErr)
+			if len(tt.urls) > 0 {
+				expectedQuery := mock.ExpectQuery(regexp.QuoteMeta(`UPDATE urls AS u SET is_deleted = true FROM (SELECT d.id, d.is_deleted FROM urls AS d JOIN (VALUES`)).
+					WithArgs(deleteArgs(tt.urls)...)
+
+				if tt.dbErr != nil {
+					expectedQuery.WillReturnError(tt.dbErr)
+				} else {
+					rows := sqlmock.NewRows([]string{"user_id", "short", "domain", "original", "is_deleted"})
+					for _, row := range tt.dbRows {
+						rows.AddRow(row...)
+					}
+					expectedQuery.WillReturnRows(rows)
+				}
+			}
 
 			repo := RepoPostgres{db: db}
 
-			got, gotErr := repo.GetCountURLs(context.Background())
-			if got != tt.want {
-				t.Errorf("TestRepoPostgres_GetCountURLs() = %v, want: %v", got, tt.want)
-			}
+			got, gotErr := repo.Delete(context.Background(), tt.urls)
 			if !errors.Is(gotErr, tt.wantErr) {
-				t.Errorf("TestRepoPostgres_GetCountURLs() = %v, wantErr: %v", gotErr, tt.wantErr)
+				t.Errorf("TestRepoPostgres_Delete() = %v, wantErr: %v", gotErr, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("TestRepoPostgres_Delete() = %v, want: %v", got, tt.want)
+			}
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("TestRepoPostgres_Delete(), unfulfilled expectations: %v", err)
 			}
 		})
 	}
 }
 
-func TestRepoPostgres_GetCountUsers(t *testing.T) {
+func TestRepoPostgres_GetStats(t *testing.T) {
+	from := time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2026, 10, 19, 0, 0, 0, 0, time.UTC)
+	filter := models.StatsFilter{From: from, To: to, Top: 2}
+
 	tests := []struct {
 		name    string
-		dbRow   int
-		dbErr   error
-		want    int
+		mock    func(sqlmock.Sqlmock)
+		want    *models.Stats
 		wantErr error
 	}{
 		{
-			name:    "тест 1",
-			dbRow:   10,
-			dbErr:   nil,
-			want:    10,
+			name: "тест 1, статистика рассчитана",
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(regexp.QuoteMeta(`SELECT COUNT(*), COUNT(*) FILTER (WHERE NOT is_deleted)`)).
+					WillReturnRows(sqlmock.NewRows([]string{"count", "active", "deleted", "users", "size"}).
+						AddRow(100, 90, 10, 7, 8192))
+				mock.ExpectQuery(`SELECT to_char\(created_at AT TIME ZONE 'UTC', 'YYYY-MM-DD'\) AS day`).
+					WithArgs(from, to).
+					WillReturnRows(sqlmock.NewRows([]string{"day", "count"}).
+						AddRow("2026-10-02", 3).AddRow("2026-10-05", 1))
+				mock.ExpectQuery(`SELECT short, original, clicks FROM urls`).
+					WithArgs(from, to, 2).
+					WillReturnRows(sqlmock.NewRows([]string{"short", "original", "clicks"}).
+						AddRow(urlAlias1, url1, 12).AddRow(urlAlias2, url2, 5))
+				mock.ExpectQuery(`SELECT user_id, COUNT\(\*\) AS count FROM urls`).
+					WithArgs(from, to, 2).
+					WillReturnRows(sqlmock.NewRows([]string{"user_id", "count"}).AddRow(UUID, 4))
+			},
+			want: &models.Stats{
+				CountURL:     100,
+				CountUsers:   7,
+				CountActive:  90,
+				CountDeleted: 10,
+				StorageBytes: 8192,
+				From:         from,
+				To:           to,
+				PerDay:       []models.DailyStats{{Date: "2026-10-02", Count: 3}, {Date: "2026-10-05", Count: 1}},
+				TopURLs: []models.URLClicks{
+					{Short: urlAlias1, Original: url1, Clicks: 12},
+					{Short: urlAlias2, Original: url2, Clicks: 5},
+				},
+				TopUsers: []models.UserStats{{UUID: UUID, Count: 4}},
+			},
 			wantErr: nil,
 		},
+		{
+			name: "тест 2, ошибка расчета количества ссылок",
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(regexp.QuoteMeta(`SELECT COUNT(*), COUNT(*) FILTER (WHERE NOT is_deleted)`)).
+					WillReturnError(errDB)
+			},
+			want:    nil,
+			wantErr: errDB,
+		},
+		{
+			name: "тест 3, ошибка получения рейтинга ссылок",
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(regexp.QuoteMeta(`SELECT COUNT(*), COUNT(*) FILTER (WHERE NOT is_deleted)`)).
+					WillReturnRows(sqlmock.NewRows([]string{"count", "active", "deleted", "users", "size"}).
+						AddRow(0, 0, 0, 0, 8192))
+				mock.ExpectQuery(`AS day`).
+					WillReturnRows(sqlmock.NewRows([]string{"day", "count"}))
+				mock.ExpectQuery(`SELECT short, original, clicks FROM urls`).
+					WillReturnError(errDB)
+			},
+			want:    nil,
+			wantErr: errDB,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -503,18 +921,18 @@ func TestRepoPostgres_GetCountUsers(t *testing.T) {
 			}
 			defer db.Close()
 
-			mock.ExpectQuery(regexp.QuoteMeta(`SELECT COUNT(DISTINCT user_id) FROM urls`)).
-				WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(tt.dbRow)).
-				WillReturnError(tt.dbErr)
-
+			tt.mock(mock)
 			repo := RepoPostgres{db: db}
 
-			got, gotErr := repo.GetCountUsers(context.Background())
-			if got != tt.want {
-				t.Errorf("TestRepoPostgres_GetCountUsers() = %v, want: %v", got, tt.want)
+			got, gotErr := repo.GetStats(context.Background(), filter)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("TestRepoPostgres_GetStats() = %v, want: %v", got, tt.want)
 			}
 			if !errors.Is(gotErr, tt.wantErr) {
-				t.Errorf("TestRepoPostgres_GetCountUsers() = %v, wantErr: %v", gotErr, tt.wantErr)
+				t.Errorf("TestRepoPostgres_GetStats() = %v, wantErr: %v", gotErr, tt.wantErr)
+			}
+			if err = mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("TestRepoPostgres_GetStats() unmet expectations: %v", err)
 			}
 		})
 	}
@@ -0,0 +1,175 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/Di-nis/shortener-url/internal/constants"
+	"github.com/Di-nis/shortener-url/internal/models"
+	"github.com/lib/pq"
+)
+
+// InsertWebhook - добавление подписки на события.
+func (repo *RepoPostgres) InsertWebhook(ctx context.Context, webhook models.Webhook) error {
+	query := `INSERT INTO webhooks (id, user_id, url, events, secret, created_at) VALUES ($1, $2, $3, $4, $5, $6)`
+	_, err := repo.db.ExecContext(ctx, query,
+		webhook.ID, webhook.UUID, webhook.URL, pq.Array(webhook.Events), webhook.Secret, webhook.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("path: internal/repository/repository_postgres_webhooks.go, func InsertWebhook(), failed to insert webhook: %w", err)
+	}
+	return nil
+}
+
+// SelectWebhooks - получение подписок пользователя без секретов.
+func (repo *RepoPostgres) SelectWebhooks(ctx context.Context, userID string) ([]models.Webhook, error) {
+	query := `SELECT id, url, events, created_at FROM webhooks WHERE user_id = $1 ORDER BY created_at, id`
+	rows, err := repo.db.QueryContext(ctx, query, userID)
+	if err != nil {
+		return nil, fmt.Errorf("path: internal/repository/repository_postgres_webhooks.go, func SelectWebhooks(), failed to get webhooks: %w", err)
+	}
+	defer rows.Close()
+
+	var webhooks []models.Webhook
+	for rows.Next() {
+		webhook := models.Webhook{UUID: userID}
+		if err = rows.Scan(&webhook.ID, &webhook.URL, pq.Array(&webhook.Events), &webhook.CreatedAt); err != nil {
+			return nil, fmt.Errorf("path: internal/repository/repository_postgres_webhooks.go, func SelectWebhooks(), failed to scan webhook: %w", err)
+		}
+		if len(webhook.Events) == 0 {
+			webhook.Events = nil
+		}
+		webhooks = append(webhooks, webhook)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("path: internal/repository/repository_postgres_webhooks.go, func SelectWebhooks(), row iteration failed: %w", err)
+	}
+	return webhooks, nil
+}
+
+// DeleteWebhook - удаление подписки пользователя вместе с журналом доставок.
+func (repo *RepoPostgres) DeleteWebhook(ctx context.Context, userID, webhookID string) error {
+	result, err := repo.db.ExecContext(ctx, "DELETE FROM webhooks WHERE id = $1 AND user_id = $2", webhookID, userID)
+	if err != nil {
+		return fmt.Errorf("path: internal/repository/repository_postgres_webhooks.go, func DeleteWebhook(), failed to delete webhook: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("path: internal/repository/repository_postgres_webhooks.go, func DeleteWebhook(), failed to get rows affected: %w", err)
+	}
+	if rows == 0 {
+		return fmt.Errorf("path: internal/repository/repository_postgres_webhooks.go, func DeleteWebhook(): %w", constants.ErrorNotFound)
+	}
+	return nil
+}
+
+// InsertDeliveries - постановка доставок событий в очередь.
+func (repo *RepoPostgres) InsertDeliveries(ctx context.Context, deliveries []models.WebhookDelivery) error {
+	tx, err := repo.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("path: internal/repository/repository_postgres_webhooks.go, func InsertDeliveries(), failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	stmt, err := tx.PrepareContext(ctx, `INSERT INTO webhook_deliveries
+		(id, webhook_id, event_type, payload, status, next_attempt_at, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $7)`)
+	if err != nil {
+		return fmt.Errorf("path: internal/repository/repository_postgres_webhooks.go, func InsertDeliveries(), failed to prepare statement: %w", err)
+	}
+	defer stmt.Close()
+
+	for _, delivery := range deliveries {
+		_, err = stmt.ExecContext(ctx, delivery.ID, delivery.WebhookID, delivery.EventType, string(delivery.Payload),
+			delivery.Status, delivery.NextAttemptAt, delivery.CreatedAt)
+		if err != nil {
+			return fmt.Errorf("path: internal/repository/repository_postgres_webhooks.go, func InsertDeliveries(), failed to insert delivery: %w", err)
+		}
+	}
+	return tx.Commit()
+}
+
+// ClaimDueDeliveries - выборка доставок, время отправки которых наступило.
+// Время следующей попытки выбранных доставок переносится на leaseUntil, чтобы их
+// не выбрали повторно другие экземпляры приложения или после сбоя во время отправки.
+func (repo *RepoPostgres) ClaimDueDeliveries(ctx context.Context, now, leaseUntil time.Time, limit int) ([]models.WebhookDelivery, error) {
+	query := `
+	WITH due AS (
+		SELECT id FROM webhook_deliveries
+		WHERE status = 'pending' AND next_attempt_at <= $1
+		ORDER BY next_attempt_at
+		LIMIT $3
+		FOR UPDATE SKIP LOCKED
+	)
+	UPDATE webhook_deliveries d SET next_attempt_at = $2
+	FROM due, webhooks w
+	WHERE d.id = due.id AND w.id = d.webhook_id
+	RETURNING d.id, d.webhook_id, d.event_type, d.payload, d.status, d.attempts, d.created_at, w.url, w.secret`
+
+	rows, err := repo.db.QueryContext(ctx, query, now, leaseUntil, limit)
+	if err != nil {
+		return nil, fmt.Errorf("path: internal/repository/repository_postgres_webhooks.go, func ClaimDueDeliveries(), failed to claim deliveries: %w", err)
+	}
+	defer rows.Close()
+
+	var deliveries []models.WebhookDelivery
+	for rows.Next() {
+		delivery := models.WebhookDelivery{NextAttemptAt: leaseUntil}
+		err = rows.Scan(&delivery.ID, &delivery.WebhookID, &delivery.EventType, &delivery.Payload, &delivery.Status,
+			&delivery.Attempts, &delivery.CreatedAt, &delivery.URL, &delivery.Secret)
+		if err != nil {
+			return nil, fmt.Errorf("path: internal/repository/repository_postgres_webhooks.go, func ClaimDueDeliveries(), failed to scan delivery: %w", err)
+		}
+		deliveries = append(deliveries, delivery)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("path: internal/repository/repository_postgres_webhooks.go, func ClaimDueDeliveries(), row iteration failed: %w", err)
+	}
+	return deliveries, nil
+}
+
+// UpdateDelivery - сохранение результата попытки доставки.
+func (repo *RepoPostgres) UpdateDelivery(ctx context.Context, delivery models.WebhookDelivery) error {
+	query := `UPDATE webhook_deliveries SET status = $2, attempts = $3, last_status_code = $4, last_error = $5,
+		next_attempt_at = $6, updated_at = $7 WHERE id = $1`
+	_, err := repo.db.ExecContext(ctx, query, delivery.ID, delivery.Status, delivery.Attempts, delivery.LastStatusCode,
+		delivery.LastError, delivery.NextAttemptAt, delivery.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("path: internal/repository/repository_postgres_webhooks.go, func UpdateDelivery(), failed to update delivery: %w", err)
+	}
+	return nil
+}
+
+// SelectDeliveries - получение журнала доставок подписки пользователя, начиная с последних.
+func (repo *RepoPostgres) SelectDeliveries(ctx context.Context, userID, webhookID string, limit int) ([]models.WebhookDelivery, error) {
+	query := `
+	SELECT d.id, d.webhook_id, d.event_type, d.status, d.attempts, d.last_status_code, d.last_error,
+		d.next_attempt_at, d.created_at, d.updated_at
+	FROM webhook_deliveries d
+	JOIN webhooks w ON w.id = d.webhook_id
+	WHERE d.webhook_id = $1 AND w.user_id = $2
+	ORDER BY d.created_at DESC, d.id
+	LIMIT $3`
+
+	rows, err := repo.db.QueryContext(ctx, query, webhookID, userID, limit)
+	if err != nil {
+		return nil, fmt.Errorf("path: internal/repository/repository_postgres_webhooks.go, func SelectDeliveries(), failed to get deliveries: %w", err)
+	}
+	defer rows.Close()
+
+	var deliveries []models.WebhookDelivery
+	for rows.Next() {
+		var delivery models.WebhookDelivery
+		err = rows.Scan(&delivery.ID, &delivery.WebhookID, &delivery.EventType, &delivery.Status, &delivery.Attempts,
+			&delivery.LastStatusCode, &delivery.LastError, &delivery.NextAttemptAt, &delivery.CreatedAt, &delivery.UpdatedAt)
+		if err != nil {
+			return nil, fmt.Errorf("path: internal/repository/repository_postgres_webhooks.go, func SelectDeliveries(), failed to scan delivery: %w", err)
+		}
+		deliveries = append(deliveries, delivery)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("path: internal/repository/repository_postgres_webhooks.go, func SelectDeliveries(), row iteration failed: %w", err)
+	}
+	return deliveries, nil
+}
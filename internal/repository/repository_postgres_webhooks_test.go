@@ -0,0 +1,116 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/Di-nis/shortener-url/internal/constants"
+	"github.com/Di-nis/shortener-url/internal/models"
+)
+
+func TestRepoPostgres_DeleteWebhook(t *testing.T) {
+	tests := []struct {
+		name         string
+		rowsAffected int64
+		dbErr        error
+		wantErr      error
+	}{
+		{
+			name:         "тест 1, подписка удалена",
+			rowsAffected: 1,
+			wantErr:      nil,
+		},
+		{
+			name:         "тест 2, подписка не найдена",
+			rowsAffected: 0,
+			wantErr:      constants.ErrorNotFound,
+		},
+		{
+			name:    "тест 3, ошибка базы данных",
+			dbErr:   errDB,
+			wantErr: errDB,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Skipf("an error '%s' was not expected when opening a stub database connection", err)
+			}
+			defer db.Close()
+
+			mock.ExpectExec(`DELETE FROM webhooks WHERE id = \$1 AND user_id = \$2`).
+				WithArgs(webhookID, UUID).
+				WillReturnResult(sqlmock.NewResult(0, tt.rowsAffected)).
+				WillReturnError(tt.dbErr)
+
+			repo := RepoPostgres{db: db}
+
+			gotErr := repo.DeleteWebhook(context.Background(), UUID, webhookID)
+			if !errors.Is(gotErr, tt.wantErr) {
+				t.Errorf("TestRepoPostgres_DeleteWebhook() = %v, wantErr: %v", gotErr, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestRepoPostgres_ClaimDueDeliveries(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Skipf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	now := time.Now().UTC()
+	lease := now.Add(time.Minute)
+
+	mock.ExpectQuery(`WITH due AS \(.+FOR UPDATE SKIP LOCKED\s+\)\s+UPDATE webhook_deliveries d SET next_attempt_at = \$2`).
+		WithArgs(now, lease, 10).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "webhook_id", "event_type", "payload", "status", "attempts", "created_at", "url", "secret"}).
+			AddRow("1", webhookID, models.EventURLCreated, []byte(`{}`), models.DeliveryPending, 2, now, "https://hooks.example.com", "secret"))
+
+	repo := RepoPostgres{db: db}
+
+	got, gotErr := repo.ClaimDueDeliveries(context.Background(), now, lease, 10)
+	if gotErr != nil || len(got) != 1 {
+		t.Fatalf("TestRepoPostgres_ClaimDueDeliveries() = %v, %v", got, gotErr)
+	}
+	if got[0].Attempts != 2 || got[0].URL != "https://hooks.example.com" || got[0].Secret != "secret" || !got[0].NextAttemptAt.Equal(lease) {
+		t.Errorf("TestRepoPostgres_ClaimDueDeliveries() = %v", got[0])
+	}
+}
+
+func TestRepoPostgres_UpdateDelivery(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Skipf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	delivery := models.WebhookDelivery{
+		ID:             "1",
+		Status:         models.DeliveryPending,
+		Attempts:       3,
+		LastStatusCode: 503,
+		LastError:      "Service Unavailable",
+		NextAttemptAt:  time.Now().UTC().Add(time.Minute),
+		UpdatedAt:      time.Now().UTC(),
+	}
+
+	mock.ExpectExec(`UPDATE webhook_deliveries SET status = \$2, attempts = \$3`).
+		WithArgs(delivery.ID, delivery.Status, delivery.Attempts, delivery.LastStatusCode, delivery.LastError,
+			delivery.NextAttemptAt, delivery.UpdatedAt).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	repo := RepoPostgres{db: db}
+
+	if gotErr := repo.UpdateDelivery(context.Background(), delivery); gotErr != nil {
+		t.Errorf("TestRepoPostgres_UpdateDelivery() = %v", gotErr)
+	}
+	if err = mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("TestRepoPostgres_UpdateDelivery() = %v", err)
+	}
+}
@@ -2,6 +2,7 @@ package repository
 
 import (
 	"errors"
+	"time"
 
 	"github.com/Di-nis/shortener-url/internal/models"
 )
@@ -25,11 +26,27 @@ var (
 		Original:    url1,
 		Short:       urlAlias1,
 		DeletedFlag: false,
+		Tags:        []string{"hockey"},
+		Folder:      "sport",
+		Title:       "КХЛ",
+		Description: "Официальный сайт лиги",
+		Rules: []models.TargetRule{
+			{Platform: "ios", URL: "https://apps.apple.com/ru/app/khl/id1"},
+			{Platform: "android", Language: "en", URL: "https://play.google.com/store/apps/details?id=ru.khl"},
+		},
 	}
 
 	testURLShort1 = models.URLBase{
-		Original: url1,
-		Short:    urlAlias1,
+		Original:    url1,
+		Short:       urlAlias1,
+		Tags:        []string{"hockey"},
+		Folder:      "sport",
+		Title:       "КХЛ",
+		Description: "Официальный сайт лиги",
+		Rules: []models.TargetRule{
+			{Platform: "ios", URL: "https://apps.apple.com/ru/app/khl/id1"},
+			{Platform: "android", Language: "en", URL: "https://play.google.com/store/apps/details?id=ru.khl"},
+		},
 	}
 
 	testURLFull2 = models.URLBase{
@@ -38,11 +55,23 @@ var (
 		Original:    url2,
 		Short:       urlAlias2,
 		DeletedFlag: false,
+		Tags:        []string{"football", "hockey"},
+		Folder:      "sport",
+		Destinations: []models.Destination{
+			{URL: url2, Weight: 80, Clicks: 3},
+			{URL: url2 + "promo", Weight: 20, Clicks: 1},
+		},
 	}
 
 	testURLShort2 = models.URLBase{
 		Original: url2,
 		Short:    urlAlias2,
+		Tags:     []string{"football", "hockey"},
+		Folder:   "sport",
+		Destinations: []models.Destination{
+			{URL: url2, Weight: 80, Clicks: 3},
+			{URL: url2 + "promo", Weight: 20, Clicks: 1},
+		},
 	}
 
 	testURLFull3 = models.URLBase{
@@ -50,6 +79,7 @@ var (
 		Original:    url3,
 		Short:       urlAlias3,
 		DeletedFlag: false,
+		CreatedAt:   time.Date(2026, 10, 2, 9, 30, 0, 0, time.UTC),
 	}
 
 	testURLFull4 = models.URLBase{
@@ -0,0 +1,48 @@
+// Package secure предоставляет middleware для установки заголовков безопасности.
+package secure
+
+import (
+	"fmt"
+	"net/http"
+)
+
+// Options - параметры заголовков безопасности.
+type Options struct {
+	EnableHTTPS    bool
+	HSTSMaxAge     int
+	ReferrerPolicy string
+}
+
+// redirectResponseWriter - реализация http.ResponseWriter, который добавляет
+// Referrer-Policy к ответам с перенаправлением.
+type redirectResponseWriter struct {
+	http.ResponseWriter
+	referrerPolicy string
+}
+
+// WriteHeader - пишет заголовок в redirectResponseWriter.
+func (r *redirectResponseWriter) WriteHeader(statusCode int) {
+	if statusCode >= 300 && statusCode < 400 && r.referrerPolicy != "" {
+		r.ResponseWriter.Header().Set("Referrer-Policy", r.referrerPolicy)
+	}
+	r.ResponseWriter.WriteHeader(statusCode)
+}
+
+// WithSecurityHeaders - middleware для установки заголовков безопасности.
+func WithSecurityHeaders(options Options) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("X-Content-Type-Options", "nosniff")
+
+			if options.EnableHTTPS && options.HSTSMaxAge > 0 {
+				w.Header().Set("Strict-Transport-Security", fmt.Sprintf("max-age=%d; includeSubDomains", options.HSTSMaxAge))
+			}
+
+			rw := &redirectResponseWriter{
+				ResponseWriter: w,
+				referrerPolicy: options.ReferrerPolicy,
+			}
+			next.ServeHTTP(rw, r)
+		})
+	}
+}
@@ -0,0 +1,99 @@
+package secure
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWithSecurityHeaders(t *testing.T) {
+	type want struct {
+		statusCode     int
+		hsts           string
+		referrerPolicy string
+	}
+
+	tests := []struct {
+		name    string
+		options Options
+		status  int
+		want    want
+	}{
+		{
+			name:    "HTTPS включен, HSTS с max-age из параметров",
+			options: Options{EnableHTTPS: true, HSTSMaxAge: 31536000},
+			status:  http.StatusOK,
+			want: want{
+				statusCode: http.StatusOK,
+				hsts:       "max-age=31536000; includeSubDomains",
+			},
+		},
+		{
+			name:    "HTTPS выключен, HSTS не отправляется",
+			options: Options{HSTSMaxAge: 31536000},
+			status:  http.StatusOK,
+			want: want{
+				statusCode: http.StatusOK,
+			},
+		},
+		{
+			name:    "HTTPS включен, нулевой max-age",
+			options: Options{EnableHTTPS: true},
+			status:  http.StatusOK,
+			want: want{
+				statusCode: http.StatusOK,
+			},
+		},
+		{
+			name:    "Referrer-Policy в ответе с перенаправлением",
+			options: Options{ReferrerPolicy: "no-referrer"},
+			status:  http.StatusTemporaryRedirect,
+			want: want{
+				statusCode:     http.StatusTemporaryRedirect,
+				referrerPolicy: "no-referrer",
+			},
+		},
+		{
+			name:    "Referrer-Policy не добавляется к ответу без перенаправления",
+			options: Options{ReferrerPolicy: "no-referrer"},
+			status:  http.StatusOK,
+			want: want{
+				statusCode: http.StatusOK,
+			},
+		},
+		{
+			name:    "перенаправление без Referrer-Policy в параметрах",
+			options: Options{},
+			status:  http.StatusMovedPermanently,
+			want: want{
+				statusCode: http.StatusMovedPermanently,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if tt.status >= 300 && tt.status < 400 {
+					http.Redirect(w, r, "https://www.khl.ru/", tt.status)
+					return
+				}
+				w.WriteHeader(tt.status)
+			})
+			handler := WithSecurityHeaders(tt.options)(next)
+
+			req := httptest.NewRequest(http.MethodGet, "/lJJpJV7h", nil)
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+
+			res := rec.Result()
+			defer res.Body.Close()
+
+			assert.Equal(t, tt.want.statusCode, res.StatusCode)
+			assert.Equal(t, "nosniff", res.Header.Get("X-Content-Type-Options"))
+			assert.Equal(t, tt.want.hsts, res.Header.Get("Strict-Transport-Security"))
+			assert.Equal(t, tt.want.referrerPolicy, res.Header.Get("Referrer-Policy"))
+		})
+	}
+}
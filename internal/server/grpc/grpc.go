@@ -3,56 +3,101 @@ package grpcserver
 import (
 	"context"
 	"errors"
+	"fmt"
+	"io"
 	"net"
-	"os"
 	"time"
 
 	"github.com/Di-nis/shortener-url/internal/authn"
+	"github.com/Di-nis/shortener-url/internal/cidr"
 	"github.com/Di-nis/shortener-url/internal/config"
 	"github.com/Di-nis/shortener-url/internal/constants"
+	"github.com/Di-nis/shortener-url/internal/domains"
+	"github.com/Di-nis/shortener-url/internal/limits"
 	"github.com/Di-nis/shortener-url/internal/logger"
 	"github.com/Di-nis/shortener-url/internal/models"
 	pb "github.com/Di-nis/shortener-url/internal/proto"
-	"github.com/Di-nis/shortener-url/internal/service"
 	"github.com/Di-nis/shortener-url/internal/toolkit"
 	"github.com/Di-nis/shortener-url/internal/usecase"
 
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/reflection"
 	"google.golang.org/grpc/status"
-	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
+// shutdownTimeout - время ожидания завершения обрабатываемых вызовов при остановке сервера.
+const shutdownTimeout = 10 * time.Second
+
+// bulkBatchSize - число URL в одном пакете записи при массовом сокращении через поток.
+const bulkBatchSize = 500
+
+// Pinger - интерфейс для проверки соединения с БД.
+type Pinger interface {
+	Ping(context.Context) error
+}
+
 // URLCreator - интерфейс, включащий методы по созданию URL.
 type URLCreator interface {
 	CreateURLOrdinary(context.Context, any) (models.URLBase, error)
+	CreateURLBatch(context.Context, []models.URLBase) ([]models.URLBase, error)
+	CreateURLBulk(context.Context, []models.URLBase) ([]models.BulkResult, error)
 }
 
 // URLReader - интерфейс, включащий методы по получению URL.
 type URLReader interface {
-	GetOriginalURL(context.Context, string) (string, error)
-	GetAllURLs(context.Context, string) ([]models.URLBase, error)
+	GetOriginalURL(context.Context, string, string) (string, error)
+	GetAllURLs(context.Context, string, models.URLFilter) ([]models.URLBase, error)
+	LookupURLs(context.Context, string, models.URLLookup) ([]models.URLBase, error)
+	GetURLsPage(context.Context, string, models.URLFilter, string, int) (models.URLPage, error)
+}
+
+// URLDeleter - интерфейс, включащий методы по удалению URL.
+type URLDeleter interface {
+	DeleteURLs(context.Context, []models.URLBase) error
+}
+
+// URLStats - интерфейс, включающий методы по получению статистики.
+type URLStats interface {
+	GetStats(context.Context, models.StatsFilter) (*models.Stats, error)
 }
 
 // URLUseCase - объединенный интерфейс.
 type URLUseCase interface {
+	Pinger
 	URLCreator
 	URLReader
+	URLDeleter
+	URLStats
 }
 
 // ShortenerServiceServer поддерживает все необходимые методы сервера.
 type ShortenerServiceServer struct {
 	pb.UnimplementedShortenerServiceServer
+	Pinger     Pinger
 	URLCreator URLCreator
 	URLReader  URLReader
+	URLDeleter URLDeleter
+	URLStats   URLStats
+	Domains    *domains.Registry
 	Config     *config.Config
 }
 
 // NewShortenerServiceServer - создание нового сервера.
 func NewShortenerServiceServer(useCase URLUseCase, config *config.Config) *ShortenerServiceServer {
 	return &ShortenerServiceServer{
+		Pinger:     useCase,
 		URLCreator: useCase,
 		URLReader:  useCase,
+		URLDeleter: useCase,
+		URLStats:   useCase,
+		Domains:    domains.NewRegistry(config.BaseURL, config.Domains),
 		Config:     config,
 	}
 }
@@ -62,10 +107,33 @@ func (s *ShortenerServiceServer) ShortenURL(ctx context.Context, in *pb.URLShort
 	var response pb.URLShortenResponse
 
 	userID := ctx.Value(constants.UserIDKey).(string)
+	domain, err := s.resolveDomain(in.GetDomain())
+	if err != nil {
+		return nil, err
+	}
+
 	urlOriginal := in.GetUrl()
 	urlIn := models.URLJSON{
-		UUID:     userID,
-		Original: urlOriginal,
+		UUID:        userID,
+		Domain:      domain,
+		Original:    urlOriginal,
+		Tags:        in.GetTags(),
+		Folder:      in.GetFolder(),
+		Title:       in.GetTitle(),
+		Description: in.GetDescription(),
+	}
+	for _, destination := range in.GetDestinations() {
+		urlIn.Destinations = append(urlIn.Destinations, models.Destination{
+			URL:    destination.GetUrl(),
+			Weight: int(destination.GetWeight()),
+		})
+	}
+	for _, rule := range in.GetRules() {
+		urlIn.Rules = append(urlIn.Rules, models.TargetRule{
+			Platform: rule.GetPlatform(),
+			Language: rule.GetLanguage(),
+			URL:      rule.GetUrl(),
+		})
 	}
 
 	urlOut, err := s.URLCreator.CreateURLOrdinary(ctx, urlIn)
@@ -73,10 +141,13 @@ func (s *ShortenerServiceServer) ShortenURL(ctx context.Context, in *pb.URLShort
 		if errors.Is(err, constants.ErrorURLAlreadyExist) {
 			return nil, status.Errorf(codes.AlreadyExists, `URL %s already exist`, urlOriginal)
 		}
+		if errors.Is(err, constants.ErrorUserBlocked) {
+			return nil, status.Error(codes.PermissionDenied, "user is blocked")
+		}
 		return nil, status.Error(codes.Internal, "internal error")
 	}
 
-	urlOut.Short = toolkit.AddBaseURLToResponse(s.Config.BaseURL, urlOut.Short)
+	urlOut.Short = toolkit.AddBaseURLToResponse(s.Domains.BaseURL(urlOut.Domain), urlOut.Short)
 
 	response.SetResult(urlOut.Short)
 
@@ -87,7 +158,12 @@ func (s *ShortenerServiceServer) ShortenURL(ctx context.Context, in *pb.URLShort
 func (s *ShortenerServiceServer) ExpandURL(ctx context.Context, in *pb.URLExpandRequest) (*pb.URLExpandResponse, error) {
 	var response pb.URLExpandResponse
 
-	urlOriginal, err := s.URLReader.GetOriginalURL(ctx, in.GetId())
+	domain, err := s.resolveDomain(in.GetDomain())
+	if err != nil {
+		return nil, err
+	}
+
+	urlOriginal, err := s.URLReader.GetOriginalURL(ctx, domain, in.GetId())
 	if err != nil {
 		if errors.Is(err, constants.ErrorURLNotExist) {
 			return nil, status.Errorf(codes.NotFound, `URL %s not found`, in.GetId())
@@ -95,6 +171,15 @@ func (s *ShortenerServiceServer) ExpandURL(ctx context.Context, in *pb.URLExpand
 		if errors.Is(err, constants.ErrorURLAlreadyDeleted) {
 			return nil, status.Errorf(codes.NotFound, `URL %s already deleted`, in.GetId())
 		}
+		if errors.Is(err, constants.ErrorURLNotYetActive) {
+			return nil, status.Errorf(codes.NotFound, `URL %s is not active yet`, in.GetId())
+		}
+		if errors.Is(err, constants.ErrorURLClicksExhausted) {
+			return nil, status.Errorf(codes.NotFound, `URL %s click limit exhausted`, in.GetId())
+		}
+		if errors.Is(err, constants.ErrorURLDisabled) {
+			return nil, status.Errorf(codes.PermissionDenied, `URL %s is disabled`, in.GetId())
+		}
 		return nil, status.Error(codes.Unavailable, "server unavailable")
 	}
 
@@ -104,65 +189,476 @@ func (s *ShortenerServiceServer) ExpandURL(ctx context.Context, in *pb.URLExpand
 }
 
 // ListUserURLs - получение списка всех коротких URL пользователя.
-func (s *ShortenerServiceServer) ListUserURLs(ctx context.Context, _ *emptypb.Empty) (*pb.UserURLsResponse, error) {
+func (s *ShortenerServiceServer) ListUserURLs(ctx context.Context, in *pb.ListUserURLsRequest) (*pb.UserURLsResponse, error) {
 	var response pb.UserURLsResponse
 
 	userID := ctx.Value(constants.UserIDKey).(string)
 
-	urls, err := s.URLReader.GetAllURLs(ctx, userID)
+	filter := models.URLFilter{
+		Tag:    in.GetTag(),
+		Folder: in.GetFolder(),
+	}
+
+	urls, err := s.URLReader.GetAllURLs(ctx, userID, filter)
+	if err != nil {
+		return nil, status.Error(codes.Internal, "internal error")
+	}
+
+	response.SetUrl(s.convertURLs(urls))
+
+	return &response, nil
+}
+
+// StreamUserURLs - потоковое получение коротких URL пользователя частями по page_size URL.
+// Каждая часть содержит токен продолжения, с которым получение можно возобновить после обрыва.
+func (s *ShortenerServiceServer) StreamUserURLs(in *pb.StreamUserURLsRequest, stream grpc.ServerStreamingServer[pb.UserURLsChunk]) error {
+	ctx := stream.Context()
+
+	userID, ok := ctx.Value(constants.UserIDKey).(string)
+	if !ok {
+		return status.Error(codes.Unauthenticated, "user is not authenticated")
+	}
+
+	filter := models.URLFilter{
+		Tag:    in.GetTag(),
+		Folder: in.GetFolder(),
+	}
+
+	token := in.GetResumeToken()
+	for {
+		// клиент отменил получение или истек срок вызова
+		if err := ctx.Err(); err != nil {
+			return status.FromContextError(err).Err()
+		}
+
+		page, err := s.URLReader.GetURLsPage(ctx, userID, filter, token, int(in.GetPageSize()))
+		if err != nil {
+			if errors.Is(err, constants.ErrorInvalidPageToken) {
+				return status.Error(codes.InvalidArgument, "invalid resume token")
+			}
+			if ctx.Err() != nil {
+				return status.FromContextError(ctx.Err()).Err()
+			}
+			return status.Error(codes.Internal, "internal error")
+		}
+		if len(page.URLs) == 0 {
+			return nil
+		}
+
+		chunk := pb.UserURLsChunk_builder{
+			Url: s.convertURLs(page.URLs),
+		}.Build()
+		if page.NextToken != "" {
+			chunk.SetResumeToken(page.NextToken)
+		}
+		if err = stream.Send(chunk); err != nil {
+			return err
+		}
+
+		if page.NextToken == "" {
+			return nil
+		}
+		token = page.NextToken
+	}
+}
+
+// SearchUserURLs - поиск URL пользователя по оригинальному URL, хосту, префиксу или подстроке.
+func (s *ShortenerServiceServer) SearchUserURLs(ctx context.Context, in *pb.SearchUserURLsRequest) (*pb.UserURLsResponse, error) {
+	var response pb.UserURLsResponse
+
+	userID := ctx.Value(constants.UserIDKey).(string)
+
+	lookup := models.URLLookup{
+		Mode:  in.GetMode(),
+		Query: in.GetQuery(),
+		Limit: int(in.GetLimit()),
+	}
+
+	urls, err := s.URLReader.LookupURLs(ctx, userID, lookup)
+	if err != nil {
+		if errors.Is(err, constants.ErrorInvalidLookup) {
+			return nil, status.Error(codes.InvalidArgument, "invalid lookup")
+		}
+		return nil, status.Error(codes.Internal, "internal error")
+	}
+
+	response.SetUrl(s.convertURLs(urls))
+
+	return &response, nil
+}
+
+// ShortenBatch - создание коротких URL для набора оригинальных URL с идентификаторами корреляции.
+func (s *ShortenerServiceServer) ShortenBatch(ctx context.Context, in *pb.ShortenBatchRequest) (*pb.ShortenBatchResponse, error) {
+	var response pb.ShortenBatchResponse
+
+	if len(in.GetItems()) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "empty batch")
+	}
+
+	userID := ctx.Value(constants.UserIDKey).(string)
+
+	urls := make([]models.URLBase, 0, len(in.GetItems()))
+	for _, item := range in.GetItems() {
+		url, err := s.convertBatchItem(userID, item)
+		if err != nil {
+			return nil, err
+		}
+		urls = append(urls, url)
+	}
+
+	createdURLs, err := s.URLCreator.CreateURLBatch(ctx, urls)
+	if err != nil {
+		if errors.Is(err, constants.ErrorURLAlreadyExist) {
+			return nil, status.Error(codes.AlreadyExists, "URL already exist")
+		}
+		if errors.Is(err, constants.ErrorUserBlocked) {
+			return nil, status.Error(codes.PermissionDenied, "user is blocked")
+		}
+		return nil, status.Error(codes.Unavailable, "server unavailable")
+	}
+
+	results := make([]*pb.BatchResult, 0, len(createdURLs))
+	for _, url := range createdURLs {
+		shortURL := toolkit.AddBaseURLToResponse(s.Domains.BaseURL(url.Domain), url.Short)
+		results = append(results, pb.BatchResult_builder{
+			CorrelationId: &url.URLID,
+			ShortUrl:      &shortURL,
+		}.Build())
+	}
+	response.SetResults(results)
+
+	return &response, nil
+}
+
+// ShortenStream - массовое сокращение URL через один поток.
+// URL записываются пакетами по bulkBatchSize, результаты пакета отправляются до чтения следующих URL,
+// поэтому медленный получатель результатов через управление потоком gRPC приостанавливает отправителя.
+// Оставшиеся URL записываются после закрытия потока клиентом.
+func (s *ShortenerServiceServer) ShortenStream(stream grpc.BidiStreamingServer[pb.BatchItem, pb.ShortenStreamResult]) error {
+	ctx := stream.Context()
+
+	userID, ok := ctx.Value(constants.UserIDKey).(string)
+	if !ok {
+		return status.Error(codes.Unauthenticated, "user is not authenticated")
+	}
+
+	batch := make([]models.URLBase, 0, bulkBatchSize)
+	for {
+		item, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			return s.flushBulk(stream, batch)
+		}
+		if err != nil {
+			return err
+		}
+
+		url, err := s.convertBatchItem(userID, item)
+		if err != nil {
+			return err
+		}
+		batch = append(batch, url)
+		if len(batch) == bulkBatchSize {
+			if err = s.flushBulk(stream, batch); err != nil {
+				return err
+			}
+			batch = batch[:0]
+		}
+	}
+}
+
+// flushBulk - запись пакета URL из потока ShortenStream и отправка результатов по каждому URL.
+func (s *ShortenerServiceServer) flushBulk(stream grpc.BidiStreamingServer[pb.BatchItem, pb.ShortenStreamResult], batch []models.URLBase) error {
+	if len(batch) == 0 {
+		return nil
+	}
+	ctx := stream.Context()
+
+	results, err := s.URLCreator.CreateURLBulk(ctx, batch)
+	if err != nil {
+		if errors.Is(err, constants.ErrorUserBlocked) {
+			return status.Error(codes.PermissionDenied, "user is blocked")
+		}
+		if ctx.Err() != nil {
+			return status.FromContextError(ctx.Err()).Err()
+		}
+		return status.Error(codes.Unavailable, "server unavailable")
+	}
+
+	for _, result := range results {
+		response := pb.ShortenStreamResult_builder{
+			CorrelationId: proto.String(result.URLID),
+			Status:        proto.String(result.Status),
+		}.Build()
+		if result.Short != "" {
+			response.SetShortUrl(toolkit.AddBaseURLToResponse(s.Domains.BaseURL(result.Domain), result.Short))
+		}
+		if err = stream.Send(response); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// convertBatchItem - преобразование URL из пакета в модель.
+func (s *ShortenerServiceServer) convertBatchItem(userID string, item *pb.BatchItem) (models.URLBase, error) {
+	domain, err := s.resolveDomain(item.GetDomain())
+	if err != nil {
+		return models.URLBase{}, err
+	}
+
+	return models.URLBase{
+		UUID:        userID,
+		Domain:      domain,
+		URLID:       item.GetCorrelationId(),
+		Original:    item.GetOriginalUrl(),
+		Tags:        item.GetTags(),
+		Folder:      item.GetFolder(),
+		Title:       item.GetTitle(),
+		Description: item.GetDescription(),
+	}, nil
+}
+
+// resolveDomain - проверка домена, выбранного в запросе.
+// Пустое значение соответствует домену по умолчанию.
+func (s *ShortenerServiceServer) resolveDomain(domain string) (string, error) {
+	resolved, err := s.Domains.Resolve(domain)
+	if err != nil {
+		return "", status.Errorf(codes.InvalidArgument, "unknown domain %s", domain)
+	}
+	return resolved, nil
+}
+
+// DeleteUserURLs - удаление коротких URL пользователя.
+func (s *ShortenerServiceServer) DeleteUserURLs(ctx context.Context, in *pb.DeleteUserURLsRequest) (*pb.DeleteUserURLsResponse, error) {
+	userID := ctx.Value(constants.UserIDKey).(string)
+	domain, err := s.resolveDomain(in.GetDomain())
+	if err != nil {
+		return nil, err
+	}
+
+	urls := make([]models.URLBase, 0, len(in.GetShortUrls()))
+	for _, short := range in.GetShortUrls() {
+		urls = append(urls, models.URLBase{
+			Short:  short,
+			UUID:   userID,
+			Domain: domain,
+		})
+	}
+
+	if err = s.URLDeleter.DeleteURLs(ctx, urls); err != nil {
+		if limits.IsTimeout(err) {
+			return nil, status.Error(codes.Unavailable, "server unavailable")
+		}
+		return nil, status.Error(codes.Internal, "internal error")
+	}
+
+	return &pb.DeleteUserURLsResponse{}, nil
+}
+
+// Ping - проверка соединения с хранилищем.
+func (s *ShortenerServiceServer) Ping(ctx context.Context, in *pb.PingRequest) (*pb.PingResponse, error) {
+	if err := s.Pinger.Ping(ctx); err != nil {
+		return nil, status.Error(codes.Unavailable, "storage unavailable")
+	}
+	return &pb.PingResponse{}, nil
+}
+
+// GetStats - получение статистики по сокращенным URL.
+// Доступно только клиентам из доверенной подсети, адрес клиента определяется по соединению.
+func (s *ShortenerServiceServer) GetStats(ctx context.Context, in *pb.StatsRequest) (*pb.StatsResponse, error) {
+	client, ok := peer.FromContext(ctx)
+	if !ok || client.Addr == nil || !cidr.ContainsAddr(s.Config.TrustedSubnet, client.Addr.String()) {
+		return nil, status.Error(codes.PermissionDenied, "untrusted subnet")
+	}
+
+	filter := models.StatsFilter{
+		Top: int(in.GetTop()),
+	}
+	if in.HasFrom() {
+		filter.From = in.GetFrom().AsTime()
+	}
+	if in.HasTo() {
+		filter.To = in.GetTo().AsTime()
+	}
+	if filter.Top < 0 {
+		return nil, status.Error(codes.InvalidArgument, constants.ErrorInvalidStatsRange.Error())
+	}
+
+	stats, err := s.URLStats.GetStats(ctx, filter)
 	if err != nil {
+		if errors.Is(err, constants.ErrorInvalidStatsRange) {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+		if limits.IsTimeout(err) {
+			return nil, status.Error(codes.Unavailable, "server unavailable")
+		}
 		return nil, status.Error(codes.Internal, "internal error")
 	}
 
+	return convertStats(stats), nil
+}
+
+// convertStats - преобразование статистики в protobuf-сообщение.
+func convertStats(stats *models.Stats) *pb.StatsResponse {
+	response := pb.StatsResponse_builder{
+		Urls:         proto.Int32(int32(stats.CountURL)),
+		Users:        proto.Int32(int32(stats.CountUsers)),
+		ActiveUrls:   proto.Int32(int32(stats.CountActive)),
+		DeletedUrls:  proto.Int32(int32(stats.CountDeleted)),
+		StorageBytes: proto.Int64(stats.StorageBytes),
+		From:         timestamppb.New(stats.From),
+		To:           timestamppb.New(stats.To),
+	}.Build()
+
+	perDay := make([]*pb.DailyStats, 0, len(stats.PerDay))
+	for _, day := range stats.PerDay {
+		perDay = append(perDay, pb.DailyStats_builder{
+			Date:  proto.String(day.Date),
+			Count: proto.Int32(int32(day.Count)),
+		}.Build())
+	}
+	response.SetPerDay(perDay)
+
+	topURLs := make([]*pb.URLClicks, 0, len(stats.TopURLs))
+	for _, url := range stats.TopURLs {
+		topURLs = append(topURLs, pb.URLClicks_builder{
+			ShortUrl:    proto.String(url.Short),
+			OriginalUrl: proto.String(url.Original),
+			Clicks:      proto.Int64(url.Clicks),
+		}.Build())
+	}
+	response.SetTopUrls(topURLs)
+
+	topUsers := make([]*pb.UserStats, 0, len(stats.TopUsers))
+	for _, user := range stats.TopUsers {
+		topUsers = append(topUsers, pb.UserStats_builder{
+			UserId: proto.String(user.UUID),
+			Urls:   proto.Int32(int32(user.Count)),
+		}.Build())
+	}
+	response.SetTopUsers(topUsers)
+
+	return response
+}
+
+// convertURLs - преобразование URL пользователя в protobuf-сообщения.
+func (s *ShortenerServiceServer) convertURLs(urls []models.URLBase) []*pb.URLData {
 	var urlsOut []*pb.URLData
 	for _, url := range urls {
-		shortURL := toolkit.AddBaseURLToResponse(s.Config.BaseURL, url.Short)
+		shortURL := toolkit.AddBaseURLToResponse(s.Domains.BaseURL(url.Domain), url.Short)
 		urlOut := pb.URLData_builder{
 			ShortUrl:    &shortURL,
 			OriginalUrl: &url.Original,
+			Tags:        url.Tags,
 		}.Build()
+		if url.Folder != "" {
+			urlOut.SetFolder(url.Folder)
+		}
+		if url.Title != "" {
+			urlOut.SetTitle(url.Title)
+		}
+		if url.Description != "" {
+			urlOut.SetDescription(url.Description)
+		}
+		if len(url.Destinations) > 0 {
+			urlOut.SetDestinations(convertDestinations(url.Destinations))
+		}
+		if len(url.Rules) > 0 {
+			urlOut.SetRules(convertRules(url.Rules))
+		}
 		urlsOut = append(urlsOut, urlOut)
 	}
+	return urlsOut
+}
 
-	response.SetUrl(urlsOut)
+// convertDestinations - преобразование адресов назначения в protobuf-сообщения.
+func convertDestinations(destinations []models.Destination) []*pb.Destination {
+	destinationsOut := make([]*pb.Destination, 0, len(destinations))
+	for _, destination := range destinations {
+		weight := int32(destination.Weight)
+		destinationsOut = append(destinationsOut, pb.Destination_builder{
+			Url:    &destination.URL,
+			Weight: &weight,
+			Clicks: &destination.Clicks,
+		}.Build())
+	}
+	return destinationsOut
+}
 
-	return &response, nil
+// convertRules - преобразование правил перенаправления в protobuf-сообщения.
+func convertRules(rules []models.TargetRule) []*pb.TargetRule {
+	rulesOut := make([]*pb.TargetRule, 0, len(rules))
+	for _, rule := range rules {
+		rulesOut = append(rulesOut, pb.TargetRule_builder{
+			Platform: &rule.Platform,
+			Language: &rule.Language,
+			Url:      &rule.URL,
+		}.Build())
+	}
+	return rulesOut
 }
 
-// Run - запуск gRPC-сервера.
-func Run(ctx context.Context, config *config.Config, repo usecase.URLRepository, svc *service.Service) error {
-	listen, err := net.Listen("tcp", config.ServerAddress)
+// Run - запуск gRPC-сервера на address до отмены ctx.
+// Возвращает ошибку, если сервер не удалось запустить или он остановился с ошибкой.
+// Хранилище закрывает вызывающая сторона после остановки всех серверов.
+func Run(ctx context.Context, config *config.Config, address string, useCase *usecase.URLUseCase) error {
+	opts := []grpc.ServerOption{
+		grpc.ChainUnaryInterceptor(authn.Interceptor(config.JWTSecret)),
+		grpc.ChainStreamInterceptor(authn.StreamInterceptor(config.JWTSecret)),
+	}
+	// токены передаются в метаданных, поэтому при EnableHTTPS gRPC-сервер также работает по TLS
+	if config.EnableHTTPS {
+		tlsConfig, err := newTLSConfig(config)
+		if err != nil {
+			return err
+		}
+		opts = append(opts, grpc.Creds(credentials.NewTLS(tlsConfig)))
+	}
+
+	listen, err := net.Listen("tcp", address)
 	if err != nil {
-		logger.Sugar.Errorf("failed initializing listener, error - %w", err)
-		os.Exit(1)
+		return fmt.Errorf("path: internal/server/grpc/grpc.go, func Run(), failed initializing listener: %w", err)
 	}
 
-	server := grpc.NewServer(grpc.UnaryInterceptor(authn.Interceptor(config.JWTSecret)))
+	server := grpc.NewServer(opts...)
 
-	useCase := usecase.NewURLUseCase(repo, svc)
 	pb.RegisterShortenerServiceServer(server, NewShortenerServiceServer(useCase, config))
 
-	logger.Sugar.Info("gRPC-server has started")
+	healthServer := health.NewServer()
+	healthpb.RegisterHealthServer(server, healthServer)
+	go WatchHealth(ctx, healthServer, useCase, healthPingInterval)
+
+	if config.GRPCReflection {
+		reflection.Register(server)
+	}
 
+	errCh := make(chan error, 1)
 	go func() {
-		if err := server.Serve(listen); err != nil {
-			logger.Sugar.Errorf("gRPC-server failed, error - %w", err)
-			os.Exit(1)
-		}
+		errCh <- server.Serve(listen)
 	}()
 
-	<-ctx.Done()
+	logger.Sugar.Infow("gRPC-server has started", "address", address)
+
+	select {
+	case err = <-errCh:
+		return fmt.Errorf("path: internal/server/grpc/grpc.go, func Run(), gRPC-server failed: %w", err)
+	case <-ctx.Done():
+	}
 
-	shutDownCtx, cancel := context.WithTimeout(context.Background(), time.Second*10)
-	defer cancel()
+	// клиенты проверки состояния получают NOT_SERVING до остановки сервера
+	healthServer.Shutdown()
 
+	stopped := make(chan struct{})
 	go func() {
 		server.GracefulStop()
-		if err = repo.Close(); err != nil {
-			logger.Sugar.Errorf("failed closing database: %w", err)
-		}
+		close(stopped)
 	}()
-	<-shutDownCtx.Done()
 
+	select {
+	case <-stopped:
+	case <-time.After(shutdownTimeout):
+		// незавершенные потоковые вызовы прерываются
+		server.Stop()
+	}
 	return nil
 }
@@ -2,10 +2,15 @@ package grpcserver_test
 
 import (
 	"context"
+	"errors"
+	"io"
 	"log"
+	"net"
 	"os"
 	"reflect"
+	"strconv"
 	"testing"
+	"time"
 
 	"github.com/Di-nis/shortener-url/internal/config"
 	"github.com/Di-nis/shortener-url/internal/constants"
@@ -15,9 +20,12 @@ import (
 	grpcserver "github.com/Di-nis/shortener-url/internal/server/grpc"
 	"github.com/Di-nis/shortener-url/internal/toolkit"
 	"github.com/golang/mock/gomock"
+	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/peer"
 	"google.golang.org/grpc/status"
-	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 var (
@@ -76,6 +84,11 @@ func setEnv() {
 	if err != nil {
 		log.Fatalf("set env ENABLE_GRPC failed: %v", err)
 	}
+
+	err = os.Setenv("TRUSTED_SUBNET", "192.168.0.0/24")
+	if err != nil {
+		log.Fatalf("set env TRUSTED_SUBNET failed: %v", err)
+	}
 }
 
 func TestMain(m *testing.M) {
@@ -87,11 +100,11 @@ func TestMain(m *testing.M) {
 	os.Exit(m.Run())
 }
 
-func newTestService(t *testing.T) (*grpcserver.ShortenerServiceServer, *mocks.MockURLUseCase) {
+func newTestService(t *testing.T) (*grpcserver.ShortenerServiceServer, *mocks.MockGRPCUseCase) {
 	t.Helper()
 
 	ctrl := gomock.NewController(t)
-	mockUseCase := mocks.NewMockURLUseCase(ctrl)
+	mockUseCase := mocks.NewMockGRPCUseCase(ctrl)
 
 	s := grpcserver.NewShortenerServiceServer(mockUseCase, cfg)
 	return s, mockUseCase
@@ -102,14 +115,14 @@ func TestShortenerServiceServer_ShortenURL(t *testing.T) {
 
 	tests := []struct {
 		name    string
-		mock    func(*mocks.MockURLUseCase)
+		mock    func(*mocks.MockGRPCUseCase)
 		in      *pb.URLShortenRequest
 		want    *pb.URLShortenResponse
 		wantErr error
 	}{
 		{
 			name: "URL создан успешно",
-			mock: func(mock *mocks.MockURLUseCase) {
+			mock: func(mock *mocks.MockGRPCUseCase) {
 				mock.EXPECT().CreateURLOrdinary(gomock.Any(), urlIn1).Return(urlOut1, nil)
 			},
 			in: pb.URLShortenRequest_builder{
@@ -122,7 +135,7 @@ func TestShortenerServiceServer_ShortenURL(t *testing.T) {
 		},
 		{
 			name: "URL уже существует",
-			mock: func(mock *mocks.MockURLUseCase) {
+			mock: func(mock *mocks.MockGRPCUseCase) {
 				mock.EXPECT().CreateURLOrdinary(gomock.Any(), urlIn2).Return(urlOut2, constants.ErrorURLAlreadyExist)
 			},
 			in: pb.URLShortenRequest_builder{
@@ -159,15 +172,15 @@ func TestShortenerServiceServer_ShortenURL(t *testing.T) {
 func TestShortenerServiceServer_ExpandURL(t *testing.T) {
 	tests := []struct {
 		name    string
-		mock    func(*mocks.MockURLUseCase)
+		mock    func(*mocks.MockGRPCUseCase)
 		in      *pb.URLExpandRequest
 		want    *pb.URLExpandResponse
 		wantErr error
 	}{
 		{
 			name: "Оригинальный URL получен",
-			mock: func(mock *mocks.MockURLUseCase) {
-				mock.EXPECT().GetOriginalURL(gomock.Any(), urlShort1).Return(urlOriginal1, nil)
+			mock: func(mock *mocks.MockGRPCUseCase) {
+				mock.EXPECT().GetOriginalURL(gomock.Any(), "", urlShort1).Return(urlOriginal1, nil)
 			},
 			in: pb.URLExpandRequest_builder{
 				Id: &urlShort1,
@@ -179,8 +192,8 @@ func TestShortenerServiceServer_ExpandURL(t *testing.T) {
 		},
 		{
 			name: "URL не найден",
-			mock: func(mock *mocks.MockURLUseCase) {
-				mock.EXPECT().GetOriginalURL(gomock.Any(), urlShort1).Return("", constants.ErrorURLNotExist)
+			mock: func(mock *mocks.MockGRPCUseCase) {
+				mock.EXPECT().GetOriginalURL(gomock.Any(), "", urlShort1).Return("", constants.ErrorURLNotExist)
 			},
 			in: pb.URLExpandRequest_builder{
 				Id: &urlShort1,
@@ -190,8 +203,8 @@ func TestShortenerServiceServer_ExpandURL(t *testing.T) {
 		},
 		{
 			name: "URL ранее был удален",
-			mock: func(mock *mocks.MockURLUseCase) {
-				mock.EXPECT().GetOriginalURL(gomock.Any(), urlShort1).Return("", constants.ErrorURLAlreadyDeleted)
+			mock: func(mock *mocks.MockGRPCUseCase) {
+				mock.EXPECT().GetOriginalURL(gomock.Any(), "", urlShort1).Return("", constants.ErrorURLAlreadyDeleted)
 			},
 			in: pb.URLExpandRequest_builder{
 				Id: &urlShort1,
@@ -199,6 +212,28 @@ func TestShortenerServiceServer_ExpandURL(t *testing.T) {
 			want:    nil,
 			wantErr: status.Errorf(codes.NotFound, `URL %s already deleted`, urlShort1),
 		},
+		{
+			name: "URL еще не активен",
+			mock: func(mock *mocks.MockGRPCUseCase) {
+				mock.EXPECT().GetOriginalURL(gomock.Any(), "", urlShort1).Return("", constants.ErrorURLNotYetActive)
+			},
+			in: pb.URLExpandRequest_builder{
+				Id: &urlShort1,
+			}.Build(),
+			want:    nil,
+			wantErr: status.Errorf(codes.NotFound, `URL %s is not active yet`, urlShort1),
+		},
+		{
+			name: "лимит переходов исчерпан",
+			mock: func(mock *mocks.MockGRPCUseCase) {
+				mock.EXPECT().GetOriginalURL(gomock.Any(), "", urlShort1).Return("", constants.ErrorURLClicksExhausted)
+			},
+			in: pb.URLExpandRequest_builder{
+				Id: &urlShort1,
+			}.Build(),
+			want:    nil,
+			wantErr: status.Errorf(codes.NotFound, `URL %s click limit exhausted`, urlShort1),
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -230,17 +265,17 @@ func TestShortenerServiceServer_ListUserURLs(t *testing.T) {
 
 	tests := []struct {
 		name    string
-		mock    func(*mocks.MockURLUseCase)
-		in      *emptypb.Empty
+		mock    func(*mocks.MockGRPCUseCase)
+		in      *pb.ListUserURLsRequest
 		want    *pb.UserURLsResponse
 		wantErr error
 	}{
 		{
 			name: "Список URL успешно получен",
-			mock: func(mock *mocks.MockURLUseCase) {
-				mock.EXPECT().GetAllURLs(gomock.Any(), UUID).Return(urlsOut, nil)
+			mock: func(mock *mocks.MockGRPCUseCase) {
+				mock.EXPECT().GetAllURLs(gomock.Any(), UUID, models.URLFilter{}).Return(urlsOut, nil)
 			},
-			in: &emptypb.Empty{},
+			in: &pb.ListUserURLsRequest{},
 			want: pb.UserURLsResponse_builder{
 				Url: []*pb.URLData{
 					pb.URLData_builder{ShortUrl: &fullUrlShort1, OriginalUrl: &urlOriginal1}.Build(),
@@ -274,3 +309,661 @@ func TestShortenerServiceServer_ListUserURLs(t *testing.T) {
 		})
 	}
 }
+
+// userURLsStream - поток ответа StreamUserURLs, сохраняющий отправленные части.
+type userURLsStream struct {
+	grpc.ServerStream
+	ctx    context.Context
+	chunks []*pb.UserURLsChunk
+	// cancel - отмена вызова клиентом после отправки первой части, если задано
+	cancel context.CancelFunc
+}
+
+func (s *userURLsStream) Context() context.Context {
+	return s.ctx
+}
+
+func (s *userURLsStream) Send(chunk *pb.UserURLsChunk) error {
+	s.chunks = append(s.chunks, chunk)
+	if s.cancel != nil {
+		s.cancel()
+	}
+	return nil
+}
+
+func TestShortenerServiceServer_StreamUserURLs(t *testing.T) {
+	fullUrlShort1 := toolkit.AddBaseURLToResponse(cfg.BaseURL, urlShort1)
+	fullUrlShort2 := toolkit.AddBaseURLToResponse(cfg.BaseURL, urlShort2)
+
+	chunk1 := pb.UserURLsChunk_builder{
+		Url:         []*pb.URLData{pb.URLData_builder{ShortUrl: &fullUrlShort1, OriginalUrl: &urlOriginal1}.Build()},
+		ResumeToken: proto.String("token-1"),
+	}.Build()
+	chunk2 := pb.UserURLsChunk_builder{
+		Url: []*pb.URLData{pb.URLData_builder{ShortUrl: &fullUrlShort2, OriginalUrl: &urlOriginal2}.Build()},
+	}.Build()
+
+	filter := models.URLFilter{Folder: "sport"}
+
+	tests := []struct {
+		name    string
+		mock    func(*mocks.MockGRPCUseCase)
+		in      *pb.StreamUserURLsRequest
+		cancel  bool
+		want    []*pb.UserURLsChunk
+		wantErr error
+	}{
+		{
+			name: "URL получены двумя частями",
+			mock: func(mock *mocks.MockGRPCUseCase) {
+				gomock.InOrder(
+					mock.EXPECT().GetURLsPage(gomock.Any(), UUID, filter, "", 1).
+						Return(models.URLPage{URLs: []models.URLBase{urlOut1}, NextToken: "token-1"}, nil),
+					mock.EXPECT().GetURLsPage(gomock.Any(), UUID, filter, "token-1", 1).
+						Return(models.URLPage{URLs: []models.URLBase{urlOut2}}, nil),
+				)
+			},
+			in:      pb.StreamUserURLsRequest_builder{Folder: proto.String("sport"), PageSize: proto.Int32(1)}.Build(),
+			want:    []*pb.UserURLsChunk{chunk1, chunk2},
+			wantErr: nil,
+		},
+		{
+			name: "возобновление с токена продолжения",
+			mock: func(mock *mocks.MockGRPCUseCase) {
+				mock.EXPECT().GetURLsPage(gomock.Any(), UUID, filter, "token-1", 1).
+					Return(models.URLPage{URLs: []models.URLBase{urlOut2}}, nil)
+			},
+			in: pb.StreamUserURLsRequest_builder{
+				Folder:      proto.String("sport"),
+				PageSize:    proto.Int32(1),
+				ResumeToken: proto.String("token-1"),
+			}.Build(),
+			want:    []*pb.UserURLsChunk{chunk2},
+			wantErr: nil,
+		},
+		{
+			name: "отмена клиентом после первой части",
+			mock: func(mock *mocks.MockGRPCUseCase) {
+				mock.EXPECT().GetURLsPage(gomock.Any(), UUID, filter, "", 1).
+					Return(models.URLPage{URLs: []models.URLBase{urlOut1}, NextToken: "token-1"}, nil)
+			},
+			in:      pb.StreamUserURLsRequest_builder{Folder: proto.String("sport"), PageSize: proto.Int32(1)}.Build(),
+			cancel:  true,
+			want:    []*pb.UserURLsChunk{chunk1},
+			wantErr: status.Error(codes.Canceled, context.Canceled.Error()),
+		},
+		{
+			name: "некорректный токен продолжения",
+			mock: func(mock *mocks.MockGRPCUseCase) {
+				mock.EXPECT().GetURLsPage(gomock.Any(), UUID, filter, "bad", 0).
+					Return(models.URLPage{}, constants.ErrorInvalidPageToken)
+			},
+			in:      pb.StreamUserURLsRequest_builder{Folder: proto.String("sport"), ResumeToken: proto.String("bad")}.Build(),
+			want:    nil,
+			wantErr: status.Error(codes.InvalidArgument, "invalid resume token"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, mockUseCase := newTestService(t)
+			tt.mock(mockUseCase)
+
+			ctx, cancel := context.WithCancel(context.WithValue(context.Background(), constants.UserIDKey, UUID))
+			defer cancel()
+
+			stream := &userURLsStream{ctx: ctx}
+			if tt.cancel {
+				stream.cancel = cancel
+			}
+
+			gotErr := s.StreamUserURLs(tt.in, stream)
+
+			if !reflect.DeepEqual(stream.chunks, tt.want) {
+				t.Errorf("StreamUserURLs() = %v, want %v", stream.chunks, tt.want)
+			}
+			if status.Code(gotErr) != status.Code(tt.wantErr) {
+				t.Errorf("code = %v, want %v", status.Code(gotErr), status.Code(tt.wantErr))
+			}
+		})
+	}
+}
+
+func TestShortenerServiceServer_SearchUserURLs(t *testing.T) {
+	fullUrlShort1 := toolkit.AddBaseURLToResponse(cfg.BaseURL, urlShort1)
+
+	tests := []struct {
+		name    string
+		mock    func(*mocks.MockGRPCUseCase)
+		in      *pb.SearchUserURLsRequest
+		want    *pb.UserURLsResponse
+		wantErr error
+	}{
+		{
+			name: "URL найдены по хосту",
+			mock: func(mock *mocks.MockGRPCUseCase) {
+				lookup := models.URLLookup{Mode: models.LookupHost, Query: "khl.ru", Limit: 10}
+				mock.EXPECT().LookupURLs(gomock.Any(), UUID, lookup).Return([]models.URLBase{urlOut1}, nil)
+			},
+			in: pb.SearchUserURLsRequest_builder{
+				Mode:  proto.String(models.LookupHost),
+				Query: proto.String("khl.ru"),
+				Limit: proto.Int32(10),
+			}.Build(),
+			want: pb.UserURLsResponse_builder{
+				Url: []*pb.URLData{
+					pb.URLData_builder{ShortUrl: &fullUrlShort1, OriginalUrl: &urlOriginal1}.Build(),
+				},
+			}.Build(),
+			wantErr: nil,
+		},
+		{
+			name: "некорректный запрос поиска",
+			mock: func(mock *mocks.MockGRPCUseCase) {
+				mock.EXPECT().LookupURLs(gomock.Any(), UUID, gomock.Any()).Return(nil, constants.ErrorInvalidLookup)
+			},
+			in:      pb.SearchUserURLsRequest_builder{Mode: proto.String("regexp")}.Build(),
+			want:    nil,
+			wantErr: status.Error(codes.InvalidArgument, "invalid lookup"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, mockUseCase := newTestService(t)
+			tt.mock(mockUseCase)
+
+			ctx := context.WithValue(context.Background(), constants.UserIDKey, UUID)
+
+			got, gotErr := s.SearchUserURLs(ctx, tt.in)
+
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("SearchUserURLs() = %v, want %v", got, tt.want)
+			}
+			if status.Code(gotErr) != status.Code(tt.wantErr) {
+				t.Errorf("code = %v, want %v", status.Code(gotErr), status.Code(tt.wantErr))
+			}
+		})
+	}
+}
+
+func TestShortenerServiceServer_ShortenBatch(t *testing.T) {
+	fullUrlShort1 := toolkit.AddBaseURLToResponse(cfg.BaseURL, urlShort1)
+	fullUrlShort2 := toolkit.AddBaseURLToResponse(cfg.BaseURL, urlShort2)
+
+	urlsIn := []models.URLBase{
+		{UUID: UUID, URLID: "1", Original: urlOriginal1},
+		{UUID: UUID, URLID: "2", Original: urlOriginal2},
+	}
+
+	in := pb.ShortenBatchRequest_builder{
+		Items: []*pb.BatchItem{
+			pb.BatchItem_builder{CorrelationId: proto.String("1"), OriginalUrl: &urlOriginal1}.Build(),
+			pb.BatchItem_builder{CorrelationId: proto.String("2"), OriginalUrl: &urlOriginal2}.Build(),
+		},
+	}.Build()
+
+	tests := []struct {
+		name    string
+		mock    func(*mocks.MockGRPCUseCase)
+		in      *pb.ShortenBatchRequest
+		want    *pb.ShortenBatchResponse
+		wantErr error
+	}{
+		{
+			name: "Набор URL успешно сокращен",
+			mock: func(mock *mocks.MockGRPCUseCase) {
+				mock.EXPECT().CreateURLBatch(gomock.Any(), urlsIn).Return(urlsOut, nil)
+			},
+			in: in,
+			want: pb.ShortenBatchResponse_builder{
+				Results: []*pb.BatchResult{
+					pb.BatchResult_builder{CorrelationId: proto.String("1"), ShortUrl: &fullUrlShort1}.Build(),
+					pb.BatchResult_builder{CorrelationId: proto.String("2"), ShortUrl: &fullUrlShort2}.Build(),
+				},
+			}.Build(),
+			wantErr: nil,
+		},
+		{
+			name: "URL уже существует",
+			mock: func(mock *mocks.MockGRPCUseCase) {
+				mock.EXPECT().CreateURLBatch(gomock.Any(), urlsIn).Return(nil, constants.ErrorURLAlreadyExist)
+			},
+			in:      in,
+			want:    nil,
+			wantErr: status.Error(codes.AlreadyExists, "URL already exist"),
+		},
+		{
+			name:    "пустой набор URL",
+			mock:    func(mock *mocks.MockGRPCUseCase) {},
+			in:      &pb.ShortenBatchRequest{},
+			want:    nil,
+			wantErr: status.Error(codes.InvalidArgument, "empty batch"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, mockUseCase := newTestService(t)
+			tt.mock(mockUseCase)
+
+			ctx := context.WithValue(context.Background(), constants.UserIDKey, UUID)
+
+			got, gotErr := s.ShortenBatch(ctx, tt.in)
+
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ShortenBatch() = %v, want %v", got, tt.want)
+			}
+			if status.Code(gotErr) != status.Code(tt.wantErr) {
+				t.Errorf("code = %v, want %v", status.Code(gotErr), status.Code(tt.wantErr))
+			}
+		})
+	}
+}
+
+// shortenStream - двунаправленный поток ShortenStream с заранее заданными URL клиента.
+type shortenStream struct {
+	grpc.ServerStream
+	ctx     context.Context
+	items   []*pb.BatchItem
+	results []*pb.ShortenStreamResult
+}
+
+func (s *shortenStream) Context() context.Context {
+	return s.ctx
+}
+
+func (s *shortenStream) Recv() (*pb.BatchItem, error) {
+	if len(s.items) == 0 {
+		return nil, io.EOF
+	}
+	item := s.items[0]
+	s.items = s.items[1:]
+	return item, nil
+}
+
+func (s *shortenStream) Send(result *pb.ShortenStreamResult) error {
+	s.results = append(s.results, result)
+	return nil
+}
+
+func TestShortenerServiceServer_ShortenStream(t *testing.T) {
+	fullUrlShort1 := toolkit.AddBaseURLToResponse(cfg.BaseURL, urlShort1)
+
+	items := make([]*pb.BatchItem, 0, 501)
+	for i := range 501 {
+		items = append(items, pb.BatchItem_builder{
+			CorrelationId: proto.String(strconv.Itoa(i)),
+			OriginalUrl:   &urlOriginal1,
+		}.Build())
+	}
+
+	tests := []struct {
+		name        string
+		items       []*pb.BatchItem
+		mock        func(*mocks.MockGRPCUseCase)
+		wantResults int
+		want        *pb.ShortenStreamResult
+		wantErr     error
+	}{
+		{
+			name:  "URL записываются пакетами, результат по каждому URL",
+			items: items,
+			mock: func(mock *mocks.MockGRPCUseCase) {
+				results := func(ctx context.Context, urls []models.URLBase) ([]models.BulkResult, error) {
+					bulk := make([]models.BulkResult, 0, len(urls))
+					for _, url := range urls {
+						bulk = append(bulk, models.BulkResult{URLID: url.URLID, Short: urlShort1, Status: models.BulkCreated})
+					}
+					return bulk, nil
+				}
+				gomock.InOrder(
+					mock.EXPECT().CreateURLBulk(gomock.Any(), gomock.Len(500)).DoAndReturn(results),
+					mock.EXPECT().CreateURLBulk(gomock.Any(), gomock.Len(1)).DoAndReturn(results),
+				)
+			},
+			wantResults: 501,
+			want: pb.ShortenStreamResult_builder{
+				CorrelationId: proto.String("0"),
+				ShortUrl:      &fullUrlShort1,
+				Status:        proto.String(models.BulkCreated),
+			}.Build(),
+			wantErr: nil,
+		},
+		{
+			name:  "некорректный URL",
+			items: []*pb.BatchItem{pb.BatchItem_builder{CorrelationId: proto.String("1")}.Build()},
+			mock: func(mock *mocks.MockGRPCUseCase) {
+				mock.EXPECT().CreateURLBulk(gomock.Any(), gomock.Len(1)).
+					Return([]models.BulkResult{{URLID: "1", Status: models.BulkInvalid}}, nil)
+			},
+			wantResults: 1,
+			want: pb.ShortenStreamResult_builder{
+				CorrelationId: proto.String("1"),
+				Status:        proto.String(models.BulkInvalid),
+			}.Build(),
+			wantErr: nil,
+		},
+		{
+			name:        "пустой поток",
+			items:       nil,
+			mock:        func(mock *mocks.MockGRPCUseCase) {},
+			wantResults: 0,
+			want:        nil,
+			wantErr:     nil,
+		},
+		{
+			name:  "пользователь заблокирован",
+			items: items[:1],
+			mock: func(mock *mocks.MockGRPCUseCase) {
+				mock.EXPECT().CreateURLBulk(gomock.Any(), gomock.Len(1)).Return(nil, constants.ErrorUserBlocked)
+			},
+			wantResults: 0,
+			want:        nil,
+			wantErr:     status.Error(codes.PermissionDenied, "user is blocked"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, mockUseCase := newTestService(t)
+			tt.mock(mockUseCase)
+
+			ctx := context.WithValue(context.Background(), constants.UserIDKey, UUID)
+			stream := &shortenStream{ctx: ctx, items: tt.items}
+
+			gotErr := s.ShortenStream(stream)
+
+			if len(stream.results) != tt.wantResults {
+				t.Fatalf("ShortenStream() results = %d, want %d", len(stream.results), tt.wantResults)
+			}
+			if tt.want != nil && !proto.Equal(stream.results[0], tt.want) {
+				t.Errorf("ShortenStream() = %v, want %v", stream.results[0], tt.want)
+			}
+			if status.Code(gotErr) != status.Code(tt.wantErr) {
+				t.Errorf("code = %v, want %v", status.Code(gotErr), status.Code(tt.wantErr))
+			}
+		})
+	}
+}
+
+func TestShortenerServiceServer_DeleteUserURLs(t *testing.T) {
+	urlsIn := []models.URLBase{
+		{Short: urlShort1, UUID: UUID},
+		{Short: urlShort2, UUID: UUID},
+	}
+
+	tests := []struct {
+		name    string
+		mock    func(*mocks.MockGRPCUseCase)
+		wantErr error
+	}{
+		{
+			name: "URL успешно удалены",
+			mock: func(mock *mocks.MockGRPCUseCase) {
+				mock.EXPECT().DeleteURLs(gomock.Any(), urlsIn).Return(nil)
+			},
+			wantErr: nil,
+		},
+		{
+			name: "ошибка удаления",
+			mock: func(mock *mocks.MockGRPCUseCase) {
+				mock.EXPECT().DeleteURLs(gomock.Any(), urlsIn).Return(errors.New("database error"))
+			},
+			wantErr: status.Error(codes.Internal, "internal error"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, mockUseCase := newTestService(t)
+			tt.mock(mockUseCase)
+
+			ctx := context.WithValue(context.Background(), constants.UserIDKey, UUID)
+			in := pb.DeleteUserURLsRequest_builder{ShortUrls: []string{urlShort1, urlShort2}}.Build()
+
+			_, gotErr := s.DeleteUserURLs(ctx, in)
+			if status.Code(gotErr) != status.Code(tt.wantErr) {
+				t.Errorf("code = %v, want %v", status.Code(gotErr), status.Code(tt.wantErr))
+			}
+		})
+	}
+}
+
+func TestShortenerServiceServer_Domains(t *testing.T) {
+	domain := "go.brand-a.com"
+	brandShort1 := "https://" + domain + "/" + urlShort1
+
+	urlBranded1 := urlOut1
+	urlBranded1.Domain = domain
+
+	tests := []struct {
+		name    string
+		mock    func(*mocks.MockGRPCUseCase)
+		call    func(context.Context, *grpcserver.ShortenerServiceServer) (string, error)
+		want    string
+		wantErr error
+	}{
+		{
+			name: "создание URL в домене",
+			mock: func(mock *mocks.MockGRPCUseCase) {
+				mock.EXPECT().CreateURLOrdinary(gomock.Any(), models.URLJSON{UUID: UUID, Original: urlOriginal1, Domain: domain}).Return(urlBranded1, nil)
+			},
+			call: func(ctx context.Context, s *grpcserver.ShortenerServiceServer) (string, error) {
+				resp, err := s.ShortenURL(ctx, pb.URLShortenRequest_builder{Url: &urlOriginal1, Domain: proto.String("GO.BRAND-A.COM")}.Build())
+				return resp.GetResult(), err
+			},
+			want: brandShort1,
+		},
+		{
+			name: "получение оригинального URL в домене",
+			mock: func(mock *mocks.MockGRPCUseCase) {
+				mock.EXPECT().GetOriginalURL(gomock.Any(), domain, urlShort1).Return(urlOriginal1, nil)
+			},
+			call: func(ctx context.Context, s *grpcserver.ShortenerServiceServer) (string, error) {
+				resp, err := s.ExpandURL(ctx, pb.URLExpandRequest_builder{Id: &urlShort1, Domain: &domain}.Build())
+				return resp.GetResult(), err
+			},
+			want: urlOriginal1,
+		},
+		{
+			name: "пакет URL в домене",
+			mock: func(mock *mocks.MockGRPCUseCase) {
+				urlsIn := []models.URLBase{{UUID: UUID, URLID: "1", Original: urlOriginal1, Domain: domain}}
+				mock.EXPECT().CreateURLBatch(gomock.Any(), urlsIn).Return([]models.URLBase{urlBranded1}, nil)
+			},
+			call: func(ctx context.Context, s *grpcserver.ShortenerServiceServer) (string, error) {
+				item := pb.BatchItem_builder{CorrelationId: proto.String("1"), OriginalUrl: &urlOriginal1, Domain: &domain}.Build()
+				resp, err := s.ShortenBatch(ctx, pb.ShortenBatchRequest_builder{Items: []*pb.BatchItem{item}}.Build())
+				if err != nil {
+					return "", err
+				}
+				return resp.GetResults()[0].GetShortUrl(), nil
+			},
+			want: brandShort1,
+		},
+		{
+			name: "поток URL в домене",
+			mock: func(mock *mocks.MockGRPCUseCase) {
+				urlsIn := []models.URLBase{{UUID: UUID, URLID: "1", Original: urlOriginal1, Domain: domain}}
+				mock.EXPECT().CreateURLBulk(gomock.Any(), urlsIn).
+					Return([]models.BulkResult{{URLID: "1", Domain: domain, Short: urlShort1, Status: models.BulkCreated}}, nil)
+			},
+			call: func(ctx context.Context, s *grpcserver.ShortenerServiceServer) (string, error) {
+				item := pb.BatchItem_builder{CorrelationId: proto.String("1"), OriginalUrl: &urlOriginal1, Domain: &domain}.Build()
+				stream := &shortenStream{ctx: ctx, items: []*pb.BatchItem{item}}
+				if err := s.ShortenStream(stream); err != nil {
+					return "", err
+				}
+				return stream.results[0].GetShortUrl(), nil
+			},
+			want: brandShort1,
+		},
+		{
+			name: "удаление URL в домене",
+			mock: func(mock *mocks.MockGRPCUseCase) {
+				mock.EXPECT().DeleteURLs(gomock.Any(), []models.URLBase{{Short: urlShort1, UUID: UUID, Domain: domain}}).Return(nil)
+			},
+			call: func(ctx context.Context, s *grpcserver.ShortenerServiceServer) (string, error) {
+				_, err := s.DeleteUserURLs(ctx, pb.DeleteUserURLsRequest_builder{ShortUrls: []string{urlShort1}, Domain: &domain}.Build())
+				return "", err
+			},
+		},
+		{
+			name: "создание URL в необслуживаемом домене",
+			mock: func(mock *mocks.MockGRPCUseCase) {},
+			call: func(ctx context.Context, s *grpcserver.ShortenerServiceServer) (string, error) {
+				resp, err := s.ShortenURL(ctx, pb.URLShortenRequest_builder{Url: &urlOriginal1, Domain: proto.String("evil.example.com")}.Build())
+				return resp.GetResult(), err
+			},
+			wantErr: status.Error(codes.InvalidArgument, "unknown domain"),
+		},
+		{
+			name: "удаление URL в необслуживаемом домене",
+			mock: func(mock *mocks.MockGRPCUseCase) {},
+			call: func(ctx context.Context, s *grpcserver.ShortenerServiceServer) (string, error) {
+				_, err := s.DeleteUserURLs(ctx, pb.DeleteUserURLsRequest_builder{ShortUrls: []string{urlShort1}, Domain: proto.String("evil.example.com")}.Build())
+				return "", err
+			},
+			wantErr: status.Error(codes.InvalidArgument, "unknown domain"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			mockUseCase := mocks.NewMockGRPCUseCase(ctrl)
+			tt.mock(mockUseCase)
+
+			domainCfg := *cfg
+			domainCfg.Domains = []string{"https://" + domain}
+			s := grpcserver.NewShortenerServiceServer(mockUseCase, &domainCfg)
+
+			ctx := context.WithValue(context.Background(), constants.UserIDKey, UUID)
+			got, gotErr := tt.call(ctx, s)
+			if status.Code(gotErr) != status.Code(tt.wantErr) {
+				t.Fatalf("code = %v, want %v", status.Code(gotErr), status.Code(tt.wantErr))
+			}
+			if got != tt.want {
+				t.Errorf("result = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestShortenerServiceServer_Ping(t *testing.T) {
+	tests := []struct {
+		name    string
+		pingErr error
+		wantErr error
+	}{
+		{
+			name:    "хранилище доступно",
+			pingErr: nil,
+			wantErr: nil,
+		},
+		{
+			name:    "хранилище недоступно",
+			pingErr: errors.New("connection refused"),
+			wantErr: status.Error(codes.Unavailable, "storage unavailable"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, mockUseCase := newTestService(t)
+			mockUseCase.EXPECT().Ping(gomock.Any()).Return(tt.pingErr)
+
+			_, gotErr := s.Ping(context.Background(), &pb.PingRequest{})
+			if status.Code(gotErr) != status.Code(tt.wantErr) {
+				t.Errorf("code = %v, want %v", status.Code(gotErr), status.Code(tt.wantErr))
+			}
+		})
+	}
+}
+
+func TestShortenerServiceServer_GetStats(t *testing.T) {
+	from := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2025, 1, 2, 0, 0, 0, 0, time.UTC)
+
+	stats := &models.Stats{
+		CountURL:    2,
+		CountUsers:  1,
+		CountActive: 2,
+		From:        from,
+		To:          to,
+		PerDay:      []models.DailyStats{{Date: "2025-01-01", Count: 2}},
+		TopURLs:     []models.URLClicks{{Short: urlShort1, Original: urlOriginal1, Clicks: 5}},
+		TopUsers:    []models.UserStats{{UUID: UUID, Count: 2}},
+	}
+
+	tests := []struct {
+		name    string
+		addr    net.Addr
+		mock    func(*mocks.MockGRPCUseCase)
+		in      *pb.StatsRequest
+		want    *pb.StatsResponse
+		wantErr error
+	}{
+		{
+			name: "клиент из доверенной подсети",
+			addr: &net.TCPAddr{IP: net.ParseIP("192.168.0.10"), Port: 50000},
+			mock: func(mock *mocks.MockGRPCUseCase) {
+				filter := models.StatsFilter{From: from, To: to, Top: 5}
+				mock.EXPECT().GetStats(gomock.Any(), filter).Return(stats, nil)
+			},
+			in: pb.StatsRequest_builder{
+				From: timestamppb.New(from),
+				To:   timestamppb.New(to),
+				Top:  proto.Int32(5),
+			}.Build(),
+			want: pb.StatsResponse_builder{
+				Urls:         proto.Int32(2),
+				Users:        proto.Int32(1),
+				ActiveUrls:   proto.Int32(2),
+				DeletedUrls:  proto.Int32(0),
+				StorageBytes: proto.Int64(0),
+				From:         timestamppb.New(from),
+				To:           timestamppb.New(to),
+				PerDay: []*pb.DailyStats{
+					pb.DailyStats_builder{Date: proto.String("2025-01-01"), Count: proto.Int32(2)}.Build(),
+				},
+				TopUrls: []*pb.URLClicks{
+					pb.URLClicks_builder{ShortUrl: &urlShort1, OriginalUrl: &urlOriginal1, Clicks: proto.Int64(5)}.Build(),
+				},
+				TopUsers: []*pb.UserStats{
+					pb.UserStats_builder{UserId: &UUID, Urls: proto.Int32(2)}.Build(),
+				},
+			}.Build(),
+			wantErr: nil,
+		},
+		{
+			name:    "клиент вне доверенной подсети",
+			addr:    &net.TCPAddr{IP: net.ParseIP("10.0.0.1"), Port: 50000},
+			mock:    func(mock *mocks.MockGRPCUseCase) {},
+			in:      &pb.StatsRequest{},
+			want:    nil,
+			wantErr: status.Error(codes.PermissionDenied, "untrusted subnet"),
+		},
+		{
+			name: "некорректный период",
+			addr: &net.TCPAddr{IP: net.ParseIP("192.168.0.10"), Port: 50000},
+			mock: func(mock *mocks.MockGRPCUseCase) {
+				mock.EXPECT().GetStats(gomock.Any(), gomock.Any()).Return(nil, constants.ErrorInvalidStatsRange)
+			},
+			in:      pb.StatsRequest_builder{From: timestamppb.New(to), To: timestamppb.New(from)}.Build(),
+			want:    nil,
+			wantErr: status.Error(codes.InvalidArgument, constants.ErrorInvalidStatsRange.Error()),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, mockUseCase := newTestService(t)
+			tt.mock(mockUseCase)
+
+			ctx := peer.NewContext(context.Background(), &peer.Peer{Addr: tt.addr})
+
+			got, gotErr := s.GetStats(ctx, tt.in)
+
+			if !proto.Equal(got, tt.want) {
+				t.Errorf("GetStats() = %v, want %v", got, tt.want)
+			}
+			if status.Code(gotErr) != status.Code(tt.wantErr) {
+				t.Errorf("code = %v, want %v", status.Code(gotErr), status.Code(tt.wantErr))
+			}
+		})
+	}
+}
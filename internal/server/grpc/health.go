@@ -0,0 +1,47 @@
+package grpcserver
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/Di-nis/shortener-url/internal/constants"
+	pb "github.com/Di-nis/shortener-url/internal/proto"
+
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+// healthPingInterval - период проверки хранилища для статуса grpc.health.v1.
+const healthPingInterval = 5 * time.Second
+
+// WatchHealth - обновление статуса grpc.health.v1 по результату проверки соединения с хранилищем.
+// Хранилище без проверки соединения (файл или память) считается доступным.
+// Статус устанавливается для сервера в целом (пустое имя сервиса) и для ShortenerService.
+// Проверки выполняются с периодом interval до отмены ctx.
+func WatchHealth(ctx context.Context, healthServer *health.Server, pinger Pinger, interval time.Duration) {
+	update := func() {
+		pingCtx, cancel := context.WithTimeout(ctx, interval)
+		defer cancel()
+
+		servingStatus := healthpb.HealthCheckResponse_SERVING
+		if err := pinger.Ping(pingCtx); err != nil && !errors.Is(err, constants.ErrorMethodNotAllowed) {
+			servingStatus = healthpb.HealthCheckResponse_NOT_SERVING
+		}
+		healthServer.SetServingStatus("", servingStatus)
+		healthServer.SetServingStatus(pb.ShortenerService_ServiceDesc.ServiceName, servingStatus)
+	}
+
+	update()
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			update()
+		}
+	}
+}
@@ -0,0 +1,81 @@
+package grpcserver_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	pb "github.com/Di-nis/shortener-url/internal/proto"
+	"github.com/Di-nis/shortener-url/internal/repository"
+	grpcserver "github.com/Di-nis/shortener-url/internal/server/grpc"
+	"github.com/Di-nis/shortener-url/internal/usecase"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+func TestWatchHealth(t *testing.T) {
+	_, mockUseCase := newTestService(t)
+
+	gomock.InOrder(
+		mockUseCase.EXPECT().Ping(gomock.Any()).Return(nil),
+		mockUseCase.EXPECT().Ping(gomock.Any()).Return(errors.New("connection refused")).AnyTimes(),
+	)
+
+	healthServer := health.NewServer()
+	ctx, cancel := context.WithCancel(context.Background())
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		grpcserver.WatchHealth(ctx, healthServer, mockUseCase, 10*time.Millisecond)
+	}()
+
+	check := func(service string) healthpb.HealthCheckResponse_ServingStatus {
+		resp, err := healthServer.Check(context.Background(), &healthpb.HealthCheckRequest{Service: service})
+		if err != nil {
+			return healthpb.HealthCheckResponse_UNKNOWN
+		}
+		return resp.GetStatus()
+	}
+
+	// хранилище доступно при первой проверке и недоступно при следующих
+	require.Eventually(t, func() bool {
+		return check(pb.ShortenerService_ServiceDesc.ServiceName) == healthpb.HealthCheckResponse_NOT_SERVING
+	}, time.Second, 5*time.Millisecond)
+	assert.Equal(t, healthpb.HealthCheckResponse_NOT_SERVING, check(""))
+
+	cancel()
+	<-done
+
+	// после остановки сервера статус не восстанавливается
+	healthServer.Shutdown()
+	assert.Equal(t, healthpb.HealthCheckResponse_NOT_SERVING, check(""))
+}
+
+func TestWatchHealthFileMemory(t *testing.T) {
+	// хранилище в файле и памяти не поддерживает проверку соединения
+	useCase := usecase.NewURLUseCase(repository.NewRepoFileMemory(&repository.Storage{}), nil)
+
+	healthServer := health.NewServer()
+	ctx, cancel := context.WithCancel(context.Background())
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		grpcserver.WatchHealth(ctx, healthServer, useCase, 10*time.Millisecond)
+	}()
+
+	for _, service := range []string{"", pb.ShortenerService_ServiceDesc.ServiceName} {
+		require.Eventually(t, func() bool {
+			resp, err := healthServer.Check(context.Background(), &healthpb.HealthCheckRequest{Service: service})
+			return err == nil && resp.GetStatus() == healthpb.HealthCheckResponse_SERVING
+		}, time.Second, 5*time.Millisecond)
+	}
+
+	cancel()
+	<-done
+}
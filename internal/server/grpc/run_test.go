@@ -0,0 +1,73 @@
+package grpcserver_test
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/Di-nis/shortener-url/internal/mocks"
+	grpcserver "github.com/Di-nis/shortener-url/internal/server/grpc"
+	"github.com/Di-nis/shortener-url/internal/usecase"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+// freeAddress - получение свободного адреса для запуска сервера.
+func freeAddress(t *testing.T) string {
+	t.Helper()
+
+	listen, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	address := listen.Addr().String()
+	require.NoError(t, listen.Close())
+	return address
+}
+
+func TestRun(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockRepo := mocks.NewMockURLRepository(ctrl)
+	mockRepo.EXPECT().Ping(gomock.Any()).Return(nil).AnyTimes()
+	useCase := usecase.NewURLUseCase(mockRepo, nil)
+
+	t.Run("кейс 1, запуск и остановка по отмене контекста", func(t *testing.T) {
+		address := freeAddress(t)
+		ctx, cancel := context.WithCancel(context.Background())
+
+		errCh := make(chan error, 1)
+		go func() {
+			errCh <- grpcserver.Run(ctx, cfg, address, useCase)
+		}()
+
+		conn, err := grpc.NewClient(address, grpc.WithTransportCredentials(insecure.NewCredentials()))
+		require.NoError(t, err)
+		defer conn.Close()
+
+		client := healthpb.NewHealthClient(conn)
+		require.Eventually(t, func() bool {
+			resp, err := client.Check(context.Background(), &healthpb.HealthCheckRequest{})
+			return err == nil && resp.GetStatus() == healthpb.HealthCheckResponse_SERVING
+		}, 5*time.Second, 20*time.Millisecond)
+
+		cancel()
+		select {
+		case err = <-errCh:
+			assert.NoError(t, err)
+		case <-time.After(5 * time.Second):
+			t.Fatal("gRPC-server did not stop")
+		}
+	})
+
+	t.Run("кейс 2, адрес занят", func(t *testing.T) {
+		listen, err := net.Listen("tcp", "127.0.0.1:0")
+		require.NoError(t, err)
+		defer listen.Close()
+
+		err = grpcserver.Run(context.Background(), cfg, listen.Addr().String(), useCase)
+		assert.Error(t, err)
+	})
+}
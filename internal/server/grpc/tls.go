@@ -0,0 +1,44 @@
+package grpcserver
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"os"
+
+	"github.com/Di-nis/shortener-url/internal/config"
+	"github.com/Di-nis/shortener-url/internal/constants"
+)
+
+// newTLSConfig - настройка TLS gRPC-сервера по сертификату и ключу из конфигурации.
+// Если задан config.ClientCAFilePath, сервер требует сертификат клиента,
+// подписанный одним из корневых сертификатов файла (mTLS).
+func newTLSConfig(config *config.Config) (*tls.Config, error) {
+	cert, err := tls.LoadX509KeyPair(config.CertFilePath, config.KeyFilePath)
+	if err != nil {
+		return nil, fmt.Errorf("path: internal/server/grpc/tls.go, func newTLSConfig(), failed to load key pair: %w", err)
+	}
+
+	tlsConfig := &tls.Config{
+		Certificates: []tls.Certificate{cert},
+		MinVersion:   tls.VersionTLS12,
+	}
+
+	if config.ClientCAFilePath == "" {
+		return tlsConfig, nil
+	}
+
+	caPEM, err := os.ReadFile(config.ClientCAFilePath)
+	if err != nil {
+		return nil, fmt.Errorf("path: internal/server/grpc/tls.go, func newTLSConfig(), failed to read client CA: %w", err)
+	}
+
+	clientCAs := x509.NewCertPool()
+	if !clientCAs.AppendCertsFromPEM(caPEM) {
+		return nil, fmt.Errorf("path: internal/server/grpc/tls.go, func newTLSConfig(), %s: %w", config.ClientCAFilePath, constants.ErrorClientCANotValid)
+	}
+
+	tlsConfig.ClientCAs = clientCAs
+	tlsConfig.ClientAuth = tls.RequireAndVerifyClientCert
+	return tlsConfig, nil
+}
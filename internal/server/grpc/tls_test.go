@@ -0,0 +1,222 @@
+package grpcserver_test
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/Di-nis/shortener-url/internal/config"
+	"github.com/Di-nis/shortener-url/internal/constants"
+	"github.com/Di-nis/shortener-url/internal/mocks"
+	grpcserver "github.com/Di-nis/shortener-url/internal/server/grpc"
+	"github.com/Di-nis/shortener-url/internal/usecase"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+// testPKI - сгенерированные для теста сертификаты.
+type testPKI struct {
+	caPool     *x509.CertPool
+	caFile     string
+	serverCert string
+	serverKey  string
+	clientCert tls.Certificate
+}
+
+// issueCert - выпуск сертификата, подписанного parent (или самоподписанного, если parent не задан).
+func issueCert(t *testing.T, template *x509.Certificate, parent *x509.Certificate, parentKey *ecdsa.PrivateKey) (*x509.Certificate, *ecdsa.PrivateKey, []byte, []byte) {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	if parent == nil {
+		parent, parentKey = template, key
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, parent, &key.PublicKey, parentKey)
+	require.NoError(t, err)
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+
+	certPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+	keyPEM := pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER})
+	return cert, key, certPEM, keyPEM
+}
+
+// newTestPKI - генерация корневого сертификата, сертификата сервера для 127.0.0.1 и сертификата клиента.
+func newTestPKI(t *testing.T) testPKI {
+	t.Helper()
+
+	dir := t.TempDir()
+	notBefore := time.Now().Add(-time.Hour)
+	notAfter := time.Now().Add(time.Hour)
+
+	ca, caKey, caPEM, _ := issueCert(t, &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "test CA"},
+		NotBefore:             notBefore,
+		NotAfter:              notAfter,
+		IsCA:                  true,
+		KeyUsage:              x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+	}, nil, nil)
+
+	_, _, serverPEM, serverKeyPEM := issueCert(t, &x509.Certificate{
+		SerialNumber: big.NewInt(2),
+		Subject:      pkix.Name{CommonName: "server"},
+		NotBefore:    notBefore,
+		NotAfter:     notAfter,
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+		IPAddresses:  []net.IP{net.ParseIP("127.0.0.1")},
+	}, ca, caKey)
+
+	_, _, clientPEM, clientKeyPEM := issueCert(t, &x509.Certificate{
+		SerialNumber: big.NewInt(3),
+		Subject:      pkix.Name{CommonName: "client"},
+		NotBefore:    notBefore,
+		NotAfter:     notAfter,
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}, ca, caKey)
+
+	clientCert, err := tls.X509KeyPair(clientPEM, clientKeyPEM)
+	require.NoError(t, err)
+
+	pki := testPKI{
+		caPool:     x509.NewCertPool(),
+		caFile:     filepath.Join(dir, "ca.pem"),
+		serverCert: filepath.Join(dir, "server.pem"),
+		serverKey:  filepath.Join(dir, "server.key"),
+		clientCert: clientCert,
+	}
+	pki.caPool.AddCert(ca)
+
+	require.NoError(t, os.WriteFile(pki.caFile, caPEM, 0o600))
+	require.NoError(t, os.WriteFile(pki.serverCert, serverPEM, 0o600))
+	require.NoError(t, os.WriteFile(pki.serverKey, serverKeyPEM, 0o600))
+	return pki
+}
+
+// startServer - запуск gRPC-сервера с конфигурацией tlsCfg до завершения теста.
+func startServer(t *testing.T, tlsCfg *config.Config, useCase *usecase.URLUseCase) string {
+	t.Helper()
+
+	address := freeAddress(t)
+	ctx, cancel := context.WithCancel(context.Background())
+
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- grpcserver.Run(ctx, tlsCfg, address, useCase)
+	}()
+	t.Cleanup(func() {
+		cancel()
+		assert.NoError(t, <-errCh)
+	})
+
+	// ожидание готовности слушателя
+	require.Eventually(t, func() bool {
+		conn, err := net.Dial("tcp", address)
+		if err != nil {
+			return false
+		}
+		_ = conn.Close()
+		return true
+	}, 5*time.Second, 20*time.Millisecond)
+	return address
+}
+
+// checkHealth - проверка состояния сервера по address с учетными данными creds.
+func checkHealth(t *testing.T, address string, creds credentials.TransportCredentials) error {
+	t.Helper()
+
+	conn, err := grpc.NewClient(address, grpc.WithTransportCredentials(creds))
+	require.NoError(t, err)
+	defer conn.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	_, err = healthpb.NewHealthClient(conn).Check(ctx, &healthpb.HealthCheckRequest{})
+	return err
+}
+
+func TestRun_TLS(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockRepo := mocks.NewMockURLRepository(ctrl)
+	mockRepo.EXPECT().Ping(gomock.Any()).Return(nil).AnyTimes()
+	useCase := usecase.NewURLUseCase(mockRepo, nil)
+
+	pki := newTestPKI(t)
+
+	newConfig := func(clientCA string) *config.Config {
+		tlsCfg := config.NewConfig()
+		tlsCfg.JWTSecret = cfg.JWTSecret
+		tlsCfg.EnableHTTPS = true
+		tlsCfg.CertFilePath = pki.serverCert
+		tlsCfg.KeyFilePath = pki.serverKey
+		tlsCfg.ClientCAFilePath = clientCA
+		return tlsCfg
+	}
+
+	t.Run("кейс 1, TLS без проверки клиента", func(t *testing.T) {
+		address := startServer(t, newConfig(""), useCase)
+
+		err := checkHealth(t, address, credentials.NewTLS(&tls.Config{RootCAs: pki.caPool}))
+		assert.NoError(t, err)
+
+		// открытое соединение не принимается
+		err = checkHealth(t, address, insecure.NewCredentials())
+		assert.Error(t, err)
+	})
+
+	t.Run("кейс 2, mTLS", func(t *testing.T) {
+		address := startServer(t, newConfig(pki.caFile), useCase)
+
+		err := checkHealth(t, address, credentials.NewTLS(&tls.Config{
+			RootCAs:      pki.caPool,
+			Certificates: []tls.Certificate{pki.clientCert},
+		}))
+		assert.NoError(t, err)
+
+		// клиент без сертификата не проходит проверку
+		err = checkHealth(t, address, credentials.NewTLS(&tls.Config{RootCAs: pki.caPool}))
+		assert.Error(t, err)
+	})
+
+	t.Run("кейс 3, файл корневых сертификатов без сертификатов", func(t *testing.T) {
+		caFile := filepath.Join(t.TempDir(), "empty.pem")
+		require.NoError(t, os.WriteFile(caFile, []byte("not a certificate"), 0o600))
+
+		err := grpcserver.Run(context.Background(), newConfig(caFile), freeAddress(t), useCase)
+		assert.ErrorIs(t, err, constants.ErrorClientCANotValid)
+	})
+
+	t.Run("кейс 4, отсутствует сертификат сервера", func(t *testing.T) {
+		tlsCfg := newConfig("")
+		tlsCfg.CertFilePath = filepath.Join(t.TempDir(), "missing.pem")
+
+		err := grpcserver.Run(context.Background(), tlsCfg, freeAddress(t), useCase)
+		assert.ErrorIs(t, err, os.ErrNotExist)
+	})
+}
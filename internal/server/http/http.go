@@ -2,58 +2,72 @@ package server
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"net/http"
 	"time"
 
 	"github.com/Di-nis/shortener-url/internal/config"
 	"github.com/Di-nis/shortener-url/internal/handler"
 	"github.com/Di-nis/shortener-url/internal/logger"
-	"github.com/Di-nis/shortener-url/internal/service"
 	"github.com/Di-nis/shortener-url/internal/usecase"
 )
 
-// setupRouter - настройка маршрутизатора.
-func setupRouter(cfg *config.Config, repo usecase.URLRepository, svc *service.Service) http.Handler {
-	urlUseCase := usecase.NewURLUseCase(repo, svc)
+// shutdownTimeout - время ожидания завершения обрабатываемых запросов при остановке сервера.
+const shutdownTimeout = 10 * time.Second
 
+// setupRouter - настройка маршрутизатора.
+func setupRouter(cfg *config.Config, repo usecase.URLRepository, urlUseCase *usecase.URLUseCase) http.Handler {
 	controller := handler.NewСontroller(urlUseCase, cfg)
+	controller.Admin = usecase.NewAdminUseCase(repo)
+	if cfg.EnableWebhooks {
+		controller.Webhooks = usecase.NewWebhookUseCase(repo)
+	}
 	return controller.SetupRouter()
 }
 
-// Run - запуск HTTP-сервера.
-func Run(ctx context.Context, cfg *config.Config, repo usecase.URLRepository, svc *service.Service) error {
-	var err error
-	routerHandler := setupRouter(cfg, repo, svc)
+// Run - запуск HTTP-сервера на cfg.ServerAddress до отмены ctx.
+// Возвращает ошибку, если сервер не удалось запустить или он остановился с ошибкой.
+// Хранилище закрывает вызывающая сторона после остановки всех серверов.
+func Run(ctx context.Context, cfg *config.Config, repo usecase.URLRepository, urlUseCase *usecase.URLUseCase) error {
+	routerHandler := setupRouter(cfg, repo, urlUseCase)
 
 	httpServer := &http.Server{
-		Addr:    cfg.ServerAddress,
-		Handler: routerHandler,
+		Addr:              cfg.ServerAddress,
+		Handler:           routerHandler,
+		ReadTimeout:       cfg.ReadTimeout,
+		ReadHeaderTimeout: cfg.ReadHeaderTimeout,
+		WriteTimeout:      cfg.WriteTimeout,
+		IdleTimeout:       cfg.IdleTimeout,
 	}
 
+	errCh := make(chan error, 1)
 	go func() {
+		var err error
 		if cfg.EnableHTTPS {
-			if err = httpServer.ListenAndServeTLS(cfg.CertFilePath, cfg.KeyFilePath); err != nil && err != http.ErrServerClosed {
-				logger.Sugar.Fatalf("failed start TLS-server: %w", err)
-			}
-		}
-		if err = httpServer.ListenAndServe(); err != nil && err != http.ErrServerClosed {
-			logger.Sugar.Fatalf("failed start server: %w", err)
+			err = httpServer.ListenAndServeTLS(cfg.CertFilePath, cfg.KeyFilePath)
+		} else {
+			err = httpServer.ListenAndServe()
 		}
+		errCh <- err
 	}()
 
-	<-ctx.Done()
+	logger.Sugar.Infow("HTTP-server has started", "address", cfg.ServerAddress)
+
+	select {
+	case err := <-errCh:
+		if !errors.Is(err, http.ErrServerClosed) {
+			return fmt.Errorf("path: internal/server/http/http.go, func Run(), failed start server: %w", err)
+		}
+		return nil
+	case <-ctx.Done():
+	}
 
-	shutDownCtx, cancel := context.WithTimeout(context.Background(), time.Second*10)
+	shutDownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
 	defer cancel()
 
-	go func() {
-		if err := httpServer.Shutdown(context.Background()); err != nil {
-			logger.Sugar.Errorf("failed graceful shutdown: %w", err)
-		}
-		if err = repo.Close(); err != nil {
-			logger.Sugar.Errorf("failed closing database: %w", err)
-		}
-	}()
-	<-shutDownCtx.Done()
+	if err := httpServer.Shutdown(shutDownCtx); err != nil {
+		return fmt.Errorf("path: internal/server/http/http.go, func Run(), failed graceful shutdown: %w", err)
+	}
 	return nil
 }
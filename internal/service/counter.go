@@ -0,0 +1,32 @@
+package service
+
+import (
+	"context"
+	"fmt"
+)
+
+// CounterService - стратегия последовательных кодов: номер счетчика в алфавите стратегии.
+type CounterService struct {
+	seq      Sequence
+	length   int
+	alphabet string
+}
+
+// NewCounterService - создание стратегии CounterService.
+func NewCounterService(seq Sequence, length int, alphabet string) *CounterService {
+	return &CounterService{
+		seq:      seq,
+		length:   length,
+		alphabet: alphabet,
+	}
+}
+
+// Generate - код следующего номера счетчика, дополненный до минимальной длины.
+// При коллизии следующая попытка получает следующий номер.
+func (service *CounterService) Generate(ctx context.Context, original string, attempt int) (string, error) {
+	id, err := service.seq.NextID(ctx)
+	if err != nil {
+		return "", fmt.Errorf("path: internal/service/counter.go, func Generate(), failed to get next id: %w", err)
+	}
+	return padLeft(encode(id, service.alphabet), service.length, service.alphabet), nil
+}
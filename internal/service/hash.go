@@ -0,0 +1,48 @@
+package service
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/binary"
+	"strconv"
+	"strings"
+)
+
+// HashService - детерминированная стратегия: код из хэша SHA-256 оригинального URL.
+type HashService struct {
+	length   int
+	alphabet string
+}
+
+// NewHashService - создание стратегии HashService.
+func NewHashService(length int, alphabet string) *HashService {
+	return &HashService{
+		length:   length,
+		alphabet: alphabet,
+	}
+}
+
+// Generate - код оригинального URL.
+// Попытка с номером больше нуля добавляет к оригинальному URL соль, чтобы получить другой код при коллизии.
+func (service *HashService) Generate(ctx context.Context, original string, attempt int) (string, error) {
+	if attempt > 0 {
+		original += "#" + strconv.Itoa(attempt)
+	}
+	return service.ShortHash(original), nil
+}
+
+// ShortHash - создание хэш на основе входных данных.
+// Каждые 8 байт SHA-256 записываются в алфавите стратегии, пока код не достигнет заданной длины.
+func (service *HashService) ShortHash(data string) string {
+	hash := sha256.Sum256([]byte(data))
+
+	var b strings.Builder
+	for i := 0; b.Len() < service.length; i = (i + 8) % len(hash) {
+		num := binary.BigEndian.Uint64(hash[i : i+8])
+		b.WriteString(encode(num, service.alphabet))
+		if i+8 == len(hash) {
+			hash = sha256.Sum256(hash[:])
+		}
+	}
+	return b.String()[:service.length]
+}
@@ -0,0 +1,66 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"slices"
+)
+
+// HashidsService - стратегия в стиле hashids: номер счетчика, закодированный в перемешанном солью алфавите.
+// Коды не раскрывают порядок и количество созданных URL, оставаясь уникальными.
+type HashidsService struct {
+	seq      Sequence
+	length   int
+	alphabet []byte
+	salt     []byte
+}
+
+// NewHashidsService - создание стратегии HashidsService.
+func NewHashidsService(seq Sequence, length int, alphabet, salt string) *HashidsService {
+	return &HashidsService{
+		seq:      seq,
+		length:   length,
+		alphabet: consistentShuffle([]byte(alphabet), []byte(salt)),
+		salt:     []byte(salt),
+	}
+}
+
+// Generate - код следующего номера счетчика.
+// При коллизии следующая попытка получает следующий номер.
+func (service *HashidsService) Generate(ctx context.Context, original string, attempt int) (string, error) {
+	id, err := service.seq.NextID(ctx)
+	if err != nil {
+		return "", fmt.Errorf("path: internal/service/hashids.go, func Generate(), failed to get next id: %w", err)
+	}
+	return service.Encode(id), nil
+}
+
+// Encode - кодирование номера.
+// Первый символ кода (lottery) определяется номером и задает перемешивание алфавита для остальной части кода.
+func (service *HashidsService) Encode(id uint64) string {
+	lottery := service.alphabet[id%uint64(len(service.alphabet))]
+
+	buffer := make([]byte, 0, 1+len(service.salt)+len(service.alphabet))
+	buffer = append(buffer, lottery)
+	buffer = append(buffer, service.salt...)
+	buffer = append(buffer, service.alphabet...)
+	alphabet := string(consistentShuffle(service.alphabet, buffer[:len(service.alphabet)]))
+
+	return string(lottery) + padLeft(encode(id, alphabet), service.length-1, alphabet)
+}
+
+// consistentShuffle - детерминированное перемешивание алфавита солью.
+func consistentShuffle(alphabet, salt []byte) []byte {
+	result := slices.Clone(alphabet)
+	if len(salt) == 0 {
+		return result
+	}
+
+	for i, v, p := len(result)-1, 0, 0; i > 0; i-- {
+		p += int(salt[v])
+		j := (int(salt[v]) + v + p) % i
+		result[i], result[j] = result[j], result[i]
+		v = (v + 1) % len(salt)
+	}
+	return result
+}
@@ -0,0 +1,38 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"fmt"
+	"math/big"
+)
+
+// RandomService - стратегия случайных кодов из криптографически стойкого генератора.
+type RandomService struct {
+	length   int
+	alphabet string
+}
+
+// NewRandomService - создание стратегии RandomService.
+func NewRandomService(length int, alphabet string) *RandomService {
+	return &RandomService{
+		length:   length,
+		alphabet: alphabet,
+	}
+}
+
+// Generate - случайный код, не зависящий от оригинального URL;
+// при коллизии следующая попытка получает новый код.
+func (service *RandomService) Generate(ctx context.Context, original string, attempt int) (string, error) {
+	size := big.NewInt(int64(len(service.alphabet)))
+
+	code := make([]byte, service.length)
+	for i := range code {
+		idx, err := rand.Int(rand.Reader, size)
+		if err != nil {
+			return "", fmt.Errorf("path: internal/service/random.go, func Generate(), failed to read random: %w", err)
+		}
+		code[i] = service.alphabet[idx.Int64()]
+	}
+	return string(code), nil
+}
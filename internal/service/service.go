@@ -2,45 +2,129 @@
 package service
 
 import (
-	"crypto/sha256"
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/Di-nis/shortener-url/internal/constants"
+)
+
+// Стратегии создания короткого URL.
+const (
+	StrategyHash    = "hash"
+	StrategyRandom  = "random"
+	StrategyCounter = "counter"
+	StrategyHashids = "hashids"
 )
 
-var base62Alphabet = []byte("0123456789abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ")
+// Параметры создания короткого URL по умолчанию.
+const (
+	DefaultLength  = 8
+	Base62Alphabet = "0123456789abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ"
+	// hashidsMinAlphabet - минимальный размер алфавита стратегии hashids.
+	hashidsMinAlphabet = 16
+)
 
-// Service - структура сервиса по созданию уникального короткого url.
-type Service struct{}
+// Service - стратегия создания короткого URL.
+type Service interface {
+	// Generate - создание короткого URL для оригинального URL.
+	// attempt - номер попытки, больше нуля после коллизии с уже занятым коротким URL.
+	Generate(ctx context.Context, original string, attempt int) (string, error)
+}
+
+// Sequence - источник монотонно возрастающих номеров для стратегий на основе счетчика.
+type Sequence interface {
+	NextID(context.Context) (uint64, error)
+}
 
-// NewService - создание структуры Service.
-func NewService() *Service {
-	return &Service{}
+// Options - параметры стратегии создания короткого URL.
+type Options struct {
+	Strategy string
+	// Length - длина короткого URL, для стратегий на основе счетчика - минимальная длина.
+	Length   int
+	Alphabet string
+	// Salt - соль стратегии hashids.
+	Salt string
 }
 
-// base62Encode -пПреобразование числа в Base62.
-func (service *Service) base62Encode(num uint64) string {
+// NewService - создание стратегии по умолчанию: хэш SHA-256 в Base62 длиной DefaultLength.
+func NewService() Service {
+	return NewHashService(DefaultLength, Base62Alphabet)
+}
+
+// New - создание стратегии по параметрам.
+// Стратегиям на основе счетчика требуется источник номеров seq.
+func New(opts Options, seq Sequence) (Service, error) {
+	if err := validateOptions(opts); err != nil {
+		return nil, err
+	}
+
+	switch opts.Strategy {
+	case StrategyHash:
+		return NewHashService(opts.Length, opts.Alphabet), nil
+	case StrategyRandom:
+		return NewRandomService(opts.Length, opts.Alphabet), nil
+	case StrategyCounter:
+		if seq == nil {
+			return nil, fmt.Errorf("path: internal/service/service.go, func New(), strategy %q requires sequence: %w", opts.Strategy, constants.ErrorInvalidShortOptions)
+		}
+		return NewCounterService(seq, opts.Length, opts.Alphabet), nil
+	case StrategyHashids:
+		if seq == nil {
+			return nil, fmt.Errorf("path: internal/service/service.go, func New(), strategy %q requires sequence: %w", opts.Strategy, constants.ErrorInvalidShortOptions)
+		}
+		return NewHashidsService(seq, opts.Length, opts.Alphabet, opts.Salt), nil
+	default:
+		return nil, fmt.Errorf("path: internal/service/service.go, func New(), unknown strategy %q: %w", opts.Strategy, constants.ErrorInvalidShortOptions)
+	}
+}
+
+// validateOptions - проверка длины и алфавита: не менее двух неповторяющихся ASCII-символов.
+func validateOptions(opts Options) error {
+	if opts.Length <= 0 {
+		return fmt.Errorf("path: internal/service/service.go, func validateOptions(), length %d: %w", opts.Length, constants.ErrorInvalidShortOptions)
+	}
+	if len(opts.Alphabet) < 2 {
+		return fmt.Errorf("path: internal/service/service.go, func validateOptions(), alphabet is too short: %w", constants.ErrorInvalidShortOptions)
+	}
+	if opts.Strategy == StrategyHashids && len(opts.Alphabet) < hashidsMinAlphabet {
+		return fmt.Errorf("path: internal/service/service.go, func validateOptions(), hashids alphabet needs %d characters: %w", hashidsMinAlphabet, constants.ErrorInvalidShortOptions)
+	}
+	for i := 0; i < len(opts.Alphabet); i++ {
+		c := opts.Alphabet[i]
+		if c <= ' ' || c > '~' || c == '/' {
+			return fmt.Errorf("path: internal/service/service.go, func validateOptions(), invalid character %q: %w", c, constants.ErrorInvalidShortOptions)
+		}
+		if strings.IndexByte(opts.Alphabet[i+1:], c) >= 0 {
+			return fmt.Errorf("path: internal/service/service.go, func validateOptions(), duplicate character %q: %w", c, constants.ErrorInvalidShortOptions)
+		}
+	}
+	return nil
+}
+
+// encode - запись числа в системе счисления с цифрами из алфавита.
+func encode(num uint64, alphabet string) string {
 	if num == 0 {
-		return string(base62Alphabet[0])
+		return string(alphabet[0])
 	}
+
+	base := uint64(len(alphabet))
 	var encoded []byte
 	for num > 0 {
-		rem := num % 62
-		num /= 62
-
-		encoded = append([]byte{base62Alphabet[rem]}, encoded...)
+		encoded = append(encoded, alphabet[num%base])
+		num /= base
+	}
+	for i, j := 0, len(encoded)-1; i < j; i, j = i+1, j-1 {
+		encoded[i], encoded[j] = encoded[j], encoded[i]
 	}
 	return string(encoded)
 }
 
-// ShortHash - создание хэш на основе входных данных.
-func (service *Service) ShortHash(data string, length int) string {
-	var num uint64
-	hash := sha256.Sum256([]byte(data))
-	for i := range 8 {
-		num = (num << 8) | uint64(hash[i])
-	}
-
-	b62 := service.base62Encode(num)
-	if len(b62) > length {
-		return b62[:length]
+// padLeft - дополнение кода первым символом алфавита до минимальной длины.
+// Код числа больше нуля не начинается с первого символа алфавита, поэтому дополнение сохраняет уникальность.
+func padLeft(code string, length int, alphabet string) string {
+	if len(code) >= length {
+		return code
 	}
-	return b62
+	return strings.Repeat(alphabet[:1], length-len(code)) + code
 }
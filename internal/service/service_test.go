@@ -1,12 +1,26 @@
 package service
 
 import (
+	"context"
+	"strings"
 	"testing"
 
 	"github.com/Di-nis/shortener-url/internal/constants"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
+// sequenceStub - счетчик в памяти.
+type sequenceStub struct {
+	last uint64
+}
+
+// NextID - следующий номер счетчика.
+func (s *sequenceStub) NextID(context.Context) (uint64, error) {
+	s.last++
+	return s.last, nil
+}
+
 func TestService_ShortHash(t *testing.T) {
 	tests := []struct {
 		name   string
@@ -17,40 +31,197 @@ func TestService_ShortHash(t *testing.T) {
 		{
 			name:   "Тест #1",
 			data:   "https://practicum.yandex.ru",
-			length: constants.HashLength,
+			length: DefaultLength,
 			want:   "bTKNZu94",
 		},
 		{
 			name:   "Тест #2",
 			data:   "https://www.sports.ru",
-			length: constants.HashLength,
+			length: DefaultLength,
+			want:   "4BeKySvE",
+		},
+		{
+			name:   "длина больше кода одного блока хэша",
+			data:   "https://www.sports.ru",
+			length: 30,
 			want:   "4BeKySvE",
 		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			service := NewService()
-			got := service.ShortHash(tt.data, tt.length)
+			service := NewHashService(tt.length, Base62Alphabet)
+			got := service.ShortHash(tt.data)
+
+			assert.Len(t, got, tt.length)
+			assert.True(t, strings.HasPrefix(got, tt.want))
+		})
+	}
+}
+
+func TestHashService_Generate(t *testing.T) {
+	service := NewHashService(DefaultLength, Base62Alphabet)
+
+	first, err := service.Generate(context.Background(), "https://www.sports.ru", 0)
+	require.NoError(t, err)
+	assert.Equal(t, "4BeKySvE", first)
+
+	salted, err := service.Generate(context.Background(), "https://www.sports.ru", 1)
+	require.NoError(t, err)
+	assert.NotEqual(t, first, salted)
+	assert.Len(t, salted, DefaultLength)
+}
+
+func TestRandomService_Generate(t *testing.T) {
+	service := NewRandomService(12, "ab")
+
+	first, err := service.Generate(context.Background(), "https://www.sports.ru", 0)
+	require.NoError(t, err)
+	assert.Len(t, first, 12)
+	assert.Empty(t, strings.Trim(first, "ab"))
+}
+
+func TestCounterService_Generate(t *testing.T) {
+	tests := []struct {
+		name     string
+		last     uint64
+		length   int
+		alphabet string
+		want     string
+	}{
+		{
+			name:     "первый номер дополняется до минимальной длины",
+			last:     0,
+			length:   4,
+			alphabet: Base62Alphabet,
+			want:     "0001",
+		},
+		{
+			name:     "номер длиннее минимальной длины",
+			last:     61,
+			length:   1,
+			alphabet: Base62Alphabet,
+			want:     "10",
+		},
+		{
+			name:     "двоичный алфавит",
+			last:     4,
+			length:   1,
+			alphabet: "01",
+			want:     "101",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := NewCounterService(&sequenceStub{last: tt.last}, tt.length, tt.alphabet)
+
+			got, err := service.Generate(context.Background(), "https://www.sports.ru", 0)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestHashidsService_Generate(t *testing.T) {
+	service := NewHashidsService(&sequenceStub{}, 6, Base62Alphabet, "shortener")
+	other := NewHashidsService(&sequenceStub{}, 6, Base62Alphabet, "other salt")
 
-			assert.Equal(t, got, tt.want)
+	codes := make(map[string]struct{})
+	for range 1000 {
+		code, err := service.Generate(context.Background(), "https://www.sports.ru", 0)
+		require.NoError(t, err)
+		assert.GreaterOrEqual(t, len(code), 6)
+
+		_, ok := codes[code]
+		assert.False(t, ok, "повторный код %s", code)
+		codes[code] = struct{}{}
+	}
+
+	assert.NotEqual(t, "000001", service.Encode(1))
+	assert.NotEqual(t, service.Encode(1), other.Encode(1))
+	assert.Equal(t, service.Encode(42), service.Encode(42))
+}
+
+func TestNew(t *testing.T) {
+	tests := []struct {
+		name    string
+		opts    Options
+		seq     Sequence
+		wantErr error
+	}{
+		{
+			name: "хэш",
+			opts: Options{Strategy: StrategyHash, Length: DefaultLength, Alphabet: Base62Alphabet},
+		},
+		{
+			name: "случайные коды",
+			opts: Options{Strategy: StrategyRandom, Length: DefaultLength, Alphabet: Base62Alphabet},
+		},
+		{
+			name: "счетчик",
+			opts: Options{Strategy: StrategyCounter, Length: DefaultLength, Alphabet: Base62Alphabet},
+			seq:  &sequenceStub{},
+		},
+		{
+			name: "hashids",
+			opts: Options{Strategy: StrategyHashids, Length: DefaultLength, Alphabet: Base62Alphabet, Salt: "salt"},
+			seq:  &sequenceStub{},
+		},
+		{
+			name:    "счетчик без источника номеров",
+			opts:    Options{Strategy: StrategyCounter, Length: DefaultLength, Alphabet: Base62Alphabet},
+			wantErr: constants.ErrorInvalidShortOptions,
+		},
+		{
+			name:    "неизвестная стратегия",
+			opts:    Options{Strategy: "uuid", Length: DefaultLength, Alphabet: Base62Alphabet},
+			wantErr: constants.ErrorInvalidShortOptions,
+		},
+		{
+			name:    "нулевая длина",
+			opts:    Options{Strategy: StrategyHash, Alphabet: Base62Alphabet},
+			wantErr: constants.ErrorInvalidShortOptions,
+		},
+		{
+			name:    "повторяющиеся символы алфавита",
+			opts:    Options{Strategy: StrategyHash, Length: DefaultLength, Alphabet: "abca"},
+			wantErr: constants.ErrorInvalidShortOptions,
+		},
+		{
+			name:    "недопустимый символ алфавита",
+			opts:    Options{Strategy: StrategyHash, Length: DefaultLength, Alphabet: "ab/"},
+			wantErr: constants.ErrorInvalidShortOptions,
+		},
+		{
+			name:    "короткий алфавит hashids",
+			opts:    Options{Strategy: StrategyHashids, Length: DefaultLength, Alphabet: "0123456789"},
+			seq:     &sequenceStub{},
+			wantErr: constants.ErrorInvalidShortOptions,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := New(tt.opts, tt.seq)
+			assert.ErrorIs(t, err, tt.wantErr)
+			if tt.wantErr == nil {
+				assert.NotNil(t, got)
+			}
 		})
 	}
 }
 
 func BenchmarkServiceMethods(b *testing.B) {
-	service := NewService()
+	service := NewHashService(DefaultLength, Base62Alphabet)
 
-	b.Run("base62Encode", func(b *testing.B) {
-		url, length := "https://practicum.yandex.ru", constants.HashLength
+	b.Run("encode", func(b *testing.B) {
 		for i := 0; i < b.N; i++ {
-			service.ShortHash(url, length)
+			encode(uint64(i), Base62Alphabet)
 		}
 	})
 
 	b.Run("ShortHash", func(b *testing.B) {
-		url, length := "https://practicum.yandex.ru", constants.HashLength
+		url := "https://practicum.yandex.ru"
 		for i := 0; i < b.N; i++ {
-			service.ShortHash(url, length)
+			service.ShortHash(url)
 		}
 	})
 }
@@ -0,0 +1,60 @@
+package storage
+
+import (
+	"errors"
+	"os"
+	"strings"
+)
+
+// BlockedUsersFile - список заблокированных пользователей, хранящийся в файле по одному на строку.
+type BlockedUsersFile struct {
+	path  string
+	users []string
+}
+
+// NewBlockedUsersFile - создание списка с загрузкой заблокированных пользователей из файла.
+// Отсутствующий файл соответствует пустому списку.
+func NewBlockedUsersFile(path string) (*BlockedUsersFile, error) {
+	blocked := &BlockedUsersFile{path: path}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return blocked, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	for _, user := range strings.Split(string(data), "\n") {
+		if user = strings.TrimSpace(user); user != "" {
+			blocked.users = append(blocked.users, user)
+		}
+	}
+	return blocked, nil
+}
+
+// Users - заблокированные пользователи, загруженные из файла.
+func (b *BlockedUsersFile) Users() []string {
+	return b.users
+}
+
+// Save - запись списка заблокированных пользователей.
+// Список записывается во временный файл и атомарно заменяет прежний.
+func (b *BlockedUsersFile) Save(users []string) error {
+	var data strings.Builder
+	for _, user := range users {
+		data.WriteString(user)
+		data.WriteByte('\n')
+	}
+
+	tmp := b.path + ".tmp"
+	if err := os.WriteFile(tmp, []byte(data.String()), 0666); err != nil {
+		return err
+	}
+	if err := os.Rename(tmp, b.path); err != nil {
+		return err
+	}
+
+	b.users = users
+	return nil
+}
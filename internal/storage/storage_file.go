@@ -45,6 +45,15 @@ func (p *Producer) Write(url models.URLBase) error {
 	return p.writer.Flush()
 }
 
+// Size - размер файла-хранилища в байтах.
+func (p *Producer) Size() (int64, error) {
+	info, err := p.file.Stat()
+	if err != nil {
+		return 0, err
+	}
+	return info.Size(), nil
+}
+
 // Close - закрытие файла.
 func (p *Producer) Close() error {
 	return p.file.Close()
@@ -89,16 +98,27 @@ func (c *Consumer) Close() error {
 }
 
 // Load - загрузка данных из файла.
+// При наличии нескольких записей с одним коротким URL в домене используется последняя.
 func (c *Consumer) Load() ([]models.URLBase, error) {
 	URLArray := make([]models.URLBase, 0)
+	indexes := make(map[string]int)
 
 	for c.scanner.Scan() {
 		urlData, err := c.Read()
 		if err != nil {
 			return nil, err
 		}
+
+		// короткий URL уникален в пространстве имен домена
+		key := urlData.Domain + "/" + urlData.Short
+		if idx, ok := indexes[key]; ok {
+			URLArray[idx] = *urlData
+			continue
+		}
+		indexes[key] = len(URLArray)
 		URLArray = append(URLArray, *urlData)
 	}
+
 	err := c.Close()
 	if err != nil {
 		return nil, err
@@ -0,0 +1,53 @@
+package storage
+
+import (
+	"errors"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// SequenceFile - счетчик, последнее выданное значение которого хранится в файле.
+type SequenceFile struct {
+	path string
+	last uint64
+}
+
+// NewSequenceFile - создание счетчика с загрузкой последнего значения из файла.
+// Отсутствующий файл соответствует счетчику, еще не выдавшему ни одного значения.
+func NewSequenceFile(path string) (*SequenceFile, error) {
+	sequence := &SequenceFile{path: path}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return sequence, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if value := strings.TrimSpace(string(data)); value != "" {
+		sequence.last, err = strconv.ParseUint(value, 10, 64)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return sequence, nil
+}
+
+// Next - следующее значение счетчика.
+// Значение записывается во временный файл и атомарно заменяет прежнее, чтобы не повториться после сбоя.
+func (s *SequenceFile) Next() (uint64, error) {
+	next := s.last + 1
+
+	tmp := s.path + ".tmp"
+	if err := os.WriteFile(tmp, []byte(strconv.FormatUint(next, 10)), 0666); err != nil {
+		return 0, err
+	}
+	if err := os.Rename(tmp, s.path); err != nil {
+		return 0, err
+	}
+
+	s.last = next
+	return next, nil
+}
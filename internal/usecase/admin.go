@@ -0,0 +1,88 @@
+package usecase
+
+import (
+	"context"
+	"strings"
+
+	"github.com/Di-nis/shortener-url/internal/constants"
+	"github.com/Di-nis/shortener-url/internal/models"
+)
+
+// Ограничения количества URL в ответе административного API.
+const (
+	defaultAdminLimit = 100
+	maxAdminLimit     = 1000
+)
+
+// AdminRepository - интерфейс хранилища для административных операций.
+type AdminRepository interface {
+	SearchURLs(context.Context, models.AdminFilter) ([]models.URLBase, error)
+	SetDisabled(context.Context, string, string, bool) error
+	ForceDelete(context.Context, string, string) error
+	BlockUser(context.Context, string) error
+	UnblockUser(context.Context, string) error
+	IsUserBlocked(context.Context, string) (bool, error)
+}
+
+// AdminUseCase - структура административных операций над URL и пользователями.
+type AdminUseCase struct {
+	Repo AdminRepository
+}
+
+// NewAdminUseCase - создание структуры AdminUseCase.
+func NewAdminUseCase(repo AdminRepository) *AdminUseCase {
+	return &AdminUseCase{
+		Repo: repo,
+	}
+}
+
+// SearchURLs - поиск URL всех пользователей.
+func (adminUseCase *AdminUseCase) SearchURLs(ctx context.Context, filter models.AdminFilter) ([]models.URLBase, error) {
+	filter.Query = strings.TrimSpace(filter.Query)
+	filter.UserID = strings.TrimSpace(filter.UserID)
+	if filter.Limit <= 0 {
+		filter.Limit = defaultAdminLimit
+	}
+	if filter.Limit > maxAdminLimit {
+		filter.Limit = maxAdminLimit
+	}
+	if filter.Offset < 0 {
+		filter.Offset = 0
+	}
+	return adminUseCase.Repo.SearchURLs(ctx, filter)
+}
+
+// DeleteURL - удаление URL в пространстве имен домена независимо от владельца.
+func (adminUseCase *AdminUseCase) DeleteURL(ctx context.Context, domain, short string) error {
+	return adminUseCase.Repo.ForceDelete(ctx, domain, short)
+}
+
+// SetURLDisabled - отключение или включение URL в пространстве имен домена независимо от владельца.
+func (adminUseCase *AdminUseCase) SetURLDisabled(ctx context.Context, domain, short string, disabled bool) error {
+	return adminUseCase.Repo.SetDisabled(ctx, domain, short, disabled)
+}
+
+// BlockUser - запрет пользователю создавать URL.
+func (adminUseCase *AdminUseCase) BlockUser(ctx context.Context, userID string) error {
+	if strings.TrimSpace(userID) == "" {
+		return constants.ErrorNotFound
+	}
+	return adminUseCase.Repo.BlockUser(ctx, userID)
+}
+
+// UnblockUser - снятие запрета на создание URL.
+func (adminUseCase *AdminUseCase) UnblockUser(ctx context.Context, userID string) error {
+	return adminUseCase.Repo.UnblockUser(ctx, userID)
+}
+
+// checkBlocked - проверка, что пользователю разрешено создавать URL.
+func (urlUseCase *URLUseCase) checkBlocked(ctx context.Context, userID string) error {
+	blocked, err := urlUseCase.Repo.IsUserBlocked(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if blocked {
+		return constants.ErrorUserBlocked
+	}
+	return nil
+}
@@ -0,0 +1,92 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"slices"
+
+	"github.com/Di-nis/shortener-url/internal/constants"
+	"github.com/Di-nis/shortener-url/internal/models"
+)
+
+// validOriginal - проверка оригинального URL при массовой загрузке.
+func validOriginal(original string) bool {
+	if original == "" {
+		return false
+	}
+	_, err := url.Parse(original)
+	return err == nil
+}
+
+// CreateURLBulk - сокращение пакета URL с результатом для каждого URL в порядке пакета.
+// В отличие от CreateURLBatch уже существующие и некорректные URL не прерывают загрузку:
+// существующие исключаются из пакета по одному, пока остальные URL не будут записаны одним вызовом InsertBatch.
+func (urlUseCase *URLUseCase) CreateURLBulk(ctx context.Context, urls []models.URLBase) ([]models.BulkResult, error) {
+	if len(urls) == 0 {
+		return nil, nil
+	}
+	if err := urlUseCase.checkBlocked(ctx, urls[0].UUID); err != nil {
+		return nil, err
+	}
+
+	results := make([]models.BulkResult, len(urls))
+	// pending - позиции в пакете URL, ожидающих записи
+	pending := make([]int, 0, len(urls))
+	batch := make([]models.URLBase, 0, len(urls))
+	for idx := range urls {
+		urls[idx] = normalizeURL(urls[idx])
+		results[idx] = models.BulkResult{URLID: urls[idx].URLID, Domain: urls[idx].Domain}
+		if !validOriginal(urls[idx].Original) {
+			results[idx].Status = models.BulkInvalid
+			continue
+		}
+
+		short, err := urlUseCase.Service.Generate(ctx, urlUseCase.hashInput(urls[idx]), 0)
+		if err != nil {
+			return nil, err
+		}
+		urls[idx].Short = short
+
+		pending = append(pending, idx)
+		batch = append(batch, urls[idx])
+	}
+
+	var existing []int
+	for len(batch) > 0 {
+		err := urlUseCase.insertBatch(ctx, batch)
+
+		var itemErr *models.BatchItemError
+		if errors.Is(err, constants.ErrorURLAlreadyExist) && errors.As(err, &itemErr) {
+			existing = append(existing, pending[itemErr.Index])
+			pending = slices.Delete(pending, itemErr.Index, itemErr.Index+1)
+			batch = slices.Delete(batch, itemErr.Index, itemErr.Index+1)
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		break
+	}
+
+	for i, idx := range pending {
+		urls[idx].Short = batch[i].Short
+		results[idx].Short = batch[i].Short
+		results[idx].Status = models.BulkCreated
+	}
+
+	// короткие URL существующих URL запрашиваются после записи пакета:
+	// повтор URL внутри пакета к этому моменту уже записан
+	for _, idx := range existing {
+		short, err := urlUseCase.Repo.SelectShort(ctx, urls[idx].Domain, urls[idx].UUID, urls[idx].Original)
+		if err != nil {
+			return nil, err
+		}
+		results[idx].Short = short
+		results[idx].Status = models.BulkExisting
+	}
+
+	urlUseCase.fillTitles(batch)
+	urlUseCase.publish(ctx, models.EventURLCreated, batch...)
+	return results, nil
+}
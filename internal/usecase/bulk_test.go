@@ -0,0 +1,105 @@
+package usecase
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/Di-nis/shortener-url/internal/constants"
+	"github.com/Di-nis/shortener-url/internal/mocks"
+	"github.com/Di-nis/shortener-url/internal/models"
+	"github.com/Di-nis/shortener-url/internal/service"
+	"github.com/golang/mock/gomock"
+)
+
+func TestURLUseCase_CreateURLBulk(t *testing.T) {
+	tests := []struct {
+		name    string
+		urls    []models.URLBase
+		mock    func(*mocks.MockURLRepository)
+		want    []models.BulkResult
+		wantErr error
+	}{
+		{
+			name: "новый, существующий и некорректный URL",
+			urls: []models.URLBase{
+				{UUID: UUID, URLID: "1", Original: urlOriginal1},
+				{UUID: UUID, URLID: "2", Original: urlOriginal2},
+				{UUID: UUID, URLID: "3", Original: ""},
+			},
+			mock: func(mockRepo *mocks.MockURLRepository) {
+				urlNew := models.URLBase{UUID: UUID, URLID: "1", Original: urlOriginal1, Short: urlShort1}
+				urlExisting := models.URLBase{UUID: UUID, URLID: "2", Original: urlOriginal2, Short: urlShort2}
+
+				mockRepo.EXPECT().IsUserBlocked(gomock.Any(), UUID).Return(false, nil)
+				gomock.InOrder(
+					mockRepo.EXPECT().InsertBatch(gomock.Any(), []models.URLBase{urlNew, urlExisting}).
+						Return(&models.BatchItemError{Index: 1, Err: constants.ErrorURLAlreadyExist}),
+					mockRepo.EXPECT().InsertBatch(gomock.Any(), []models.URLBase{urlNew}).Return(nil),
+					mockRepo.EXPECT().SelectShort(gomock.Any(), "", UUID, urlOriginal2).Return("existing", nil),
+				)
+			},
+			want: []models.BulkResult{
+				{URLID: "1", Short: urlShort1, Status: models.BulkCreated},
+				{URLID: "2", Short: "existing", Status: models.BulkExisting},
+				{URLID: "3", Status: models.BulkInvalid},
+			},
+			wantErr: nil,
+		},
+		{
+			name: "коллизия короткого URL, повторный подбор кода",
+			urls: []models.URLBase{{UUID: UUID, URLID: "1", Original: urlOriginal1}},
+			mock: func(mockRepo *mocks.MockURLRepository) {
+				mockRepo.EXPECT().IsUserBlocked(gomock.Any(), UUID).Return(false, nil)
+				gomock.InOrder(
+					mockRepo.EXPECT().InsertBatch(gomock.Any(), gomock.Any()).
+						Return(&models.BatchItemError{Index: 0, Err: constants.ErrorShortCollision}),
+					mockRepo.EXPECT().InsertBatch(gomock.Any(), gomock.Any()).Return(nil),
+				)
+			},
+			want: []models.BulkResult{
+				{URLID: "1", Short: urlShortSalted1, Status: models.BulkCreated},
+			},
+			wantErr: nil,
+		},
+		{
+			name: "все URL некорректны",
+			urls: []models.URLBase{{UUID: UUID, URLID: "1", Original: "http://%zz"}},
+			mock: func(mockRepo *mocks.MockURLRepository) {
+				mockRepo.EXPECT().IsUserBlocked(gomock.Any(), UUID).Return(false, nil)
+			},
+			want: []models.BulkResult{
+				{URLID: "1", Status: models.BulkInvalid},
+			},
+			wantErr: nil,
+		},
+		{
+			name: "пользователь заблокирован",
+			urls: []models.URLBase{{UUID: UUID, URLID: "1", Original: urlOriginal1}},
+			mock: func(mockRepo *mocks.MockURLRepository) {
+				mockRepo.EXPECT().IsUserBlocked(gomock.Any(), UUID).Return(true, nil)
+			},
+			want:    nil,
+			wantErr: constants.ErrorUserBlocked,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockRepo := mocks.NewMockURLRepository(ctrl)
+			tt.mock(mockRepo)
+
+			useCase := NewURLUseCase(mockRepo, service.NewService())
+
+			got, gotErr := useCase.CreateURLBulk(context.Background(), tt.urls)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("CreateURLBulk() = %v, want %v", got, tt.want)
+			}
+			if gotErr != tt.wantErr {
+				t.Errorf("CreateURLBulk() = %v, wantErr %v", gotErr, tt.wantErr)
+			}
+		})
+	}
+}
@@ -3,25 +3,61 @@ package usecase
 
 import (
 	"context"
+	"encoding/base64"
 	"errors"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
 	"sync"
+	"time"
 
 	"github.com/Di-nis/shortener-url/internal/constants"
+	"github.com/Di-nis/shortener-url/internal/logger"
 	"github.com/Di-nis/shortener-url/internal/models"
+	"github.com/Di-nis/shortener-url/internal/redirect"
 	"github.com/Di-nis/shortener-url/internal/service"
 )
 
+// Параметры статистики по умолчанию.
+const (
+	statsDefaultPeriod = 30 * 24 * time.Hour
+	statsDefaultTop    = 10
+	statsMaxTop        = 100
+)
+
+// Размер страницы при постраничном получении URL пользователя.
+const (
+	pageDefaultSize = 100
+	pageMaxSize     = 1000
+)
+
+// Ограничение числа URL в результатах поиска.
+const (
+	lookupDefaultLimit = 100
+	lookupMaxLimit     = 1000
+)
+
 // URLRepository - интерфейс для базы данных.
 type URLRepository interface {
 	Ping(context.Context) error
 	InsertBatch(context.Context, []models.URLBase) error
 	InsertOrdinary(context.Context, models.URLBase) error
-	SelectOriginal(context.Context, string) (string, error)
-	SelectShort(context.Context, string) (string, error)
-	SelectAll(context.Context, string) ([]models.URLBase, error)
-	Delete(context.Context, []models.URLBase) error
-	GetCountURLs(context.Context) (int, error)
-	GetCountUsers(context.Context) (int, error)
+	SelectOriginal(context.Context, string, string) (models.URLBase, error)
+	SelectShort(context.Context, string, string, string) (string, error)
+	SelectAll(context.Context, string, models.URLFilter) ([]models.URLBase, error)
+	SelectPage(context.Context, string, models.URLCursor) ([]models.URLBase, int64, error)
+	Update(context.Context, models.URLUpdate) error
+	RecordClick(context.Context, string, string, int) error
+	ConsumeClick(context.Context, string, string) error
+	SelectForHealthCheck(context.Context) ([]models.URLBase, error)
+	UpdateHealth(context.Context, string, string, models.URLHealth) error
+	Delete(context.Context, []models.URLBase) ([]models.URLBase, error)
+	GetStats(context.Context, models.StatsFilter) (*models.Stats, error)
+	LookupURLs(context.Context, string, models.URLLookup) ([]models.URLBase, error)
+	NextID(context.Context) (uint64, error)
+	WebhookRepository
+	AdminRepository
 	Close() error
 }
 
@@ -38,17 +74,160 @@ func convertToSingleType(urlIn any) models.URLBase {
 	return models.URLBase{}
 }
 
+// normalizeTags - удаление пробелов, пустых значений и дубликатов тегов.
+func normalizeTags(tags []string) []string {
+	if tags == nil {
+		return nil
+	}
+
+	normalized := make([]string, 0, len(tags))
+	for _, tag := range tags {
+		tag = strings.TrimSpace(tag)
+		if tag != "" && !slices.Contains(normalized, tag) {
+			normalized = append(normalized, tag)
+		}
+	}
+	return normalized
+}
+
+// normalizeDestinations - удаление пробелов и адресов назначения без URL или с неположительным весом.
+// Если остается меньше двух адресов, распределение переходов не требуется.
+func normalizeDestinations(destinations []models.Destination) []models.Destination {
+	normalized := make([]models.Destination, 0, len(destinations))
+	for _, destination := range destinations {
+		destination.URL = strings.TrimSpace(destination.URL)
+		if destination.URL == "" || destination.Weight <= 0 {
+			continue
+		}
+		normalized = append(normalized, models.Destination{URL: destination.URL, Weight: destination.Weight})
+	}
+	if len(normalized) < 2 {
+		return nil
+	}
+	return normalized
+}
+
+// normalizeRules - приведение правил перенаправления к единому виду.
+// Удаляются правила без URL, без условий или с неизвестной платформой.
+func normalizeRules(rules []models.TargetRule) []models.TargetRule {
+	normalized := make([]models.TargetRule, 0, len(rules))
+	for _, rule := range rules {
+		rule.URL = strings.TrimSpace(rule.URL)
+		rule.Platform = strings.ToLower(strings.TrimSpace(rule.Platform))
+		rule.Language = strings.ToLower(strings.TrimSpace(rule.Language))
+		if rule.URL == "" || (rule.Platform == "" && rule.Language == "") {
+			continue
+		}
+		if rule.Platform != "" && !slices.Contains(redirect.Platforms, rule.Platform) {
+			continue
+		}
+		normalized = append(normalized, rule)
+	}
+	if len(normalized) == 0 {
+		return nil
+	}
+	return normalized
+}
+
+// normalizeNotBefore - приведение времени активации к UTC, нулевое время означает отсутствие ограничения.
+func normalizeNotBefore(notBefore *time.Time) *time.Time {
+	if notBefore == nil || notBefore.IsZero() {
+		return nil
+	}
+	utc := notBefore.UTC()
+	return &utc
+}
+
+// checkActive - проверка, что URL не отключен администратором и время его активации наступило.
+func checkActive(url models.URLBase) error {
+	if url.DisabledFlag {
+		return constants.ErrorURLDisabled
+	}
+	if url.NotBefore != nil && time.Now().Before(*url.NotBefore) {
+		return constants.ErrorURLNotYetActive
+	}
+	return nil
+}
+
+// consumeClick - списание перехода по URL с ограниченным количеством переходов.
+func (urlUseCase *URLUseCase) consumeClick(ctx context.Context, url models.URLBase) error {
+	if url.MaxClicks == nil {
+		return nil
+	}
+	if *url.MaxClicks <= 0 {
+		return constants.ErrorURLClicksExhausted
+	}
+	return urlUseCase.Repo.ConsumeClick(ctx, url.Domain, url.Short)
+}
+
+// recordClick - учет перехода по URL, position - номер выбранного адреса назначения или -1.
+// Ошибка учета перехода не должна мешать перенаправлению, поэтому только логируется.
+func (urlUseCase *URLUseCase) recordClick(ctx context.Context, url models.URLBase, position int) {
+	if err := urlUseCase.Repo.RecordClick(ctx, url.Domain, url.Short, position); err != nil {
+		logger.Sugar.Warnw("failed to record click", "short", url.Short, "domain", url.Domain, "error", err)
+	}
+}
+
+// normalizeURL - приведение атрибутов создаваемого URL к единому виду.
+func normalizeURL(url models.URLBase) models.URLBase {
+	url.Tags = normalizeTags(url.Tags)
+	url.Folder = strings.TrimSpace(url.Folder)
+	url.Title = strings.TrimSpace(url.Title)
+	url.Description = strings.TrimSpace(url.Description)
+	url.Destinations = normalizeDestinations(url.Destinations)
+	url.Rules = normalizeRules(url.Rules)
+	url.NotBefore = normalizeNotBefore(url.NotBefore)
+	if url.MaxClicks != nil && *url.MaxClicks <= 0 {
+		url.MaxClicks = nil
+	}
+	if url.Original == "" && len(url.Destinations) > 0 {
+		url.Original = url.Destinations[0].URL
+	}
+	return url
+}
+
+// hashInput - данные для создания короткого URL.
+// В режиме PerUserOriginals к оригинальному URL добавляется владелец, чтобы коды
+// пользователей одного оригинального URL не совпадали и не расходовали попытки на коллизии.
+func (urlUseCase *URLUseCase) hashInput(url models.URLBase) string {
+	if urlUseCase.PerUserOriginals {
+		return url.UUID + " " + url.Original
+	}
+	return url.Original
+}
+
+// titleQueueSize - максимальное число URL, ожидающих получения заголовка.
+const titleQueueSize = 1000
+
+// TitleFetcher - интерфейс для получения заголовка страницы по URL.
+type TitleFetcher interface {
+	Fetch(context.Context, string) (string, error)
+}
+
 // URLUseCase - структура создания короткого и получение оригинального url.
 type URLUseCase struct {
 	Repo    URLRepository
-	Service *service.Service
+	Service service.Service
+	// TitleFetcher - необязательный источник заголовков для URL, созданных без title.
+	// Заголовки получают обработчики, запущенные RunTitleWorkers.
+	TitleFetcher TitleFetcher
+	// RedirectPolicy - правила перенаправления по умолчанию, переопределяемые для отдельного URL.
+	RedirectPolicy redirect.Policy
+	// Events - необязательный получатель событий жизненного цикла URL.
+	Events EventPublisher
+	// PerUserOriginals - режим, в котором каждый пользователь получает собственный короткий URL
+	// для одного оригинального URL.
+	PerUserOriginals bool
+
+	titleJobs chan models.URLBase
 }
 
 // NewURLUseCase - создание структуры URLUseCase.
-func NewURLUseCase(repo URLRepository, service *service.Service) *URLUseCase {
+func NewURLUseCase(repo URLRepository, service service.Service) *URLUseCase {
 	return &URLUseCase{
-		Repo:    repo,
-		Service: service,
+		Repo:      repo,
+		Service:   service,
+		titleJobs: make(chan models.URLBase, titleQueueSize),
 	}
 }
 
@@ -58,18 +237,34 @@ func (urlUseCase *URLUseCase) Ping(ctx context.Context) error {
 }
 
 // CreateURLOrdinary - создание короткого URL и его запись в базу данных.
+// При коллизии короткого URL с другим оригинальным URL код подбирается повторно,
+// не более constants.ShortAttempts раз.
 func (urlUseCase *URLUseCase) CreateURLOrdinary(ctx context.Context, urlIn any) (models.URLBase, error) {
-	urlOrdinary := convertToSingleType(urlIn)
-	urlOrdinary.Short = urlUseCase.Service.ShortHash(urlOrdinary.Original, constants.HashLength)
+	urlOrdinary := normalizeURL(convertToSingleType(urlIn))
+	if err := urlUseCase.checkBlocked(ctx, urlOrdinary.UUID); err != nil {
+		return models.URLBase{}, err
+	}
 
-	err := urlUseCase.Repo.InsertOrdinary(ctx, urlOrdinary)
+	var err error
+	for attempt := range constants.ShortAttempts {
+		urlOrdinary.Short, err = urlUseCase.Service.Generate(ctx, urlUseCase.hashInput(urlOrdinary), attempt)
+		if err != nil {
+			return models.URLBase{}, err
+		}
+		err = urlUseCase.Repo.InsertOrdinary(ctx, urlOrdinary)
+		if !errors.Is(err, constants.ErrorShortCollision) {
+			break
+		}
+	}
 
 	if err == nil {
+		urlUseCase.fillTitles([]models.URLBase{urlOrdinary})
+		urlUseCase.publish(ctx, models.EventURLCreated, urlOrdinary)
 		return urlOrdinary, nil
 	}
 
 	if errors.Is(err, constants.ErrorURLAlreadyExist) {
-		urlOrdinary.Short, _ = urlUseCase.Repo.SelectShort(ctx, urlOrdinary.Original)
+		urlOrdinary.Short, _ = urlUseCase.Repo.SelectShort(ctx, urlOrdinary.Domain, urlOrdinary.UUID, urlOrdinary.Original)
 		return urlOrdinary, err
 	} else {
 		return urlOrdinary, err
@@ -80,41 +275,327 @@ func (urlUseCase *URLUseCase) CreateURLOrdinary(ctx context.Context, urlIn any)
 func (urlUseCase *URLUseCase) CreateURLBatch(ctx context.Context, urls []models.URLBase) ([]models.URLBase, error) {
 	var idxTemp int
 
-	for idx, url := range urls {
-		urls[idx].Short = urlUseCase.Service.ShortHash(url.Original, constants.HashLength)
+	if len(urls) > 0 {
+		if err := urlUseCase.checkBlocked(ctx, urls[0].UUID); err != nil {
+			return nil, err
+		}
+	}
+
+	for idx := range urls {
+		urls[idx] = normalizeURL(urls[idx])
+		short, err := urlUseCase.Service.Generate(ctx, urlUseCase.hashInput(urls[idx]), 0)
+		if err != nil {
+			return nil, err
+		}
+		urls[idx].Short = short
 
 		if idx%1000 == 0 || idx == len(urls)-1 {
 			urlsTemp := urls[idxTemp : idx+1]
 			idxTemp = idx + 1
 
-			err := urlUseCase.Repo.InsertBatch(ctx, urlsTemp)
+			err := urlUseCase.insertBatch(ctx, urlsTemp)
 			if err != nil {
 				return nil, err
 			}
 		}
 	}
+	urlUseCase.fillTitles(urls)
+	urlUseCase.publish(ctx, models.EventURLCreated, urls...)
 	return urls, nil
 }
 
-// GetOriginalURL - получение оригинального URL.
-func (urlUseCase *URLUseCase) GetOriginalURL(ctx context.Context, shortURL string) (string, error) {
-	originalURL, err := urlUseCase.Repo.SelectOriginal(ctx, shortURL)
+// insertBatch - запись пакета URL с повторным подбором кодов, совпавших с уже занятыми короткими URL.
+// Для каждого URL выполняется не более constants.ShortAttempts попыток.
+func (urlUseCase *URLUseCase) insertBatch(ctx context.Context, urls []models.URLBase) error {
+	attempts := make([]int, len(urls))
+	for {
+		err := urlUseCase.Repo.InsertBatch(ctx, urls)
+
+		var itemErr *models.BatchItemError
+		if !errors.Is(err, constants.ErrorShortCollision) || !errors.As(err, &itemErr) {
+			return err
+		}
+
+		idx := itemErr.Index
+		attempts[idx]++
+		if attempts[idx] >= constants.ShortAttempts {
+			return err
+		}
+		urls[idx].Short, err = urlUseCase.Service.Generate(ctx, urlUseCase.hashInput(urls[idx]), attempts[idx])
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// fillTitles - постановка URL, созданных без title, в очередь получения заголовков.
+// Если очередь заполнена, заголовок не запрашивается. Ошибки не влияют на результат создания URL.
+func (urlUseCase *URLUseCase) fillTitles(urls []models.URLBase) {
+	if urlUseCase.TitleFetcher == nil {
+		return
+	}
+
+	for _, url := range urls {
+		if url.Title != "" {
+			continue
+		}
+		select {
+		case urlUseCase.titleJobs <- url:
+		default:
+			logger.Sugar.Debugw("title fetch skipped, queue is full", "url", url.Original)
+		}
+	}
+}
+
+// RunTitleWorkers - запуск workers обработчиков очереди получения заголовков до отмены ctx.
+// Число обработчиков не должно превышать число одновременных запросов TitleFetcher.
+func (urlUseCase *URLUseCase) RunTitleWorkers(ctx context.Context, workers int) {
+	if workers <= 0 {
+		workers = 1
+	}
+
+	var wg sync.WaitGroup
+	for range workers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				select {
+				case url := <-urlUseCase.titleJobs:
+					urlUseCase.fillTitle(ctx, url)
+				case <-ctx.Done():
+					return
+				}
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+// fillTitle - получение и сохранение заголовка страницы для URL.
+func (urlUseCase *URLUseCase) fillTitle(ctx context.Context, url models.URLBase) {
+	title, err := urlUseCase.TitleFetcher.Fetch(ctx, url.Original)
 	if err != nil {
+		logger.Sugar.Debugw("title fetch skipped", "url", url.Original, "error", err)
+		return
+	}
+
+	err = urlUseCase.Repo.Update(ctx, models.URLUpdate{
+		UUID:   url.UUID,
+		Short:  url.Short,
+		Domain: url.Domain,
+		Title:  &title,
+	})
+	if err != nil {
+		logger.Sugar.Warnw("failed to save fetched title", "url", url.Original, "error", err)
+	}
+}
+
+// GetOriginalURL - получение оригинального URL в пространстве имен домена, пустой домен - домен по умолчанию.
+func (urlUseCase *URLUseCase) GetOriginalURL(ctx context.Context, domain, shortURL string) (string, error) {
+	url, err := urlUseCase.Repo.SelectOriginal(ctx, domain, shortURL)
+	if err != nil {
+		return "", err
+	}
+	if err = checkActive(url); err != nil {
+		return "", err
+	}
+	if err = urlUseCase.consumeClick(ctx, url); err != nil {
 		return "", err
 	}
+	urlUseCase.recordClick(ctx, url, -1)
 
-	return originalURL, nil
+	return url.Original, nil
+}
+
+// GetRedirectURL - получение адреса перенаправления с учетом правил по платформе и языку,
+// адресов назначения, параметров и сегментов пути входящего запроса.
+// Правила проверяются первыми, при отсутствии совпадений используются адреса назначения
+// или оригинальный URL.
+func (urlUseCase *URLUseCase) GetRedirectURL(ctx context.Context, req models.RedirectRequest) (models.RedirectResult, error) {
+	url, err := urlUseCase.Repo.SelectOriginal(ctx, req.Domain, req.Short)
+	if err != nil {
+		return models.RedirectResult{}, err
+	}
+	if err = checkActive(url); err != nil {
+		return models.RedirectResult{}, err
+	}
+
+	var result models.RedirectResult
+	destination := url.Original
+	position := -1
+	result.Targeted = len(url.Rules) > 0
+	if idx, ok := redirect.MatchRule(url.Rules, req.UserAgent, req.AcceptLanguage); ok {
+		destination = url.Rules[idx].URL
+	} else if len(url.Destinations) > 0 {
+		var key string
+		if req.VisitorID != "" {
+			key = req.VisitorID + ":" + req.Short
+		}
+		position = redirect.PickDestination(url.Destinations, key)
+		destination = url.Destinations[position].URL
+		result.Sticky = true
+	}
+
+	policy := urlUseCase.RedirectPolicy
+	if url.PassQuery != nil {
+		policy.PassQuery = *url.PassQuery
+	}
+	if url.PassPath != nil {
+		policy.PassPath = *url.PassPath
+	}
+
+	result.URL, err = redirect.BuildURL(destination, policy, req.RawQuery, req.ExtraPath)
+	if errors.Is(err, redirect.ErrPathNotAllowed) {
+		return models.RedirectResult{}, constants.ErrorURLNotExist
+	}
+	if err != nil {
+		return models.RedirectResult{}, err
+	}
+
+	if err = urlUseCase.consumeClick(ctx, url); err != nil {
+		return models.RedirectResult{}, err
+	}
+
+	urlUseCase.recordClick(ctx, url, position)
+	urlUseCase.publish(ctx, models.EventURLFollowed, url)
+	return result, nil
 }
 
 // GetAllURLs - получение всех когда-либо сокращенных пользователем URL.
-func (urlUseCase *URLUseCase) GetAllURLs(ctx context.Context, userID string) ([]models.URLBase, error) {
-	urls, err := urlUseCase.Repo.SelectAll(ctx, userID)
+func (urlUseCase *URLUseCase) GetAllURLs(ctx context.Context, userID string, filter models.URLFilter) ([]models.URLBase, error) {
+	urls, err := urlUseCase.Repo.SelectAll(ctx, userID, filter)
 	if err != nil {
 		return nil, err
 	}
 	return urls, nil
 }
 
+// GetURLsPage - получение страницы URL пользователя, начиная с позиции из токена продолжения.
+// Пустой токен - начало списка.
+func (urlUseCase *URLUseCase) GetURLsPage(ctx context.Context, userID string, filter models.URLFilter, token string, size int) (models.URLPage, error) {
+	after, err := decodePageToken(token)
+	if err != nil {
+		return models.URLPage{}, err
+	}
+
+	if size <= 0 {
+		size = pageDefaultSize
+	}
+	cursor := models.URLCursor{
+		Filter: filter,
+		After:  after,
+		Limit:  min(size, pageMaxSize),
+	}
+
+	urls, next, err := urlUseCase.Repo.SelectPage(ctx, userID, cursor)
+	if err != nil {
+		return models.URLPage{}, err
+	}
+
+	page := models.URLPage{URLs: urls}
+	if next > 0 {
+		page.NextToken = encodePageToken(next)
+	}
+	return page, nil
+}
+
+// encodePageToken - токен продолжения для позиции последнего URL страницы.
+func encodePageToken(position int64) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(strconv.FormatInt(position, 10)))
+}
+
+// decodePageToken - позиция последнего URL предыдущей страницы из токена продолжения.
+func decodePageToken(token string) (int64, error) {
+	if token == "" {
+		return 0, nil
+	}
+	data, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return 0, constants.ErrorInvalidPageToken
+	}
+	position, err := strconv.ParseInt(string(data), 10, 64)
+	if err != nil || position <= 0 {
+		return 0, constants.ErrorInvalidPageToken
+	}
+	return position, nil
+}
+
+// LookupURLs - поиск URL пользователя по оригинальному URL, хосту, префиксу или подстроке.
+// По умолчанию ищется точное совпадение; для поиска по хосту допускается полный URL.
+func (urlUseCase *URLUseCase) LookupURLs(ctx context.Context, userID string, lookup models.URLLookup) ([]models.URLBase, error) {
+	lookup, err := normalizeLookup(lookup)
+	if err != nil {
+		return nil, err
+	}
+	return urlUseCase.Repo.LookupURLs(ctx, userID, lookup)
+}
+
+// normalizeLookup - проверка способа и значения поиска, установка ограничения числа URL.
+func normalizeLookup(lookup models.URLLookup) (models.URLLookup, error) {
+	lookup.Query = strings.TrimSpace(lookup.Query)
+	if lookup.Mode == "" {
+		lookup.Mode = models.LookupExact
+	}
+
+	switch lookup.Mode {
+	case models.LookupExact, models.LookupPrefix, models.LookupContains:
+	case models.LookupHost:
+		if u, err := url.Parse(lookup.Query); err == nil && u.Host != "" {
+			lookup.Query = u.Hostname()
+		}
+		lookup.Query = strings.TrimSuffix(strings.ToLower(lookup.Query), ".")
+	default:
+		return lookup, constants.ErrorInvalidLookup
+	}
+	if lookup.Query == "" {
+		return lookup, constants.ErrorInvalidLookup
+	}
+
+	if lookup.Limit <= 0 {
+		lookup.Limit = lookupDefaultLimit
+	}
+	lookup.Limit = min(lookup.Limit, lookupMaxLimit)
+	return lookup, nil
+}
+
+// UpdateURL - изменение атрибутов сокращенного URL.
+func (urlUseCase *URLUseCase) UpdateURL(ctx context.Context, update models.URLUpdate) error {
+	if update.Tags != nil {
+		tags := normalizeTags(*update.Tags)
+		update.Tags = &tags
+	}
+	if update.Folder != nil {
+		folder := strings.TrimSpace(*update.Folder)
+		update.Folder = &folder
+	}
+	if update.Title != nil {
+		title := strings.TrimSpace(*update.Title)
+		update.Title = &title
+	}
+	if update.Description != nil {
+		description := strings.TrimSpace(*update.Description)
+		update.Description = &description
+	}
+	if update.Destinations != nil {
+		destinations := normalizeDestinations(*update.Destinations)
+		update.Destinations = &destinations
+	}
+	if update.Rules != nil {
+		rules := normalizeRules(*update.Rules)
+		update.Rules = &rules
+	}
+	if update.NotBefore != nil {
+		notBefore := update.NotBefore.UTC()
+		update.NotBefore = &notBefore
+	}
+	if update.MaxClicks != nil && *update.MaxClicks < 0 {
+		maxClicks := 0
+		update.MaxClicks = &maxClicks
+	}
+	return urlUseCase.Repo.Update(ctx, update)
+}
+
 // generator - генерирует сообщения в канал.
 func (urlUseCase *URLUseCase) generator(ctx context.Context, urls []models.URLBase, inChan chan models.URLBase) {
 	for _, url := range urls {
@@ -126,28 +607,39 @@ func (urlUseCase *URLUseCase) generator(ctx context.Context, urls []models.URLBa
 	}
 }
 
+// deleteResult - результат удаления пакета URL.
+type deleteResult struct {
+	deleted []models.URLBase
+	err     error
+}
+
 // worker - работник.
-func (urlUseCase *URLUseCase) worker(ctx context.Context, urls <-chan models.URLBase, result chan error) {
+func (urlUseCase *URLUseCase) worker(ctx context.Context, urls <-chan models.URLBase, result chan deleteResult) {
 	urlsToDB := make([]models.URLBase, 0, 100)
 
+	flush := func() {
+		deleted, err := urlUseCase.Repo.Delete(ctx, urlsToDB)
+		result <- deleteResult{deleted: deleted, err: err}
+	}
+
 	for {
 		select {
 		case <-ctx.Done():
 			if len(urlsToDB) > 0 {
-				result <- urlUseCase.Repo.Delete(ctx, urlsToDB)
+				flush()
 			}
 			return
 
 		case url, ok := <-urls:
 			if !ok {
 				if len(urlsToDB) > 0 {
-					result <- urlUseCase.Repo.Delete(ctx, urlsToDB)
+					flush()
 				}
 				return
 			}
 			urlsToDB = append(urlsToDB, url)
 			if len(urlsToDB) >= 1 {
-				result <- urlUseCase.Repo.Delete(ctx, urlsToDB)
+				flush()
 				urlsToDB = urlsToDB[:0]
 			}
 
@@ -157,10 +649,11 @@ func (urlUseCase *URLUseCase) worker(ctx context.Context, urls <-chan models.URL
 }
 
 // DeleteURLs - удаление сокращенных URL.
+// События об удалении публикуются только для URL, удаленных этим вызовом.
 func (urlUseCase *URLUseCase) DeleteURLs(ctx context.Context, urls []models.URLBase) error {
 	const numWorkers = 3
 	inChan := make(chan models.URLBase, 1024)
-	resultChan := make(chan error, numWorkers)
+	resultChan := make(chan deleteResult, numWorkers)
 	var wg sync.WaitGroup
 
 	go func() {
@@ -181,27 +674,37 @@ func (urlUseCase *URLUseCase) DeleteURLs(ctx context.Context, urls []models.URLB
 		close(resultChan)
 	}()
 
-	var firstErr error
-	for err := range resultChan {
-		if err != nil && firstErr == nil {
-			firstErr = err
+	var (
+		firstErr error
+		deleted  []models.URLBase
+	)
+	for result := range resultChan {
+		if result.err != nil && firstErr == nil {
+			firstErr = result.err
 		}
+		deleted = append(deleted, result.deleted...)
 	}
+	urlUseCase.publish(ctx, models.EventURLDeleted, deleted...)
 	return firstErr
 
 }
 
 // GetStats - получение статистики по записям и пользователям.
-func (urlUseCase *URLUseCase) GetStats(ctx context.Context) (int, int, error) {
-	countURLs, err := urlUseCase.Repo.GetCountURLs(ctx)
-	if err != nil {
-		return 0, 0, err
+// По умолчанию период охватывает последние statsDefaultPeriod, рейтинги содержат statsDefaultTop записей.
+func (urlUseCase *URLUseCase) GetStats(ctx context.Context, filter models.StatsFilter) (*models.Stats, error) {
+	if filter.To.IsZero() {
+		filter.To = time.Now().UTC()
 	}
-
-	countUsers, err := urlUseCase.Repo.GetCountUsers(ctx)
-	if err != nil {
-		return 0, 0, err
+	if filter.From.IsZero() {
+		filter.From = filter.To.Add(-statsDefaultPeriod)
+	}
+	if !filter.From.Before(filter.To) {
+		return nil, constants.ErrorInvalidStatsRange
+	}
+	if filter.Top <= 0 {
+		filter.Top = statsDefaultTop
 	}
+	filter.Top = min(filter.Top, statsMaxTop)
 
-	return countURLs, countUsers, nil
+	return urlUseCase.Repo.GetStats(ctx, filter)
 }
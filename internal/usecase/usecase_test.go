@@ -2,13 +2,19 @@ package usecase
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"reflect"
 	"testing"
+	"time"
 
 	"github.com/Di-nis/shortener-url/internal/constants"
 	"github.com/Di-nis/shortener-url/internal/mocks"
 	"github.com/Di-nis/shortener-url/internal/models"
+	"github.com/Di-nis/shortener-url/internal/redirect"
+	"github.com/Di-nis/shortener-url/internal/repository"
 	"github.com/Di-nis/shortener-url/internal/service"
+	"github.com/Di-nis/shortener-url/internal/storage"
 	"github.com/golang/mock/gomock"
 )
 
@@ -20,6 +26,10 @@ var (
 	urlShort2    = "kiFL71uv"
 	urlOriginal3 = "https://chatgpt.com/"
 	urlShort3    = "826drChJ"
+	// urlShortSalted1 - короткий URL для urlOriginal1 со второй попытки после коллизии
+	urlShortSalted1 = "d28mqxFb"
+	// urlShortSalted4 - короткий URL для urlOriginal1 с последней попытки после коллизий
+	urlShortSalted4 = "4VW1rylC"
 
 	urlIn1 = models.URLBase{
 		UUID:        UUID,
@@ -105,6 +115,7 @@ func TestURLUseCase_CreateURLOrdinary(t *testing.T) {
 			name:  "создание короткого URL, кейс 1",
 			urlIn: urlIn1,
 			mock: func(mockRepo *mocks.MockURLRepository) {
+				mockRepo.EXPECT().IsUserBlocked(gomock.Any(), UUID).Return(false, nil)
 				mockRepo.EXPECT().InsertOrdinary(gomock.Any(), urlOut1).Return(nil)
 			},
 			want:    urlOut1,
@@ -114,12 +125,54 @@ func TestURLUseCase_CreateURLOrdinary(t *testing.T) {
 			name:  "создание короткого URL, кейс 2",
 			urlIn: urlIn2,
 			mock: func(mockRepo *mocks.MockURLRepository) {
+				mockRepo.EXPECT().IsUserBlocked(gomock.Any(), UUID).Return(false, nil)
 				mockRepo.EXPECT().InsertOrdinary(gomock.Any(), urlOut2).Return(constants.ErrorURLAlreadyExist)
-				mockRepo.EXPECT().SelectShort(gomock.Any(), urlOriginal2).Return(urlShort2, nil)
+				mockRepo.EXPECT().SelectShort(gomock.Any(), "", UUID, urlOriginal2).Return(urlShort2, nil)
 			},
 			want:    urlOut2,
 			wantErr: constants.ErrorURLAlreadyExist,
 		},
+		{
+			name:  "коллизия короткого URL, повторный подбор кода",
+			urlIn: urlIn1,
+			mock: func(mockRepo *mocks.MockURLRepository) {
+				urlSalted := urlOut1
+				urlSalted.Short = urlShortSalted1
+
+				mockRepo.EXPECT().IsUserBlocked(gomock.Any(), UUID).Return(false, nil)
+				mockRepo.EXPECT().InsertOrdinary(gomock.Any(), urlOut1).Return(constants.ErrorShortCollision)
+				mockRepo.EXPECT().InsertOrdinary(gomock.Any(), urlSalted).Return(nil)
+			},
+			want: models.URLBase{
+				UUID:     UUID,
+				Original: urlOriginal1,
+				Short:    urlShortSalted1,
+			},
+			wantErr: nil,
+		},
+		{
+			name:  "коллизии короткого URL, попытки исчерпаны",
+			urlIn: urlIn1,
+			mock: func(mockRepo *mocks.MockURLRepository) {
+				mockRepo.EXPECT().IsUserBlocked(gomock.Any(), UUID).Return(false, nil)
+				mockRepo.EXPECT().InsertOrdinary(gomock.Any(), gomock.Any()).Return(constants.ErrorShortCollision).Times(constants.ShortAttempts)
+			},
+			want: models.URLBase{
+				UUID:     UUID,
+				Original: urlOriginal1,
+				Short:    urlShortSalted4,
+			},
+			wantErr: constants.ErrorShortCollision,
+		},
+		{
+			name:  "пользователю запрещено создавать URL",
+			urlIn: urlIn1,
+			mock: func(mockRepo *mocks.MockURLRepository) {
+				mockRepo.EXPECT().IsUserBlocked(gomock.Any(), UUID).Return(true, nil)
+			},
+			want:    models.URLBase{},
+			wantErr: constants.ErrorUserBlocked,
+		},
 	}
 	for _, tt := range tests {
 		ctrl := gomock.NewController(t)
@@ -132,7 +185,7 @@ func TestURLUseCase_CreateURLOrdinary(t *testing.T) {
 		useCase := NewURLUseCase(mockRepo, service)
 
 		got, gotErr := useCase.CreateURLOrdinary(context.Background(), tt.urlIn)
-		if got != tt.want {
+		if !reflect.DeepEqual(got, tt.want) {
 			t.Errorf("CreateURLOrdinary() = %v, want %v", got, tt.want)
 		}
 		if gotErr != tt.wantErr {
@@ -141,6 +194,111 @@ func TestURLUseCase_CreateURLOrdinary(t *testing.T) {
 	}
 }
 
+// titleFetcherStub - заглушка для получения заголовка страницы.
+type titleFetcherStub struct {
+	title string
+}
+
+// Fetch - возвращает заранее заданный заголовок.
+func (f titleFetcherStub) Fetch(context.Context, string) (string, error) {
+	return f.title, nil
+}
+
+func TestURLUseCase_CreateURLOrdinaryFetchTitle(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	title := "КХЛ"
+	done := make(chan struct{})
+
+	mockRepo := mocks.NewMockURLRepository(ctrl)
+	mockRepo.EXPECT().IsUserBlocked(gomock.Any(), UUID).Return(false, nil)
+	mockRepo.EXPECT().InsertOrdinary(gomock.Any(), urlOut1).Return(nil)
+	mockRepo.EXPECT().Update(gomock.Any(), models.URLUpdate{
+		UUID:  UUID,
+		Short: urlShort1,
+		Title: &title,
+	}).DoAndReturn(func(context.Context, models.URLUpdate) error {
+		close(done)
+		return nil
+	})
+
+	useCase := NewURLUseCase(mockRepo, service.NewService())
+	useCase.TitleFetcher = titleFetcherStub{title: title}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go useCase.RunTitleWorkers(ctx, 1)
+
+	got, gotErr := useCase.CreateURLOrdinary(context.Background(), urlIn1)
+	if !reflect.DeepEqual(got, urlOut1) {
+		t.Errorf("CreateURLOrdinary() = %v, want %v", got, urlOut1)
+	}
+	if gotErr != nil {
+		t.Errorf("CreateURLOrdinary() = %v, wantErr %v", gotErr, nil)
+	}
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Error("CreateURLOrdinary(), title was not saved")
+	}
+}
+
+func TestURLUseCase_RunTitleWorkers(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	title := "КХЛ"
+	domain := "khl.example.com"
+	done := make(chan struct{})
+
+	mockRepo := mocks.NewMockURLRepository(ctrl)
+	mockRepo.EXPECT().Update(gomock.Any(), models.URLUpdate{
+		UUID:   UUID,
+		Short:  urlShort1,
+		Domain: domain,
+		Title:  &title,
+	}).DoAndReturn(func(context.Context, models.URLUpdate) error {
+		close(done)
+		return nil
+	})
+
+	useCase := NewURLUseCase(mockRepo, service.NewService())
+	useCase.TitleFetcher = titleFetcherStub{title: title}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	stopped := make(chan struct{})
+	go func() {
+		defer close(stopped)
+		useCase.RunTitleWorkers(ctx, 2)
+	}()
+
+	// заголовок сохраняется для URL на дополнительном домене
+	useCase.fillTitles([]models.URLBase{{UUID: UUID, Original: urlOriginal1, Short: urlShort1, Domain: domain}})
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Error("RunTitleWorkers(), title was not saved")
+	}
+
+	// обработчики завершаются при отмене контекста
+	cancel()
+	select {
+	case <-stopped:
+	case <-time.After(time.Second):
+		t.Error("RunTitleWorkers(), workers did not stop")
+	}
+
+	// при заполненной очереди URL пропускаются без блокировки
+	for range titleQueueSize + 1 {
+		useCase.fillTitles([]models.URLBase{{UUID: UUID, Original: urlOriginal1, Short: urlShort1}})
+	}
+	if len(useCase.titleJobs) != titleQueueSize {
+		t.Errorf("fillTitles(), queue length = %d, want %d", len(useCase.titleJobs), titleQueueSize)
+	}
+}
+
 func TestURLUseCase_CreateURLBatch(t *testing.T) {
 	tests := []struct {
 		name    string
@@ -153,11 +311,28 @@ func TestURLUseCase_CreateURLBatch(t *testing.T) {
 			name: "создание коротких URL (batch), кейс 1",
 			urls: urlsIn,
 			mock: func(mockRepo *mocks.MockURLRepository) {
+				mockRepo.EXPECT().IsUserBlocked(gomock.Any(), UUID).Return(false, nil)
 				mockRepo.EXPECT().InsertBatch(gomock.Any(), urlsOut).Return(nil)
 			},
 			want:    urlsOut,
 			wantErr: nil,
 		},
+		{
+			name: "коллизия короткого URL в пакете, повторный подбор кода",
+			urls: []models.URLBase{{UUID: UUID, Original: urlOriginal1}},
+			mock: func(mockRepo *mocks.MockURLRepository) {
+				urlsSalted := []models.URLBase{{UUID: UUID, Original: urlOriginal1, Short: urlShortSalted1}}
+
+				mockRepo.EXPECT().IsUserBlocked(gomock.Any(), UUID).Return(false, nil)
+				gomock.InOrder(
+					mockRepo.EXPECT().InsertBatch(gomock.Any(), urlsOut).
+						Return(&models.BatchItemError{Index: 0, Err: constants.ErrorShortCollision}),
+					mockRepo.EXPECT().InsertBatch(gomock.Any(), urlsSalted).Return(nil),
+				)
+			},
+			want:    []models.URLBase{{UUID: UUID, Original: urlOriginal1, Short: urlShortSalted1}},
+			wantErr: nil,
+		},
 	}
 	for _, tt := range tests {
 		ctrl := gomock.NewController(t)
@@ -179,9 +354,74 @@ func TestURLUseCase_CreateURLBatch(t *testing.T) {
 	}
 }
 
+func TestURLUseCase_CreateURLPerUserOriginals(t *testing.T) {
+	// пользователей больше, чем попыток подобрать свободный код
+	users := constants.ShortAttempts * 2
+
+	tests := []struct {
+		name   string
+		create func(*URLUseCase, string) (string, error)
+	}{
+		{
+			name: "создание короткого URL",
+			create: func(urlUseCase *URLUseCase, userID string) (string, error) {
+				url, err := urlUseCase.CreateURLOrdinary(context.Background(), models.URLBase{UUID: userID, Original: urlOriginal1})
+				return url.Short, err
+			},
+		},
+		{
+			name: "создание пакета коротких URL",
+			create: func(urlUseCase *URLUseCase, userID string) (string, error) {
+				urls, err := urlUseCase.CreateURLBatch(context.Background(), []models.URLBase{{UUID: userID, Original: urlOriginal1}})
+				if err != nil {
+					return "", err
+				}
+				return urls[0].Short, nil
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			urls := make([]models.URLBase, 0)
+			repo := repository.NewRepoFileMemory(&repository.Storage{
+				Consumer: storage.NewConsumerMemory(urls),
+				Producer: storage.NewProducerMemory(urls),
+			})
+			repo.PerUserOriginals = true
+
+			urlUseCase := NewURLUseCase(repo, service.NewService())
+			urlUseCase.PerUserOriginals = true
+
+			shorts := make(map[string]bool)
+			for i := range users {
+				short, err := tt.create(urlUseCase, fmt.Sprintf("user-%d", i))
+				if err != nil {
+					t.Fatalf("user-%d: error = %v, want nil", i, err)
+				}
+				if shorts[short] {
+					t.Errorf("user-%d: short %s is already used", i, short)
+				}
+				shorts[short] = true
+			}
+		})
+	}
+}
+
 func TestURLUseCase_GetOriginalURL(t *testing.T) {
+	notBeforeFuture := time.Now().Add(time.Hour)
+	urlEmbargo := urlOut1
+	urlEmbargo.NotBefore = &notBeforeFuture
+
+	notBeforePast := time.Now().Add(-time.Hour)
+	urlActive := urlOut1
+	urlActive.NotBefore = &notBeforePast
+
+	urlBranded := urlOut1
+	urlBranded.Domain = "go.brand-a.com"
+
 	tests := []struct {
 		name     string
+		domain   string
 		shortURL string
 		mock     func(*mocks.MockURLRepository)
 		want     string
@@ -191,7 +431,38 @@ func TestURLUseCase_GetOriginalURL(t *testing.T) {
 			name:     "получение оригинального URL, кейс 1",
 			shortURL: urlShort1,
 			mock: func(mockRepo *mocks.MockURLRepository) {
-				mockRepo.EXPECT().SelectOriginal(gomock.Any(), urlShort1).Return(urlOriginal1, nil)
+				mockRepo.EXPECT().SelectOriginal(gomock.Any(), "", urlShort1).Return(urlOut1, nil)
+				mockRepo.EXPECT().RecordClick(gomock.Any(), "", urlShort1, -1).Return(nil)
+			},
+			want:    urlOriginal1,
+			wantErr: nil,
+		},
+		{
+			name:     "получение оригинального URL в домене",
+			domain:   urlBranded.Domain,
+			shortURL: urlShort1,
+			mock: func(mockRepo *mocks.MockURLRepository) {
+				mockRepo.EXPECT().SelectOriginal(gomock.Any(), urlBranded.Domain, urlShort1).Return(urlBranded, nil)
+				mockRepo.EXPECT().RecordClick(gomock.Any(), urlBranded.Domain, urlShort1, -1).Return(nil)
+			},
+			want:    urlOriginal1,
+			wantErr: nil,
+		},
+		{
+			name:     "время активации URL не наступило",
+			shortURL: urlShort1,
+			mock: func(mockRepo *mocks.MockURLRepository) {
+				mockRepo.EXPECT().SelectOriginal(gomock.Any(), "", urlShort1).Return(urlEmbargo, nil)
+			},
+			want:    "",
+			wantErr: constants.ErrorURLNotYetActive,
+		},
+		{
+			name:     "время активации URL наступило",
+			shortURL: urlShort1,
+			mock: func(mockRepo *mocks.MockURLRepository) {
+				mockRepo.EXPECT().SelectOriginal(gomock.Any(), "", urlShort1).Return(urlActive, nil)
+				mockRepo.EXPECT().RecordClick(gomock.Any(), "", urlShort1, -1).Return(nil)
 			},
 			want:    urlOriginal1,
 			wantErr: nil,
@@ -207,7 +478,7 @@ func TestURLUseCase_GetOriginalURL(t *testing.T) {
 		service := service.NewService()
 		useCase := NewURLUseCase(mockRepo, service)
 
-		got, gotErr := useCase.GetOriginalURL(context.Background(), tt.shortURL)
+		got, gotErr := useCase.GetOriginalURL(context.Background(), tt.domain, tt.shortURL)
 		if got != tt.want {
 			t.Errorf("GetOriginalURL() = %v, want %v", got, tt.want)
 		}
@@ -218,6 +489,165 @@ func TestURLUseCase_GetOriginalURL(t *testing.T) {
 	}
 }
 
+func TestURLUseCase_GetRedirectURL(t *testing.T) {
+	passQuery := false
+	urlNoQuery := urlOut1
+	urlNoQuery.PassQuery = &passQuery
+
+	urlAB := urlOut1
+	urlAB.Destinations = []models.Destination{
+		{URL: "https://www.khl.ru/landing-a", Weight: 1},
+		{URL: "https://www.khl.ru/landing-b", Weight: 0},
+	}
+
+	clicksLeft, noClicksLeft := 1, 0
+	urlLimited := urlOut1
+	urlLimited.MaxClicks = &clicksLeft
+	urlExhausted := urlOut1
+	urlExhausted.MaxClicks = &noClicksLeft
+
+	urlDisabled := urlOut1
+	urlDisabled.DisabledFlag = true
+
+	urlTargeted := urlAB
+	urlTargeted.Rules = []models.TargetRule{
+		{Platform: "ios", URL: "https://apps.apple.com/ru/app/khl/id1"},
+		{Platform: "android", URL: "https://play.google.com/store/apps/details?id=ru.khl"},
+	}
+
+	tests := []struct {
+		name    string
+		req     models.RedirectRequest
+		mock    func(*mocks.MockURLRepository)
+		want    models.RedirectResult
+		wantErr error
+	}{
+		{
+			name: "перенос параметров по правилу сервера",
+			req:  models.RedirectRequest{Short: urlShort1, RawQuery: "utm_source=telegram"},
+			mock: func(mockRepo *mocks.MockURLRepository) {
+				mockRepo.EXPECT().SelectOriginal(gomock.Any(), "", urlShort1).Return(urlOut1, nil)
+				mockRepo.EXPECT().RecordClick(gomock.Any(), "", urlShort1, -1).Return(nil)
+			},
+			want:    models.RedirectResult{URL: urlOriginal1 + "?utm_source=telegram"},
+			wantErr: nil,
+		},
+		{
+			name: "URL отключен администратором",
+			req:  models.RedirectRequest{Short: urlShort1},
+			mock: func(mockRepo *mocks.MockURLRepository) {
+				mockRepo.EXPECT().SelectOriginal(gomock.Any(), "", urlShort1).Return(urlDisabled, nil)
+			},
+			want:    models.RedirectResult{},
+			wantErr: constants.ErrorURLDisabled,
+		},
+		{
+			name: "перенос параметров отключен для URL",
+			req:  models.RedirectRequest{Short: urlShort1, RawQuery: "utm_source=telegram"},
+			mock: func(mockRepo *mocks.MockURLRepository) {
+				mockRepo.EXPECT().SelectOriginal(gomock.Any(), "", urlShort1).Return(urlNoQuery, nil)
+				mockRepo.EXPECT().RecordClick(gomock.Any(), "", urlShort1, -1).Return(errors.New("db error"))
+			},
+			want:    models.RedirectResult{URL: urlOriginal1},
+			wantErr: nil,
+		},
+		{
+			name: "выбор адреса назначения по весу",
+			req:  models.RedirectRequest{Short: urlShort1, VisitorID: "visitor-1"},
+			mock: func(mockRepo *mocks.MockURLRepository) {
+				mockRepo.EXPECT().SelectOriginal(gomock.Any(), "", urlShort1).Return(urlAB, nil)
+				mockRepo.EXPECT().RecordClick(gomock.Any(), "", urlShort1, 0).Return(nil)
+			},
+			want:    models.RedirectResult{URL: "https://www.khl.ru/landing-a", Sticky: true},
+			wantErr: nil,
+		},
+		{
+			name: "правило по платформе",
+			req: models.RedirectRequest{
+				Short:     urlShort1,
+				UserAgent: "Mozilla/5.0 (Linux; Android 14; Pixel 8) AppleWebKit/537.36 Mobile Safari/537.36",
+			},
+			mock: func(mockRepo *mocks.MockURLRepository) {
+				mockRepo.EXPECT().SelectOriginal(gomock.Any(), "", urlShort1).Return(urlTargeted, nil)
+				mockRepo.EXPECT().RecordClick(gomock.Any(), "", urlShort1, -1).Return(nil)
+			},
+			want:    models.RedirectResult{URL: "https://play.google.com/store/apps/details?id=ru.khl", Targeted: true},
+			wantErr: nil,
+		},
+		{
+			name: "правила не подошли, выбор адреса назначения по весу",
+			req: models.RedirectRequest{
+				Short:     urlShort1,
+				VisitorID: "visitor-1",
+				UserAgent: "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 Chrome/126.0 Safari/537.36",
+			},
+			mock: func(mockRepo *mocks.MockURLRepository) {
+				mockRepo.EXPECT().SelectOriginal(gomock.Any(), "", urlShort1).Return(urlTargeted, nil)
+				mockRepo.EXPECT().RecordClick(gomock.Any(), "", urlShort1, 0).Return(nil)
+			},
+			want:    models.RedirectResult{URL: "https://www.khl.ru/landing-a", Sticky: true, Targeted: true},
+			wantErr: nil,
+		},
+		{
+			name: "переход по URL с ограничением количества переходов",
+			req:  models.RedirectRequest{Short: urlShort1},
+			mock: func(mockRepo *mocks.MockURLRepository) {
+				mockRepo.EXPECT().SelectOriginal(gomock.Any(), "", urlShort1).Return(urlLimited, nil)
+				mockRepo.EXPECT().ConsumeClick(gomock.Any(), "", urlShort1).Return(nil)
+				mockRepo.EXPECT().RecordClick(gomock.Any(), "", urlShort1, -1).Return(nil)
+			},
+			want:    models.RedirectResult{URL: urlOriginal1},
+			wantErr: nil,
+		},
+		{
+			name: "лимит переходов исчерпан при одновременном переходе",
+			req:  models.RedirectRequest{Short: urlShort1},
+			mock: func(mockRepo *mocks.MockURLRepository) {
+				mockRepo.EXPECT().SelectOriginal(gomock.Any(), "", urlShort1).Return(urlLimited, nil)
+				mockRepo.EXPECT().ConsumeClick(gomock.Any(), "", urlShort1).Return(constants.ErrorURLClicksExhausted)
+			},
+			want:    models.RedirectResult{},
+			wantErr: constants.ErrorURLClicksExhausted,
+		},
+		{
+			name: "лимит переходов исчерпан",
+			req:  models.RedirectRequest{Short: urlShort1},
+			mock: func(mockRepo *mocks.MockURLRepository) {
+				mockRepo.EXPECT().SelectOriginal(gomock.Any(), "", urlShort1).Return(urlExhausted, nil)
+			},
+			want:    models.RedirectResult{},
+			wantErr: constants.ErrorURLClicksExhausted,
+		},
+		{
+			name: "перенос сегментов пути не разрешен",
+			req:  models.RedirectRequest{Short: urlShort1, ExtraPath: "news"},
+			mock: func(mockRepo *mocks.MockURLRepository) {
+				mockRepo.EXPECT().SelectOriginal(gomock.Any(), "", urlShort1).Return(urlOut1, nil)
+			},
+			want:    models.RedirectResult{},
+			wantErr: constants.ErrorURLNotExist,
+		},
+	}
+	for _, tt := range tests {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockRepo := mocks.NewMockURLRepository(ctrl)
+		tt.mock(mockRepo)
+
+		useCase := NewURLUseCase(mockRepo, service.NewService())
+		useCase.RedirectPolicy = redirect.Policy{PassQuery: true}
+
+		got, gotErr := useCase.GetRedirectURL(context.Background(), tt.req)
+		if got != tt.want {
+			t.Errorf("GetRedirectURL() = %v, want %v", got, tt.want)
+		}
+		if gotErr != tt.wantErr {
+			t.Errorf("GetRedirectURL() = %v, wantErr %v", gotErr, tt.wantErr)
+		}
+	}
+}
+
 func TestURLUseCase_GetAllURLs(t *testing.T) {
 	tests := []struct {
 		name    string
@@ -230,7 +660,7 @@ func TestURLUseCase_GetAllURLs(t *testing.T) {
 			name:   "получение всех URL пользователя, кейс 1",
 			userID: UUID,
 			mock: func(mockRepo *mocks.MockURLRepository) {
-				mockRepo.EXPECT().SelectAll(gomock.Any(), UUID).Return(urlsOut, nil)
+				mockRepo.EXPECT().SelectAll(gomock.Any(), UUID, models.URLFilter{}).Return(urlsOut, nil)
 			},
 			want:    urlsOut,
 			wantErr: nil,
@@ -246,7 +676,7 @@ func TestURLUseCase_GetAllURLs(t *testing.T) {
 		service := service.NewService()
 		useCase := NewURLUseCase(mockRepo, service)
 
-		got, gotErr := useCase.GetAllURLs(context.Background(), tt.userID)
+		got, gotErr := useCase.GetAllURLs(context.Background(), tt.userID, models.URLFilter{})
 		if !reflect.DeepEqual(got, tt.want) {
 			t.Errorf("GetAllURLs() = %v, want %v", got, tt.want)
 		}
@@ -257,6 +687,131 @@ func TestURLUseCase_GetAllURLs(t *testing.T) {
 	}
 }
 
+func TestURLUseCase_GetURLsPage(t *testing.T) {
+	filter := models.URLFilter{Folder: "sport"}
+
+	tests := []struct {
+		name    string
+		token   string
+		size    int
+		mock    func(*mocks.MockURLRepository)
+		want    models.URLPage
+		wantErr error
+	}{
+		{
+			name:  "первая страница с размером по умолчанию",
+			token: "",
+			size:  0,
+			mock: func(mockRepo *mocks.MockURLRepository) {
+				cursor := models.URLCursor{Filter: filter, After: 0, Limit: pageDefaultSize}
+				mockRepo.EXPECT().SelectPage(gomock.Any(), UUID, cursor).Return(urlsOut, int64(42), nil)
+			},
+			want:    models.URLPage{URLs: urlsOut, NextToken: encodePageToken(42)},
+			wantErr: nil,
+		},
+		{
+			name:  "последняя страница, ограничение размера",
+			token: encodePageToken(42),
+			size:  5000,
+			mock: func(mockRepo *mocks.MockURLRepository) {
+				cursor := models.URLCursor{Filter: filter, After: 42, Limit: pageMaxSize}
+				mockRepo.EXPECT().SelectPage(gomock.Any(), UUID, cursor).Return(urlsOut, int64(0), nil)
+			},
+			want:    models.URLPage{URLs: urlsOut},
+			wantErr: nil,
+		},
+		{
+			name:    "некорректный токен",
+			token:   "not a token",
+			size:    10,
+			mock:    func(mockRepo *mocks.MockURLRepository) {},
+			want:    models.URLPage{},
+			wantErr: constants.ErrorInvalidPageToken,
+		},
+	}
+	for _, tt := range tests {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockRepo := mocks.NewMockURLRepository(ctrl)
+		tt.mock(mockRepo)
+
+		useCase := NewURLUseCase(mockRepo, service.NewService())
+
+		got, gotErr := useCase.GetURLsPage(context.Background(), UUID, filter, tt.token, tt.size)
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("GetURLsPage() = %v, want %v", got, tt.want)
+		}
+		if gotErr != tt.wantErr {
+			t.Errorf("GetURLsPage() = %v, wantErr %v", gotErr, tt.wantErr)
+		}
+	}
+}
+
+func TestURLUseCase_LookupURLs(t *testing.T) {
+	tests := []struct {
+		name    string
+		lookup  models.URLLookup
+		mock    func(*mocks.MockURLRepository)
+		want    []models.URLBase
+		wantErr error
+	}{
+		{
+			name:   "точное совпадение по умолчанию",
+			lookup: models.URLLookup{Query: " " + urlOriginal1 + " "},
+			mock: func(mockRepo *mocks.MockURLRepository) {
+				mockRepo.EXPECT().LookupURLs(gomock.Any(), UUID, models.URLLookup{
+					Mode: models.LookupExact, Query: urlOriginal1, Limit: lookupDefaultLimit,
+				}).Return(urlsOut, nil)
+			},
+			want:    urlsOut,
+			wantErr: nil,
+		},
+		{
+			name:   "хост из полного URL, ограничение числа URL",
+			lookup: models.URLLookup{Mode: models.LookupHost, Query: "https://WWW.KHL.ru:443/news", Limit: 5000},
+			mock: func(mockRepo *mocks.MockURLRepository) {
+				mockRepo.EXPECT().LookupURLs(gomock.Any(), UUID, models.URLLookup{
+					Mode: models.LookupHost, Query: "www.khl.ru", Limit: lookupMaxLimit,
+				}).Return(urlsOut, nil)
+			},
+			want:    urlsOut,
+			wantErr: nil,
+		},
+		{
+			name:    "неизвестный способ поиска",
+			lookup:  models.URLLookup{Mode: "regexp", Query: "khl"},
+			mock:    func(mockRepo *mocks.MockURLRepository) {},
+			want:    nil,
+			wantErr: constants.ErrorInvalidLookup,
+		},
+		{
+			name:    "пустое значение поиска",
+			lookup:  models.URLLookup{Mode: models.LookupContains, Query: "  "},
+			mock:    func(mockRepo *mocks.MockURLRepository) {},
+			want:    nil,
+			wantErr: constants.ErrorInvalidLookup,
+		},
+	}
+	for _, tt := range tests {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockRepo := mocks.NewMockURLRepository(ctrl)
+		tt.mock(mockRepo)
+
+		useCase := NewURLUseCase(mockRepo, service.NewService())
+
+		got, gotErr := useCase.LookupURLs(context.Background(), UUID, tt.lookup)
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("LookupURLs() = %v, want %v", got, tt.want)
+		}
+		if gotErr != tt.wantErr {
+			t.Errorf("LookupURLs() = %v, wantErr %v", gotErr, tt.wantErr)
+		}
+	}
+}
+
 func TestURLUseCase_DeleteURLs(t *testing.T) {
 	tests := []struct {
 		name    string
@@ -268,7 +823,7 @@ func TestURLUseCase_DeleteURLs(t *testing.T) {
 			name: "удаление URL, кейс 1",
 			urls: urlsOut,
 			mock: func(mockRepo *mocks.MockURLRepository) {
-				mockRepo.EXPECT().Delete(gomock.Any(), urlsOut).Return(nil)
+				mockRepo.EXPECT().Delete(gomock.Any(), urlsOut).Return(urlsOut, nil)
 			},
 			wantErr: nil,
 		},
@@ -291,24 +846,56 @@ func TestURLUseCase_DeleteURLs(t *testing.T) {
 }
 
 func TestURLUseCase_GetStats(t *testing.T) {
-	URLs, users := 100, 10
+	from := time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2026, 10, 19, 0, 0, 0, 0, time.UTC)
+	stats := &models.Stats{CountURL: 100, CountUsers: 10, CountActive: 95, CountDeleted: 5}
 
 	tests := []struct {
-		name      string
-		mock      func(*mocks.MockURLRepository)
-		wantURLs  int
-		wantUsers int
-		wantErr   error
+		name    string
+		filter  models.StatsFilter
+		mock    func(*mocks.MockURLRepository)
+		want    *models.Stats
+		wantErr error
 	}{
 		{
-			name: "кейс 1",
+			name:   "кейс 1, период и размер рейтингов заданы",
+			filter: models.StatsFilter{From: from, To: to, Top: 5},
 			mock: func(mockRepo *mocks.MockURLRepository) {
-				mockRepo.EXPECT().GetCountURLs(gomock.Any()).Return(URLs, nil)
-				mockRepo.EXPECT().GetCountUsers(gomock.Any()).Return(users, nil)
+				mockRepo.EXPECT().GetStats(gomock.Any(), models.StatsFilter{From: from, To: to, Top: 5}).Return(stats, nil)
 			},
-			wantURLs:  URLs,
-			wantUsers: users,
-			wantErr:   nil,
+			want:    stats,
+			wantErr: nil,
+		},
+		{
+			name:   "кейс 2, начало периода и размер рейтингов по умолчанию",
+			filter: models.StatsFilter{To: to, Top: 1000},
+			mock: func(mockRepo *mocks.MockURLRepository) {
+				mockRepo.EXPECT().GetStats(gomock.Any(), models.StatsFilter{From: to.Add(-statsDefaultPeriod), To: to, Top: statsMaxTop}).Return(stats, nil)
+			},
+			want:    stats,
+			wantErr: nil,
+		},
+		{
+			name:   "кейс 3, период по умолчанию",
+			filter: models.StatsFilter{},
+			mock: func(mockRepo *mocks.MockURLRepository) {
+				mockRepo.EXPECT().GetStats(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, filter models.StatsFilter) (*models.Stats, error) {
+						if filter.To.Sub(filter.From) != statsDefaultPeriod || filter.Top != statsDefaultTop {
+							return nil, constants.ErrorInvalidStatsRange
+						}
+						return stats, nil
+					})
+			},
+			want:    stats,
+			wantErr: nil,
+		},
+		{
+			name:    "кейс 4, начало периода позже окончания",
+			filter:  models.StatsFilter{From: to, To: from},
+			mock:    func(mockRepo *mocks.MockURLRepository) {},
+			want:    nil,
+			wantErr: constants.ErrorInvalidStatsRange,
 		},
 	}
 	for _, tt := range tests {
@@ -321,15 +908,12 @@ func TestURLUseCase_GetStats(t *testing.T) {
 		service := service.NewService()
 		useCase := NewURLUseCase(mockRepo, service)
 
-		gotCountURLs, gotCountUsers, GotErr := useCase.GetStats(context.Background())
-		if gotCountURLs != tt.wantURLs {
-			t.Errorf("TestURLUseCase_GetStats() = %v, wantURLs %v", gotCountURLs, tt.wantURLs)
-		}
-		if gotCountUsers != tt.wantUsers {
-			t.Errorf("TestURLUseCase_GetStats() = %v, wantUsers %v", gotCountUsers, tt.wantUsers)
+		got, gotErr := useCase.GetStats(context.Background(), tt.filter)
+		if got != tt.want {
+			t.Errorf("TestURLUseCase_GetStats() = %v, want %v", got, tt.want)
 		}
-		if GotErr != tt.wantErr {
-			t.Errorf("TestURLUseCase_GetStats() = %v, wantUsers %v", GotErr, tt.wantErr)
+		if gotErr != tt.wantErr {
+			t.Errorf("TestURLUseCase_GetStats() = %v, wantErr %v", gotErr, tt.wantErr)
 		}
 	}
 }
@@ -379,12 +963,12 @@ func BenchmarkService(b *testing.B) {
 	})
 	b.Run("GetOriginalURL", func(b *testing.B) {
 		for i := 0; i < b.N; i++ {
-			useCase.GetOriginalURL(ctx, urlShort1)
+			useCase.GetOriginalURL(ctx, "", urlShort1)
 		}
 	})
 	b.Run("GetAllURLs", func(b *testing.B) {
 		for i := 0; i < b.N; i++ {
-			useCase.GetAllURLs(ctx, UUID)
+			useCase.GetAllURLs(ctx, UUID, models.URLFilter{})
 		}
 	})
 	b.Run("DeleteURLs", func(b *testing.B) {
@@ -401,10 +985,10 @@ func getBenchmarkMocks(ctrl *gomock.Controller) *mocks.MockURLRepository {
 	mockRepository.EXPECT().InsertOrdinary(gomock.Any(), urlOut1).Return(nil).AnyTimes()
 	mockRepository.EXPECT().InsertOrdinary(gomock.Any(), urlOut2).Return(constants.ErrorURLAlreadyExist).AnyTimes()
 	mockRepository.EXPECT().InsertBatch(gomock.Any(), urlsOut).Return(nil).AnyTimes().AnyTimes()
-	mockRepository.EXPECT().SelectShort(gomock.Any(), urlOriginal2).Return(urlShort2, nil).AnyTimes()
-	mockRepository.EXPECT().SelectShort(gomock.Any(), urlOriginal3).Return(urlShort3, nil).AnyTimes()
-	mockRepository.EXPECT().SelectOriginal(gomock.Any(), urlShort1).Return(urlOriginal1, nil).AnyTimes()
-	mockRepository.EXPECT().SelectAll(gomock.Any(), UUID).Return(urlsOut, nil).AnyTimes().AnyTimes()
-	mockRepository.EXPECT().Delete(gomock.Any(), urlsOut).Return(nil).AnyTimes().AnyTimes()
+	mockRepository.EXPECT().SelectShort(gomock.Any(), "", UUID, urlOriginal2).Return(urlShort2, nil).AnyTimes()
+	mockRepository.EXPECT().SelectShort(gomock.Any(), "", UUID, urlOriginal3).Return(urlShort3, nil).AnyTimes()
+	mockRepository.EXPECT().SelectOriginal(gomock.Any(), "", urlShort1).Return(urlOut1, nil).AnyTimes()
+	mockRepository.EXPECT().SelectAll(gomock.Any(), UUID, models.URLFilter{}).Return(urlsOut, nil).AnyTimes().AnyTimes()
+	mockRepository.EXPECT().Delete(gomock.Any(), urlsOut).Return(urlsOut, nil).AnyTimes().AnyTimes()
 	return mockRepository
 }
@@ -0,0 +1,218 @@
+package usecase
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/Di-nis/shortener-url/internal/constants"
+	"github.com/Di-nis/shortener-url/internal/logger"
+	"github.com/Di-nis/shortener-url/internal/models"
+	"github.com/Di-nis/shortener-url/internal/netguard"
+	"github.com/google/uuid"
+)
+
+// deliveriesLimit - максимальное количество записей журнала доставок в ответе.
+const deliveriesLimit = 100
+
+// publishTimeout - время ожидания постановки событий в очередь доставки.
+const publishTimeout = 10 * time.Second
+
+// WebhookRepository - интерфейс хранилища подписок на события и очереди доставок.
+type WebhookRepository interface {
+	InsertWebhook(context.Context, models.Webhook) error
+	SelectWebhooks(context.Context, string) ([]models.Webhook, error)
+	DeleteWebhook(context.Context, string, string) error
+	InsertDeliveries(context.Context, []models.WebhookDelivery) error
+	ClaimDueDeliveries(context.Context, time.Time, time.Time, int) ([]models.WebhookDelivery, error)
+	UpdateDelivery(context.Context, models.WebhookDelivery) error
+	SelectDeliveries(context.Context, string, string, int) ([]models.WebhookDelivery, error)
+}
+
+// EventPublisher - интерфейс получателя событий жизненного цикла URL.
+type EventPublisher interface {
+	Publish(context.Context, models.WebhookEvent) error
+}
+
+// WebhookUseCase - структура управления подписками на события и постановки событий в очередь доставки.
+type WebhookUseCase struct {
+	Repo WebhookRepository
+	// Resolver - разрешение имени хоста адреса подписки для проверки, что адрес публичный.
+	Resolver netguard.Resolver
+}
+
+// NewWebhookUseCase - создание структуры WebhookUseCase.
+func NewWebhookUseCase(repo WebhookRepository) *WebhookUseCase {
+	return &WebhookUseCase{
+		Repo:     repo,
+		Resolver: net.DefaultResolver,
+	}
+}
+
+// normalizeEvents - удаление пробелов и дубликатов типов событий.
+// Возвращает ошибку, если тип события неизвестен.
+func normalizeEvents(events []string) ([]string, error) {
+	normalized := make([]string, 0, len(events))
+	for _, event := range events {
+		event = strings.ToLower(strings.TrimSpace(event))
+		if !slices.Contains(models.WebhookEvents, event) {
+			return nil, fmt.Errorf("unknown event %q: %w", event, constants.ErrorInvalidWebhook)
+		}
+		if !slices.Contains(normalized, event) {
+			normalized = append(normalized, event)
+		}
+	}
+	if len(normalized) == 0 {
+		return nil, nil
+	}
+	return normalized, nil
+}
+
+// generateSecret - генерация секрета для подписи событий.
+func generateSecret() (string, error) {
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(secret), nil
+}
+
+// CreateWebhook - регистрация подписки пользователя на события.
+// Секрет для проверки подписи возвращается только при создании.
+func (webhookUseCase *WebhookUseCase) CreateWebhook(ctx context.Context, webhook models.Webhook) (models.Webhook, error) {
+	webhook.URL = strings.TrimSpace(webhook.URL)
+	parsedURL, err := url.Parse(webhook.URL)
+	if err != nil || (parsedURL.Scheme != "http" && parsedURL.Scheme != "https") || parsedURL.Host == "" {
+		return models.Webhook{}, fmt.Errorf("invalid url %q: %w", webhook.URL, constants.ErrorInvalidWebhook)
+	}
+	// события не доставляются на локальные, частные и служебные адреса
+	if err = netguard.CheckHost(ctx, webhookUseCase.Resolver, parsedURL.Hostname()); err != nil {
+		return models.Webhook{}, fmt.Errorf("url %q is not public: %w", webhook.URL, constants.ErrorInvalidWebhook)
+	}
+
+	webhook.Events, err = normalizeEvents(webhook.Events)
+	if err != nil {
+		return models.Webhook{}, err
+	}
+
+	webhook.Secret, err = generateSecret()
+	if err != nil {
+		return models.Webhook{}, fmt.Errorf("path: internal/usecase/webhook.go, func CreateWebhook(), failed to generate secret: %w", err)
+	}
+	webhook.ID = uuid.NewString()
+	webhook.CreatedAt = time.Now().UTC()
+
+	if err = webhookUseCase.Repo.InsertWebhook(ctx, webhook); err != nil {
+		return models.Webhook{}, err
+	}
+	return webhook, nil
+}
+
+// GetWebhooks - получение подписок пользователя.
+func (webhookUseCase *WebhookUseCase) GetWebhooks(ctx context.Context, userID string) ([]models.Webhook, error) {
+	return webhookUseCase.Repo.SelectWebhooks(ctx, userID)
+}
+
+// DeleteWebhook - удаление подписки пользователя.
+func (webhookUseCase *WebhookUseCase) DeleteWebhook(ctx context.Context, userID, webhookID string) error {
+	if _, err := uuid.Parse(webhookID); err != nil {
+		return constants.ErrorNotFound
+	}
+	return webhookUseCase.Repo.DeleteWebhook(ctx, userID, webhookID)
+}
+
+// GetDeliveries - получение журнала доставок подписки пользователя.
+func (webhookUseCase *WebhookUseCase) GetDeliveries(ctx context.Context, userID, webhookID string) ([]models.WebhookDelivery, error) {
+	if _, err := uuid.Parse(webhookID); err != nil {
+		return nil, constants.ErrorNotFound
+	}
+
+	webhooks, err := webhookUseCase.Repo.SelectWebhooks(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	owned := slices.ContainsFunc(webhooks, func(webhook models.Webhook) bool {
+		return webhook.ID == webhookID
+	})
+	if !owned {
+		return nil, constants.ErrorNotFound
+	}
+
+	return webhookUseCase.Repo.SelectDeliveries(ctx, userID, webhookID, deliveriesLimit)
+}
+
+// Publish - постановка события в очередь доставки для всех подходящих подписок владельца URL.
+func (webhookUseCase *WebhookUseCase) Publish(ctx context.Context, event models.WebhookEvent) error {
+	if event.UserID == "" {
+		return nil
+	}
+
+	webhooks, err := webhookUseCase.Repo.SelectWebhooks(ctx, event.UserID)
+	if err != nil {
+		return err
+	}
+
+	if event.ID == "" {
+		event.ID = uuid.NewString()
+	}
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("path: internal/usecase/webhook.go, func Publish(), failed to marshal event: %w", err)
+	}
+
+	now := time.Now().UTC()
+	deliveries := make([]models.WebhookDelivery, 0, len(webhooks))
+	for _, webhook := range webhooks {
+		if len(webhook.Events) > 0 && !slices.Contains(webhook.Events, event.Type) {
+			continue
+		}
+		deliveries = append(deliveries, models.WebhookDelivery{
+			ID:            uuid.NewString(),
+			WebhookID:     webhook.ID,
+			EventType:     event.Type,
+			Payload:       payload,
+			Status:        models.DeliveryPending,
+			NextAttemptAt: now,
+			CreatedAt:     now,
+		})
+	}
+	if len(deliveries) == 0 {
+		return nil
+	}
+	return webhookUseCase.Repo.InsertDeliveries(ctx, deliveries)
+}
+
+// publish - постановка событий в очередь доставки.
+// Выполняется до завершения операции, породившей событие, поэтому события не теряются
+// при остановке сервера. Ошибки не влияют на результат операции.
+func (urlUseCase *URLUseCase) publish(ctx context.Context, eventType string, urls ...models.URLBase) {
+	if urlUseCase.Events == nil || len(urls) == 0 {
+		return
+	}
+
+	// отмена запроса клиентом не прерывает постановку событий уже выполненной операции
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), publishTimeout)
+	defer cancel()
+
+	now := time.Now().UTC()
+	for _, url := range urls {
+		event := models.WebhookEvent{
+			Type:       eventType,
+			UserID:     url.UUID,
+			Short:      url.Short,
+			Domain:     url.Domain,
+			Original:   url.Original,
+			OccurredAt: now,
+		}
+		if err := urlUseCase.Events.Publish(ctx, event); err != nil {
+			logger.Sugar.Warnw("failed to publish event", "type", event.Type, "short", event.Short, "error", err)
+		}
+	}
+}
@@ -0,0 +1,290 @@
+package usecase
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/netip"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Di-nis/shortener-url/internal/constants"
+	"github.com/Di-nis/shortener-url/internal/mocks"
+	"github.com/Di-nis/shortener-url/internal/models"
+	"github.com/Di-nis/shortener-url/internal/service"
+	"github.com/golang/mock/gomock"
+)
+
+// webhookID - идентификатор подписки для тестов.
+const webhookID = "6f9619ff-8b86-d011-b42d-00cf4fc964ff"
+
+// publisherStub - получатель событий для тестов.
+type publisherStub struct {
+	events chan models.WebhookEvent
+}
+
+func (p publisherStub) Publish(ctx context.Context, event models.WebhookEvent) error {
+	p.events <- event
+	return nil
+}
+
+// resolverStub - разрешение имен хостов для тестов.
+type resolverStub map[string][]netip.Addr
+
+func (r resolverStub) LookupNetIP(ctx context.Context, network, host string) ([]netip.Addr, error) {
+	addrs, ok := r[host]
+	if !ok {
+		return nil, errors.New("no such host")
+	}
+	return addrs, nil
+}
+
+// webhookResolver - адреса хостов подписок в тестах.
+var webhookResolver = resolverStub{
+	"hooks.example.com":    {netip.MustParseAddr("93.184.216.34")},
+	"internal.example.com": {netip.MustParseAddr("10.0.0.1")},
+}
+
+func TestWebhookUseCase_CreateWebhook(t *testing.T) {
+	tests := []struct {
+		name       string
+		webhook    models.Webhook
+		mock       func(*mocks.MockURLRepository)
+		wantEvents []string
+		wantErr    error
+	}{
+		{
+			name:    "создание подписки на выбранные события",
+			webhook: models.Webhook{UUID: UUID, URL: " https://hooks.example.com/shortener ", Events: []string{"url.created", "URL.Deleted", "url.created"}},
+			mock: func(mockRepo *mocks.MockURLRepository) {
+				mockRepo.EXPECT().InsertWebhook(gomock.Any(), gomock.Any()).Return(nil)
+			},
+			wantEvents: []string{models.EventURLCreated, models.EventURLDeleted},
+		},
+		{
+			name:    "создание подписки на все события",
+			webhook: models.Webhook{UUID: UUID, URL: "http://hooks.example.com"},
+			mock: func(mockRepo *mocks.MockURLRepository) {
+				mockRepo.EXPECT().InsertWebhook(gomock.Any(), gomock.Any()).Return(nil)
+			},
+		},
+		{
+			name:    "неподдерживаемая схема адреса",
+			webhook: models.Webhook{UUID: UUID, URL: "ftp://hooks.example.com"},
+			mock:    func(mockRepo *mocks.MockURLRepository) {},
+			wantErr: constants.ErrorInvalidWebhook,
+		},
+		{
+			name:    "адрес loopback",
+			webhook: models.Webhook{UUID: UUID, URL: "http://127.0.0.1:6379/"},
+			mock:    func(mockRepo *mocks.MockURLRepository) {},
+			wantErr: constants.ErrorInvalidWebhook,
+		},
+		{
+			name:    "адрес метаданных облака",
+			webhook: models.Webhook{UUID: UUID, URL: "http://169.254.169.254/latest/meta-data/"},
+			mock:    func(mockRepo *mocks.MockURLRepository) {},
+			wantErr: constants.ErrorInvalidWebhook,
+		},
+		{
+			name:    "имя разрешается во внутренний адрес",
+			webhook: models.Webhook{UUID: UUID, URL: "https://internal.example.com/hook"},
+			mock:    func(mockRepo *mocks.MockURLRepository) {},
+			wantErr: constants.ErrorInvalidWebhook,
+		},
+		{
+			name:    "имя не разрешается",
+			webhook: models.Webhook{UUID: UUID, URL: "https://missing.example.com/hook"},
+			mock:    func(mockRepo *mocks.MockURLRepository) {},
+			wantErr: constants.ErrorInvalidWebhook,
+		},
+		{
+			name:    "неизвестный тип события",
+			webhook: models.Webhook{UUID: UUID, URL: "https://hooks.example.com", Events: []string{"url.updated"}},
+			mock:    func(mockRepo *mocks.MockURLRepository) {},
+			wantErr: constants.ErrorInvalidWebhook,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockRepo := mocks.NewMockURLRepository(ctrl)
+			tt.mock(mockRepo)
+
+			webhookUseCase := NewWebhookUseCase(mockRepo)
+			webhookUseCase.Resolver = webhookResolver
+
+			got, gotErr := webhookUseCase.CreateWebhook(context.Background(), tt.webhook)
+			if !errors.Is(gotErr, tt.wantErr) {
+				t.Fatalf("CreateWebhook() = %v, wantErr %v", gotErr, tt.wantErr)
+			}
+			if tt.wantErr != nil {
+				return
+			}
+			if got.ID == "" || len(got.Secret) != 64 || got.URL != strings.TrimSpace(tt.webhook.URL) {
+				t.Errorf("CreateWebhook() = %v", got)
+			}
+			if len(got.Events) != len(tt.wantEvents) {
+				t.Errorf("CreateWebhook() events = %v, want %v", got.Events, tt.wantEvents)
+			}
+			for i := range tt.wantEvents {
+				if got.Events[i] != tt.wantEvents[i] {
+					t.Errorf("CreateWebhook() events = %v, want %v", got.Events, tt.wantEvents)
+				}
+			}
+		})
+	}
+}
+
+func TestWebhookUseCase_GetDeliveries(t *testing.T) {
+	tests := []struct {
+		name      string
+		webhookID string
+		mock      func(*mocks.MockURLRepository)
+		wantErr   error
+	}{
+		{
+			name:      "журнал доставок подписки пользователя",
+			webhookID: webhookID,
+			mock: func(mockRepo *mocks.MockURLRepository) {
+				mockRepo.EXPECT().SelectWebhooks(gomock.Any(), UUID).Return([]models.Webhook{{ID: webhookID}}, nil)
+				mockRepo.EXPECT().SelectDeliveries(gomock.Any(), UUID, webhookID, deliveriesLimit).Return(nil, nil)
+			},
+		},
+		{
+			name:      "подписка другого пользователя",
+			webhookID: webhookID,
+			mock: func(mockRepo *mocks.MockURLRepository) {
+				mockRepo.EXPECT().SelectWebhooks(gomock.Any(), UUID).Return(nil, nil)
+			},
+			wantErr: constants.ErrorNotFound,
+		},
+		{
+			name:      "некорректный идентификатор подписки",
+			webhookID: "not-a-uuid",
+			mock:      func(mockRepo *mocks.MockURLRepository) {},
+			wantErr:   constants.ErrorNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockRepo := mocks.NewMockURLRepository(ctrl)
+			tt.mock(mockRepo)
+
+			_, gotErr := NewWebhookUseCase(mockRepo).GetDeliveries(context.Background(), UUID, tt.webhookID)
+			if !errors.Is(gotErr, tt.wantErr) {
+				t.Errorf("GetDeliveries() = %v, wantErr %v", gotErr, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestWebhookUseCase_Publish(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	event := models.WebhookEvent{Type: models.EventURLDeleted, UserID: UUID, Short: urlShort1}
+
+	mockRepo := mocks.NewMockURLRepository(ctrl)
+	mockRepo.EXPECT().SelectWebhooks(gomock.Any(), UUID).Return([]models.Webhook{
+		{ID: "all"},
+		{ID: "created", Events: []string{models.EventURLCreated}},
+		{ID: "deleted", Events: []string{models.EventURLCreated, models.EventURLDeleted}},
+	}, nil)
+	mockRepo.EXPECT().InsertDeliveries(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(ctx context.Context, deliveries []models.WebhookDelivery) error {
+			if len(deliveries) != 2 || deliveries[0].WebhookID != "all" || deliveries[1].WebhookID != "deleted" {
+				t.Errorf("Publish() deliveries = %v", deliveries)
+			}
+			for _, delivery := range deliveries {
+				var payload models.WebhookEvent
+				if err := json.Unmarshal(delivery.Payload, &payload); err != nil || payload.Short != urlShort1 || payload.ID == "" {
+					t.Errorf("Publish() payload = %s, error %v", delivery.Payload, err)
+				}
+				if delivery.Status != models.DeliveryPending || delivery.EventType != models.EventURLDeleted {
+					t.Errorf("Publish() delivery = %v", delivery)
+				}
+			}
+			return nil
+		})
+
+	if err := NewWebhookUseCase(mockRepo).Publish(context.Background(), event); err != nil {
+		t.Errorf("Publish() = %v", err)
+	}
+}
+
+func TestURLUseCase_CreateURLOrdinaryPublish(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockURLRepository(ctrl)
+	mockRepo.EXPECT().IsUserBlocked(gomock.Any(), UUID).Return(false, nil)
+	mockRepo.EXPECT().InsertOrdinary(gomock.Any(), urlOut1).Return(nil)
+
+	events := make(chan models.WebhookEvent, 1)
+	useCase := NewURLUseCase(mockRepo, service.NewService())
+	useCase.Events = publisherStub{events: events}
+
+	if _, err := useCase.CreateURLOrdinary(context.Background(), urlIn1); err != nil {
+		t.Fatalf("CreateURLOrdinary() = %v", err)
+	}
+
+	select {
+	case event := <-events:
+		if event.Type != models.EventURLCreated || event.UserID != UUID || event.Short != urlShort1 || event.Original != urlOriginal1 {
+			t.Errorf("CreateURLOrdinary() event = %v", event)
+		}
+	case <-time.After(time.Second):
+		t.Error("CreateURLOrdinary(), event was not published")
+	}
+}
+
+func TestURLUseCase_DeleteURLsPublish(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	deleted := urlOut1
+	deleted.DeletedFlag = true
+
+	// удаляется только URL пользователя, чужой и несуществующий URL не изменяются
+	mockRepo := mocks.NewMockURLRepository(ctrl)
+	mockRepo.EXPECT().Delete(gomock.Any(), gomock.Len(1)).
+		DoAndReturn(func(_ context.Context, urls []models.URLBase) ([]models.URLBase, error) {
+			if urls[0].Short == urlShort1 {
+				return []models.URLBase{deleted}, nil
+			}
+			return nil, nil
+		}).Times(3)
+
+	events := make(chan models.WebhookEvent, 3)
+	useCase := NewURLUseCase(mockRepo, service.NewService())
+	useCase.Events = publisherStub{events: events}
+
+	urls := []models.URLBase{
+		{UUID: UUID, Short: urlShort1},
+		{UUID: UUID, Short: urlShort2},
+		{UUID: UUID, Short: "missing"},
+	}
+	if err := useCase.DeleteURLs(context.Background(), urls); err != nil {
+		t.Fatalf("DeleteURLs() = %v", err)
+	}
+
+	// события публикуются до возврата из DeleteURLs
+	close(events)
+	var got []models.WebhookEvent
+	for event := range events {
+		got = append(got, event)
+	}
+	if len(got) != 1 {
+		t.Fatalf("DeleteURLs() events = %v, want 1 event", got)
+	}
+	if got[0].Type != models.EventURLDeleted || got[0].Short != urlShort1 || got[0].Original != urlOriginal1 {
+		t.Errorf("DeleteURLs() event = %v", got[0])
+	}
+}
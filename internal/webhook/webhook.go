@@ -0,0 +1,215 @@
+// Package webhook реализует фоновую доставку событий жизненного цикла URL на адреса подписок
+// с подписью HMAC-SHA256, повторными попытками и экспоненциальной задержкой между ними.
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/Di-nis/shortener-url/internal/logger"
+	"github.com/Di-nis/shortener-url/internal/models"
+)
+
+// Заголовки запроса доставки события.
+const (
+	// HeaderSignature - подпись тела запроса в формате "sha256=<hex>"
+	HeaderSignature = "X-Shortener-Signature"
+	// HeaderEvent - тип события
+	HeaderEvent = "X-Shortener-Event"
+	// HeaderDelivery - идентификатор доставки, одинаковый для всех попыток
+	HeaderDelivery = "X-Shortener-Delivery"
+)
+
+// maxErrorLength - максимальная длина сохраняемого текста ошибки.
+const maxErrorLength = 512
+
+// maxDrainBytes - максимальный объем тела ответа, который вычитывается для повторного использования соединения.
+const maxDrainBytes = 64 << 10
+
+// Repository - интерфейс очереди доставок.
+type Repository interface {
+	ClaimDueDeliveries(context.Context, time.Time, time.Time, int) ([]models.WebhookDelivery, error)
+	UpdateDelivery(context.Context, models.WebhookDelivery) error
+}
+
+// Options - параметры доставки.
+type Options struct {
+	// PollInterval - период проверки очереди
+	PollInterval time.Duration
+	// Timeout - время ожидания ответа получателя
+	Timeout time.Duration
+	// MaxAttempts - максимальное число попыток доставки
+	MaxAttempts int
+	// BackoffBase - задержка перед второй попыткой, далее удваивается
+	BackoffBase time.Duration
+	// BackoffMax - максимальная задержка между попытками
+	BackoffMax time.Duration
+	// BatchSize - число доставок, выбираемых из очереди за один раз
+	BatchSize int
+}
+
+// Dispatcher - структура для доставки событий.
+type Dispatcher struct {
+	repo   Repository
+	client *http.Client
+	opts   Options
+}
+
+// NewDispatcher - создание структуры Dispatcher.
+// Для доставки на адреса пользователей следует передавать клиент netguard.NewPublicClient.
+func NewDispatcher(repo Repository, client *http.Client, opts Options) *Dispatcher {
+	if opts.MaxAttempts <= 0 {
+		opts.MaxAttempts = 1
+	}
+	if opts.BatchSize <= 0 {
+		opts.BatchSize = 100
+	}
+	// перенаправления не выполняются: ответ с кодом 3xx считается неуспешной доставкой
+	noRedirects := *client
+	noRedirects.CheckRedirect = func(*http.Request, []*http.Request) error {
+		return http.ErrUseLastResponse
+	}
+	return &Dispatcher{
+		repo:   repo,
+		client: &noRedirects,
+		opts:   opts,
+	}
+}
+
+// Sign - вычисление подписи тела запроса секретом подписки.
+func Sign(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// Run - периодическая доставка событий из очереди до отмены контекста.
+func (d *Dispatcher) Run(ctx context.Context) {
+	ticker := time.NewTicker(d.opts.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		if err := d.DeliverDue(ctx); err != nil && ctx.Err() == nil {
+			logger.Sugar.Warnw("failed to deliver webhook events", "error", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// DeliverDue - однократная доставка событий, время отправки которых наступило.
+func (d *Dispatcher) DeliverDue(ctx context.Context) error {
+	for {
+		now := time.Now().UTC()
+		// повторная выборка доставки возможна только после истечения времени ожидания ответа
+		deliveries, err := d.repo.ClaimDueDeliveries(ctx, now, now.Add(d.lease()), d.opts.BatchSize)
+		if err != nil {
+			return fmt.Errorf("path: internal/webhook/webhook.go, func DeliverDue(), failed to claim deliveries: %w", err)
+		}
+
+		for _, delivery := range deliveries {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			delivery = d.deliver(ctx, delivery)
+			if err = d.repo.UpdateDelivery(ctx, delivery); err != nil {
+				logger.Sugar.Warnw("failed to save webhook delivery", "delivery", delivery.ID, "error", err)
+			}
+		}
+
+		if len(deliveries) < d.opts.BatchSize {
+			return ctx.Err()
+		}
+	}
+}
+
+// lease - время, на которое доставка исключается из очереди на время отправки.
+func (d *Dispatcher) lease() time.Duration {
+	return 2*d.opts.Timeout + time.Minute
+}
+
+// deliver - попытка доставки события и расчет времени следующей попытки.
+func (d *Dispatcher) deliver(ctx context.Context, delivery models.WebhookDelivery) models.WebhookDelivery {
+	status, err := d.send(ctx, delivery)
+
+	now := time.Now().UTC()
+	delivery.Attempts++
+	delivery.LastStatusCode = status
+	delivery.LastError = ""
+	delivery.UpdatedAt = now
+
+	if err == nil && status >= http.StatusOK && status < http.StatusMultipleChoices {
+		delivery.Status = models.DeliveryDelivered
+		return delivery
+	}
+
+	if err != nil {
+		delivery.LastError = err.Error()
+	} else {
+		delivery.LastError = http.StatusText(status)
+	}
+	if len(delivery.LastError) > maxErrorLength {
+		delivery.LastError = delivery.LastError[:maxErrorLength]
+	}
+
+	if delivery.Attempts >= d.opts.MaxAttempts {
+		delivery.Status = models.DeliveryFailed
+		return delivery
+	}
+	delivery.Status = models.DeliveryPending
+	delivery.NextAttemptAt = now.Add(d.backoff(delivery.Attempts))
+	return delivery
+}
+
+// backoff - задержка перед следующей попыткой после attempts неудачных попыток.
+func (d *Dispatcher) backoff(attempts int) time.Duration {
+	delay := d.opts.BackoffBase
+	for i := 1; i < attempts; i++ {
+		delay *= 2
+		if d.opts.BackoffMax > 0 && delay >= d.opts.BackoffMax {
+			return d.opts.BackoffMax
+		}
+	}
+	if d.opts.BackoffMax > 0 && delay > d.opts.BackoffMax {
+		return d.opts.BackoffMax
+	}
+	return delay
+}
+
+// send - отправка события с ограничением времени ожидания.
+func (d *Dispatcher) send(ctx context.Context, delivery models.WebhookDelivery) (int, error) {
+	if d.opts.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, d.opts.Timeout)
+		defer cancel()
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, delivery.URL, bytes.NewReader(delivery.Payload))
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(HeaderEvent, delivery.EventType)
+	req.Header.Set(HeaderDelivery, delivery.ID)
+	req.Header.Set(HeaderSignature, Sign(delivery.Secret, delivery.Payload))
+
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, io.LimitReader(resp.Body, maxDrainBytes))
+
+	return resp.StatusCode, nil
+}
@@ -0,0 +1,256 @@
+package webhook
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/Di-nis/shortener-url/internal/models"
+	"github.com/Di-nis/shortener-url/internal/netguard"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// repoStub - очередь доставок для тестов.
+type repoStub struct {
+	mu         sync.Mutex
+	deliveries []models.WebhookDelivery
+}
+
+func (r *repoStub) ClaimDueDeliveries(ctx context.Context, now, leaseUntil time.Time, limit int) ([]models.WebhookDelivery, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var due []models.WebhookDelivery
+	for i := range r.deliveries {
+		if len(due) < limit && r.deliveries[i].Status == models.DeliveryPending && !r.deliveries[i].NextAttemptAt.After(now) {
+			r.deliveries[i].NextAttemptAt = leaseUntil
+			due = append(due, r.deliveries[i])
+		}
+	}
+	return due, nil
+}
+
+func (r *repoStub) UpdateDelivery(ctx context.Context, delivery models.WebhookDelivery) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for i := range r.deliveries {
+		if r.deliveries[i].ID == delivery.ID {
+			r.deliveries[i] = delivery
+		}
+	}
+	return nil
+}
+
+func TestSign(t *testing.T) {
+	// значение получено командой: printf '{"type":"url.created"}' | openssl dgst -sha256 -hmac secret
+	want := "sha256=379309e97887d965505d55a3e76c340cb524d481447d0db62e5d1cb330250397"
+	assert.Equal(t, want, Sign("secret", []byte(`{"type":"url.created"}`)))
+	assert.NotEqual(t, want, Sign("other", []byte(`{"type":"url.created"}`)))
+}
+
+func TestDispatcher_DeliverDue(t *testing.T) {
+	payload := []byte(`{"type":"url.created","short_url":"lJJpJV7h"}`)
+
+	tests := []struct {
+		name         string
+		status       int
+		attempts     int
+		wantStatus   string
+		wantAttempts int
+		wantRetry    time.Duration
+	}{
+		{
+			name:         "событие доставлено",
+			status:       http.StatusNoContent,
+			wantStatus:   models.DeliveryDelivered,
+			wantAttempts: 1,
+		},
+		{
+			name:         "ошибка получателя, первая повторная попытка",
+			status:       http.StatusInternalServerError,
+			wantStatus:   models.DeliveryPending,
+			wantAttempts: 1,
+			wantRetry:    time.Second,
+		},
+		{
+			name:         "ошибка получателя, задержка удваивается",
+			status:       http.StatusBadGateway,
+			attempts:     2,
+			wantStatus:   models.DeliveryPending,
+			wantAttempts: 3,
+			wantRetry:    4 * time.Second,
+		},
+		{
+			name:         "ошибка получателя, задержка ограничена",
+			status:       http.StatusBadGateway,
+			attempts:     5,
+			wantStatus:   models.DeliveryPending,
+			wantAttempts: 6,
+			wantRetry:    10 * time.Second,
+		},
+		{
+			name:         "попытки исчерпаны",
+			status:       http.StatusNotFound,
+			attempts:     7,
+			wantStatus:   models.DeliveryFailed,
+			wantAttempts: 8,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var received *http.Request
+			var body []byte
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				received = r
+				body, _ = io.ReadAll(r.Body)
+				w.WriteHeader(tt.status)
+			}))
+			defer server.Close()
+
+			repo := &repoStub{deliveries: []models.WebhookDelivery{{
+				ID:            "3f1c2a8e-5b7d-4e0a-9c6f-2d8b1e4a7c90",
+				EventType:     models.EventURLCreated,
+				Payload:       payload,
+				Status:        models.DeliveryPending,
+				Attempts:      tt.attempts,
+				NextAttemptAt: time.Now().Add(-time.Second),
+				URL:           server.URL,
+				Secret:        "secret",
+			}}}
+			dispatcher := NewDispatcher(repo, server.Client(), Options{
+				Timeout:     time.Second,
+				MaxAttempts: 8,
+				BackoffBase: time.Second,
+				BackoffMax:  10 * time.Second,
+			})
+
+			require.NoError(t, dispatcher.DeliverDue(context.Background()))
+
+			require.NotNil(t, received)
+			assert.Equal(t, http.MethodPost, received.Method)
+			assert.Equal(t, payload, body)
+			assert.Equal(t, Sign("secret", payload), received.Header.Get(HeaderSignature))
+			assert.Equal(t, models.EventURLCreated, received.Header.Get(HeaderEvent))
+			assert.Equal(t, "3f1c2a8e-5b7d-4e0a-9c6f-2d8b1e4a7c90", received.Header.Get(HeaderDelivery))
+
+			got := repo.deliveries[0]
+			assert.Equal(t, tt.wantStatus, got.Status)
+			assert.Equal(t, tt.wantAttempts, got.Attempts)
+			assert.Equal(t, tt.status, got.LastStatusCode)
+			assert.Equal(t, tt.wantStatus == models.DeliveryDelivered, got.LastError == "")
+			if tt.wantRetry > 0 {
+				assert.WithinDuration(t, time.Now().Add(tt.wantRetry), got.NextAttemptAt, 500*time.Millisecond)
+			}
+		})
+	}
+}
+
+func TestDispatcher_DeliverDueRedirect(t *testing.T) {
+	var redirected atomic.Bool
+	mux := http.NewServeMux()
+	mux.HandleFunc("/hook", func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "/internal", http.StatusFound)
+	})
+	mux.HandleFunc("/internal", func(w http.ResponseWriter, r *http.Request) {
+		redirected.Store(true)
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	repo := &repoStub{deliveries: []models.WebhookDelivery{{
+		ID:            "3f1c2a8e-5b7d-4e0a-9c6f-2d8b1e4a7c90",
+		EventType:     models.EventURLCreated,
+		Status:        models.DeliveryPending,
+		NextAttemptAt: time.Now().Add(-time.Second),
+		URL:           server.URL + "/hook",
+	}}}
+	dispatcher := NewDispatcher(repo, server.Client(), Options{Timeout: time.Second, MaxAttempts: 1})
+
+	require.NoError(t, dispatcher.DeliverDue(context.Background()))
+
+	// перенаправление не выполняется и считается неуспешной доставкой
+	got := repo.deliveries[0]
+	assert.False(t, redirected.Load(), "redirect is followed")
+	assert.Equal(t, models.DeliveryFailed, got.Status)
+	assert.Equal(t, http.StatusFound, got.LastStatusCode)
+}
+
+func TestDispatcher_DeliverDuePublicClient(t *testing.T) {
+	var requested atomic.Bool
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requested.Store(true)
+	}))
+	defer server.Close()
+
+	repo := &repoStub{deliveries: []models.WebhookDelivery{{
+		ID:            "3f1c2a8e-5b7d-4e0a-9c6f-2d8b1e4a7c90",
+		EventType:     models.EventURLCreated,
+		Status:        models.DeliveryPending,
+		NextAttemptAt: time.Now().Add(-time.Second),
+		URL:           server.URL,
+	}}}
+	dispatcher := NewDispatcher(repo, netguard.NewPublicClient(), Options{Timeout: time.Second, MaxAttempts: 1})
+
+	require.NoError(t, dispatcher.DeliverDue(context.Background()))
+
+	// адрес loopback отклоняется при установке соединения
+	got := repo.deliveries[0]
+	assert.False(t, requested.Load(), "request reached loopback server")
+	assert.Equal(t, models.DeliveryFailed, got.Status)
+	assert.Contains(t, got.LastError, netguard.ErrForbiddenAddress.Error())
+}
+
+func TestDispatcher_Run(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// первая попытка завершается ошибкой, вторая успешна
+		if calls.Add(1) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+	}))
+	defer server.Close()
+
+	repo := &repoStub{deliveries: []models.WebhookDelivery{{
+		ID:            "3f1c2a8e-5b7d-4e0a-9c6f-2d8b1e4a7c90",
+		EventType:     models.EventURLDeleted,
+		Payload:       []byte(`{}`),
+		Status:        models.DeliveryPending,
+		NextAttemptAt: time.Now(),
+		URL:           server.URL,
+	}}}
+	dispatcher := NewDispatcher(repo, server.Client(), Options{
+		PollInterval: 10 * time.Millisecond,
+		Timeout:      time.Second,
+		MaxAttempts:  3,
+		BackoffBase:  20 * time.Millisecond,
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		dispatcher.Run(ctx)
+		close(done)
+	}()
+
+	assert.Eventually(t, func() bool {
+		repo.mu.Lock()
+		defer repo.mu.Unlock()
+		return repo.deliveries[0].Status == models.DeliveryDelivered
+	}, time.Second, 10*time.Millisecond)
+	cancel()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("dispatcher did not stop after context cancellation")
+	}
+	assert.Equal(t, int32(2), calls.Load())
+	assert.Equal(t, 2, repo.deliveries[0].Attempts)
+}
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_url_tags_tag;
+DROP TABLE IF EXISTS url_tags;
+ALTER TABLE urls DROP COLUMN folder;
\ No newline at end of file
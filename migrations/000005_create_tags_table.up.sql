@@ -0,0 +1,10 @@
+ALTER TABLE urls
+ADD COLUMN folder VARCHAR(255) NOT NULL DEFAULT '';
+
+CREATE TABLE url_tags (
+    url_id INTEGER NOT NULL REFERENCES urls(id) ON DELETE CASCADE,
+    tag VARCHAR(64) NOT NULL,
+    PRIMARY KEY (url_id, tag)
+);
+
+CREATE INDEX idx_url_tags_tag ON url_tags(tag);
\ No newline at end of file
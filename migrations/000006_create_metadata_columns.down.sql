@@ -0,0 +1,2 @@
+ALTER TABLE urls DROP COLUMN description;
+ALTER TABLE urls DROP COLUMN title;
\ No newline at end of file
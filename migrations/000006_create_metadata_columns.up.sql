@@ -0,0 +1,3 @@
+ALTER TABLE urls
+ADD COLUMN title TEXT NOT NULL DEFAULT '',
+ADD COLUMN description TEXT NOT NULL DEFAULT '';
\ No newline at end of file
@@ -0,0 +1,2 @@
+ALTER TABLE urls DROP COLUMN pass_path;
+ALTER TABLE urls DROP COLUMN pass_query;
\ No newline at end of file
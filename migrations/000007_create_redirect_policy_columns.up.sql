@@ -0,0 +1,3 @@
+ALTER TABLE urls
+ADD COLUMN pass_query BOOLEAN,
+ADD COLUMN pass_path BOOLEAN;
\ No newline at end of file
@@ -0,0 +1 @@
+DROP TABLE IF EXISTS url_destinations;
\ No newline at end of file
@@ -0,0 +1,8 @@
+CREATE TABLE IF NOT EXISTS url_destinations (
+    url_id INTEGER NOT NULL REFERENCES urls(id) ON DELETE CASCADE,
+    position SMALLINT NOT NULL,
+    url TEXT NOT NULL,
+    weight INTEGER NOT NULL CHECK (weight > 0),
+    clicks BIGINT NOT NULL DEFAULT 0,
+    PRIMARY KEY (url_id, position)
+);
\ No newline at end of file
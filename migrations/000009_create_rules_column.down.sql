@@ -0,0 +1 @@
+ALTER TABLE urls DROP COLUMN rules;
\ No newline at end of file
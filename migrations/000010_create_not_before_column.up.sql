@@ -0,0 +1,2 @@
+ALTER TABLE urls
+ADD COLUMN not_before TIMESTAMPTZ;
\ No newline at end of file
@@ -0,0 +1,2 @@
+ALTER TABLE urls
+ADD COLUMN max_clicks INTEGER CHECK (max_clicks >= 0);
\ No newline at end of file
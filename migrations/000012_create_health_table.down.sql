@@ -0,0 +1 @@
+DROP TABLE IF EXISTS url_health;
\ No newline at end of file
@@ -0,0 +1,7 @@
+CREATE TABLE IF NOT EXISTS url_health (
+    url_id INTEGER PRIMARY KEY REFERENCES urls(id) ON DELETE CASCADE,
+    status INTEGER NOT NULL DEFAULT 0,
+    error TEXT NOT NULL DEFAULT '',
+    checked_at TIMESTAMPTZ NOT NULL,
+    broken BOOLEAN NOT NULL DEFAULT false
+);
\ No newline at end of file
@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS blocked_users;
+
+ALTER TABLE urls DROP COLUMN IF EXISTS is_disabled;
\ No newline at end of file
@@ -0,0 +1,7 @@
+ALTER TABLE urls
+ADD COLUMN IF NOT EXISTS is_disabled BOOLEAN NOT NULL DEFAULT FALSE;
+
+CREATE TABLE IF NOT EXISTS blocked_users (
+    user_id VARCHAR(255) PRIMARY KEY,
+    blocked_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
\ No newline at end of file
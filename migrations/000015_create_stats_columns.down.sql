@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS idx_urls_clicks;
+DROP INDEX IF EXISTS idx_urls_created_at;
+
+ALTER TABLE urls DROP COLUMN IF EXISTS clicks;
+ALTER TABLE urls DROP COLUMN IF EXISTS created_at;
\ No newline at end of file
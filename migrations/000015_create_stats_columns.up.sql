@@ -0,0 +1,6 @@
+ALTER TABLE urls
+ADD COLUMN IF NOT EXISTS created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+ADD COLUMN IF NOT EXISTS clicks BIGINT NOT NULL DEFAULT 0;
+
+CREATE INDEX IF NOT EXISTS idx_urls_created_at ON urls (created_at);
+CREATE INDEX IF NOT EXISTS idx_urls_clicks ON urls (clicks DESC) WHERE NOT is_deleted;
\ No newline at end of file
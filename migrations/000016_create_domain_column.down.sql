@@ -0,0 +1,7 @@
+DROP INDEX IF EXISTS idx_urls_domain_short;
+DROP INDEX IF EXISTS idx_urls_domain_original;
+
+ALTER TABLE urls ADD CONSTRAINT urls_original_key UNIQUE (original);
+ALTER TABLE urls ADD CONSTRAINT urls_short_key UNIQUE (short);
+
+ALTER TABLE urls DROP COLUMN IF EXISTS domain;
\ No newline at end of file
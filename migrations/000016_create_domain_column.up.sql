@@ -0,0 +1,8 @@
+ALTER TABLE urls
+ADD COLUMN IF NOT EXISTS domain VARCHAR(255) NOT NULL DEFAULT '';
+
+ALTER TABLE urls DROP CONSTRAINT IF EXISTS urls_original_key;
+ALTER TABLE urls DROP CONSTRAINT IF EXISTS urls_short_key;
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_urls_domain_original ON urls (domain, original);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_urls_domain_short ON urls (domain, short);
\ No newline at end of file
@@ -0,0 +1 @@
+DROP SEQUENCE IF EXISTS urls_short_seq;
\ No newline at end of file
@@ -0,0 +1 @@
+CREATE SEQUENCE IF NOT EXISTS urls_short_seq;
\ No newline at end of file
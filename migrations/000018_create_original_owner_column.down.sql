@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS idx_urls_domain_owner_original;
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_urls_domain_original ON urls (domain, original);
+
+ALTER TABLE urls DROP COLUMN IF EXISTS original_owner;
\ No newline at end of file
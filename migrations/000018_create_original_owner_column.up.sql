@@ -0,0 +1,6 @@
+ALTER TABLE urls
+ADD COLUMN IF NOT EXISTS original_owner VARCHAR(255) NOT NULL DEFAULT '';
+
+DROP INDEX IF EXISTS idx_urls_domain_original;
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_urls_domain_owner_original ON urls (domain, original_owner, original);
\ No newline at end of file
@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS idx_urls_original_trgm;
+DROP INDEX IF EXISTS idx_urls_user_host;
+DROP INDEX IF EXISTS idx_urls_user_original;
+
+ALTER TABLE urls DROP COLUMN IF EXISTS original_host;
\ No newline at end of file
@@ -0,0 +1,13 @@
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+
+ALTER TABLE urls
+ADD COLUMN IF NOT EXISTS original_host TEXT GENERATED ALWAYS AS (
+    CASE WHEN original ~ '^[A-Za-z][A-Za-z0-9+.-]*://'
+        THEN lower(regexp_replace(original, '^[A-Za-z][A-Za-z0-9+.-]*://([^/?#]*@)?([^/?#:]*).*$', '\2'))
+        ELSE ''
+    END
+) STORED;
+
+CREATE INDEX IF NOT EXISTS idx_urls_user_original ON urls (user_id, original text_pattern_ops);
+CREATE INDEX IF NOT EXISTS idx_urls_user_host ON urls (user_id, original_host);
+CREATE INDEX IF NOT EXISTS idx_urls_original_trgm ON urls USING gin (original gin_trgm_ops);
\ No newline at end of file
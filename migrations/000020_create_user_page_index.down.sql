@@ -0,0 +1 @@
+DROP INDEX IF EXISTS idx_urls_user_id_id;
\ No newline at end of file
@@ -0,0 +1 @@
+CREATE INDEX IF NOT EXISTS idx_urls_user_id_id ON urls (user_id, id);
\ No newline at end of file
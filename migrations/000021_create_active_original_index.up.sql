@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_urls_domain_owner_original;
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_urls_domain_owner_original ON urls (domain, original_owner, original) WHERE NOT is_deleted;
\ No newline at end of file